@@ -0,0 +1,1006 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: reviewer/v1/statistics.proto
+
+package reviewerv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetStatisticsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Restricts statistics to the members of this team.
+	Team string `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	// Optional [from, to) window applied to each metric's own event time.
+	From *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+}
+
+func (x *GetStatisticsRequest) Reset() {
+	*x = GetStatisticsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_reviewer_v1_statistics_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStatisticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatisticsRequest) ProtoMessage() {}
+
+func (x *GetStatisticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_statistics_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatisticsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatisticsRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_statistics_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetStatisticsRequest) GetTeam() string {
+	if x != nil {
+		return x.Team
+	}
+	return ""
+}
+
+func (x *GetStatisticsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetStatisticsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type GetStatisticsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TotalPrs          int64             `protobuf:"varint,1,opt,name=total_prs,json=totalPrs,proto3" json:"total_prs,omitempty"`
+	AssignmentsByUser map[string]int64  `protobuf:"bytes,2,rep,name=assignments_by_user,json=assignmentsByUser,proto3" json:"assignments_by_user,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	DeclinesByUser    map[string]int64  `protobuf:"bytes,3,rep,name=declines_by_user,json=declinesByUser,proto3" json:"declines_by_user,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Users             []*UserStatistics `protobuf:"bytes,4,rep,name=users,proto3" json:"users,omitempty"`
+	Teams             []*TeamStatistics `protobuf:"bytes,5,rep,name=teams,proto3" json:"teams,omitempty"`
+}
+
+func (x *GetStatisticsResponse) Reset() {
+	*x = GetStatisticsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_reviewer_v1_statistics_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStatisticsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatisticsResponse) ProtoMessage() {}
+
+func (x *GetStatisticsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_statistics_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatisticsResponse.ProtoReflect.Descriptor instead.
+func (*GetStatisticsResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_statistics_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetStatisticsResponse) GetTotalPrs() int64 {
+	if x != nil {
+		return x.TotalPrs
+	}
+	return 0
+}
+
+func (x *GetStatisticsResponse) GetAssignmentsByUser() map[string]int64 {
+	if x != nil {
+		return x.AssignmentsByUser
+	}
+	return nil
+}
+
+func (x *GetStatisticsResponse) GetDeclinesByUser() map[string]int64 {
+	if x != nil {
+		return x.DeclinesByUser
+	}
+	return nil
+}
+
+func (x *GetStatisticsResponse) GetUsers() []*UserStatistics {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *GetStatisticsResponse) GetTeams() []*TeamStatistics {
+	if x != nil {
+		return x.Teams
+	}
+	return nil
+}
+
+type ReviewStatistics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OpenPrs                int64 `protobuf:"varint,1,opt,name=open_prs,json=openPrs,proto3" json:"open_prs,omitempty"`
+	MergedPrs              int64 `protobuf:"varint,2,opt,name=merged_prs,json=mergedPrs,proto3" json:"merged_prs,omitempty"`
+	MergedWithoutReviewers int64 `protobuf:"varint,3,opt,name=merged_without_reviewers,json=mergedWithoutReviewers,proto3" json:"merged_without_reviewers,omitempty"`
+	Assignments            int64 `protobuf:"varint,4,opt,name=assignments,proto3" json:"assignments,omitempty"`
+	ReassignmentsAway      int64 `protobuf:"varint,5,opt,name=reassignments_away,json=reassignmentsAway,proto3" json:"reassignments_away,omitempty"`
+	Declines               int64 `protobuf:"varint,6,opt,name=declines,proto3" json:"declines,omitempty"`
+	// Unset when nothing assigned to the group was merged in the window.
+	AvgTimeToMergeSeconds *float64 `protobuf:"fixed64,7,opt,name=avg_time_to_merge_seconds,json=avgTimeToMergeSeconds,proto3,oneof" json:"avg_time_to_merge_seconds,omitempty"`
+	P90TimeToMergeSeconds *float64 `protobuf:"fixed64,8,opt,name=p90_time_to_merge_seconds,json=p90TimeToMergeSeconds,proto3,oneof" json:"p90_time_to_merge_seconds,omitempty"`
+}
+
+func (x *ReviewStatistics) Reset() {
+	*x = ReviewStatistics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_reviewer_v1_statistics_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReviewStatistics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewStatistics) ProtoMessage() {}
+
+func (x *ReviewStatistics) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_statistics_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewStatistics.ProtoReflect.Descriptor instead.
+func (*ReviewStatistics) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_statistics_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ReviewStatistics) GetOpenPrs() int64 {
+	if x != nil {
+		return x.OpenPrs
+	}
+	return 0
+}
+
+func (x *ReviewStatistics) GetMergedPrs() int64 {
+	if x != nil {
+		return x.MergedPrs
+	}
+	return 0
+}
+
+func (x *ReviewStatistics) GetMergedWithoutReviewers() int64 {
+	if x != nil {
+		return x.MergedWithoutReviewers
+	}
+	return 0
+}
+
+func (x *ReviewStatistics) GetAssignments() int64 {
+	if x != nil {
+		return x.Assignments
+	}
+	return 0
+}
+
+func (x *ReviewStatistics) GetReassignmentsAway() int64 {
+	if x != nil {
+		return x.ReassignmentsAway
+	}
+	return 0
+}
+
+func (x *ReviewStatistics) GetDeclines() int64 {
+	if x != nil {
+		return x.Declines
+	}
+	return 0
+}
+
+func (x *ReviewStatistics) GetAvgTimeToMergeSeconds() float64 {
+	if x != nil && x.AvgTimeToMergeSeconds != nil {
+		return *x.AvgTimeToMergeSeconds
+	}
+	return 0
+}
+
+func (x *ReviewStatistics) GetP90TimeToMergeSeconds() float64 {
+	if x != nil && x.P90TimeToMergeSeconds != nil {
+		return *x.P90TimeToMergeSeconds
+	}
+	return 0
+}
+
+type UserStatistics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId   string            `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TeamName string            `protobuf:"bytes,2,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Stats    *ReviewStatistics `protobuf:"bytes,3,opt,name=stats,proto3" json:"stats,omitempty"`
+}
+
+func (x *UserStatistics) Reset() {
+	*x = UserStatistics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_reviewer_v1_statistics_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserStatistics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserStatistics) ProtoMessage() {}
+
+func (x *UserStatistics) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_statistics_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserStatistics.ProtoReflect.Descriptor instead.
+func (*UserStatistics) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_statistics_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UserStatistics) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserStatistics) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *UserStatistics) GetStats() *ReviewStatistics {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type TeamStatistics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TeamName string            `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Stats    *ReviewStatistics `protobuf:"bytes,2,opt,name=stats,proto3" json:"stats,omitempty"`
+}
+
+func (x *TeamStatistics) Reset() {
+	*x = TeamStatistics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_reviewer_v1_statistics_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TeamStatistics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamStatistics) ProtoMessage() {}
+
+func (x *TeamStatistics) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_statistics_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamStatistics.ProtoReflect.Descriptor instead.
+func (*TeamStatistics) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_statistics_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TeamStatistics) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *TeamStatistics) GetStats() *ReviewStatistics {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type GetFairnessReportRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Team string `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	// Defaults to the 30 days before to, which defaults to now.
+	From *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	// Allowed relative deviation from the expected share; defaults to 0.25
+	// when unset.
+	Threshold *float64 `protobuf:"fixed64,4,opt,name=threshold,proto3,oneof" json:"threshold,omitempty"`
+}
+
+func (x *GetFairnessReportRequest) Reset() {
+	*x = GetFairnessReportRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_reviewer_v1_statistics_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetFairnessReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFairnessReportRequest) ProtoMessage() {}
+
+func (x *GetFairnessReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_statistics_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFairnessReportRequest.ProtoReflect.Descriptor instead.
+func (*GetFairnessReportRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_statistics_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetFairnessReportRequest) GetTeam() string {
+	if x != nil {
+		return x.Team
+	}
+	return ""
+}
+
+func (x *GetFairnessReportRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetFairnessReportRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetFairnessReportRequest) GetThreshold() float64 {
+	if x != nil && x.Threshold != nil {
+		return *x.Threshold
+	}
+	return 0
+}
+
+type FairnessMember struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId         string  `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	IsActive       bool    `protobuf:"varint,2,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	ActiveFraction float64 `protobuf:"fixed64,3,opt,name=active_fraction,json=activeFraction,proto3" json:"active_fraction,omitempty"`
+	Assignments    int64   `protobuf:"varint,4,opt,name=assignments,proto3" json:"assignments,omitempty"`
+	ActualShare    float64 `protobuf:"fixed64,5,opt,name=actual_share,json=actualShare,proto3" json:"actual_share,omitempty"`
+	ExpectedShare  float64 `protobuf:"fixed64,6,opt,name=expected_share,json=expectedShare,proto3" json:"expected_share,omitempty"`
+	Deviation      float64 `protobuf:"fixed64,7,opt,name=deviation,proto3" json:"deviation,omitempty"`
+	Flagged        bool    `protobuf:"varint,8,opt,name=flagged,proto3" json:"flagged,omitempty"`
+}
+
+func (x *FairnessMember) Reset() {
+	*x = FairnessMember{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_reviewer_v1_statistics_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FairnessMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FairnessMember) ProtoMessage() {}
+
+func (x *FairnessMember) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_statistics_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FairnessMember.ProtoReflect.Descriptor instead.
+func (*FairnessMember) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_statistics_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *FairnessMember) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *FairnessMember) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *FairnessMember) GetActiveFraction() float64 {
+	if x != nil {
+		return x.ActiveFraction
+	}
+	return 0
+}
+
+func (x *FairnessMember) GetAssignments() int64 {
+	if x != nil {
+		return x.Assignments
+	}
+	return 0
+}
+
+func (x *FairnessMember) GetActualShare() float64 {
+	if x != nil {
+		return x.ActualShare
+	}
+	return 0
+}
+
+func (x *FairnessMember) GetExpectedShare() float64 {
+	if x != nil {
+		return x.ExpectedShare
+	}
+	return 0
+}
+
+func (x *FairnessMember) GetDeviation() float64 {
+	if x != nil {
+		return x.Deviation
+	}
+	return 0
+}
+
+func (x *FairnessMember) GetFlagged() bool {
+	if x != nil {
+		return x.Flagged
+	}
+	return false
+}
+
+type GetFairnessReportResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TeamName         string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	From             *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To               *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Threshold        float64                `protobuf:"fixed64,4,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	TotalAssignments int64                  `protobuf:"varint,5,opt,name=total_assignments,json=totalAssignments,proto3" json:"total_assignments,omitempty"`
+	Gini             float64                `protobuf:"fixed64,6,opt,name=gini,proto3" json:"gini,omitempty"`
+	MaxMinRatio      *float64               `protobuf:"fixed64,7,opt,name=max_min_ratio,json=maxMinRatio,proto3,oneof" json:"max_min_ratio,omitempty"`
+	Members          []*FairnessMember      `protobuf:"bytes,8,rep,name=members,proto3" json:"members,omitempty"`
+}
+
+func (x *GetFairnessReportResponse) Reset() {
+	*x = GetFairnessReportResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_reviewer_v1_statistics_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetFairnessReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFairnessReportResponse) ProtoMessage() {}
+
+func (x *GetFairnessReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_statistics_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFairnessReportResponse.ProtoReflect.Descriptor instead.
+func (*GetFairnessReportResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_statistics_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetFairnessReportResponse) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *GetFairnessReportResponse) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetFairnessReportResponse) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetFairnessReportResponse) GetThreshold() float64 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *GetFairnessReportResponse) GetTotalAssignments() int64 {
+	if x != nil {
+		return x.TotalAssignments
+	}
+	return 0
+}
+
+func (x *GetFairnessReportResponse) GetGini() float64 {
+	if x != nil {
+		return x.Gini
+	}
+	return 0
+}
+
+func (x *GetFairnessReportResponse) GetMaxMinRatio() float64 {
+	if x != nil && x.MaxMinRatio != nil {
+		return *x.MaxMinRatio
+	}
+	return 0
+}
+
+func (x *GetFairnessReportResponse) GetMembers() []*FairnessMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+var File_reviewer_v1_statistics_proto protoreflect.FileDescriptor
+
+var file_reviewer_v1_statistics_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x74,
+	0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b,
+	0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x86, 0x01, 0x0a,
+	0x14, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x61, 0x6d, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x61, 0x6d, 0x12, 0x2e, 0x0a, 0x04, 0x66, 0x72, 0x6f,
+	0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x2a, 0x0a, 0x02, 0x74, 0x6f, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x02, 0x74, 0x6f, 0x22, 0xf0, 0x03, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x1b, 0x0a, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x70, 0x72, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x08, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x50, 0x72, 0x73, 0x12, 0x69, 0x0a, 0x13,
+	0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x5f, 0x62, 0x79, 0x5f, 0x75,
+	0x73, 0x65, 0x72, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x72, 0x65, 0x76, 0x69,
+	0x65, 0x77, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x69,
+	0x73, 0x74, 0x69, 0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x41, 0x73,
+	0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x42, 0x79, 0x55, 0x73, 0x65, 0x72, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x11, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x42, 0x79, 0x55, 0x73, 0x65, 0x72, 0x12, 0x60, 0x0a, 0x10, 0x64, 0x65, 0x63, 0x6c, 0x69,
+	0x6e, 0x65, 0x73, 0x5f, 0x62, 0x79, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x36, 0x2e, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x44, 0x65, 0x63, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x42, 0x79,
+	0x55, 0x73, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0e, 0x64, 0x65, 0x63, 0x6c, 0x69,
+	0x6e, 0x65, 0x73, 0x42, 0x79, 0x55, 0x73, 0x65, 0x72, 0x12, 0x31, 0x0a, 0x05, 0x75, 0x73, 0x65,
+	0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x72, 0x65, 0x76, 0x69, 0x65,
+	0x77, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x69,
+	0x73, 0x74, 0x69, 0x63, 0x73, 0x52, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x12, 0x31, 0x0a, 0x05,
+	0x74, 0x65, 0x61, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x72, 0x65,
+	0x76, 0x69, 0x65, 0x77, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x61, 0x6d, 0x53, 0x74,
+	0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x52, 0x05, 0x74, 0x65, 0x61, 0x6d, 0x73, 0x1a,
+	0x44, 0x0a, 0x16, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x42, 0x79,
+	0x55, 0x73, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x41, 0x0a, 0x13, 0x44, 0x65, 0x63, 0x6c, 0x69, 0x6e, 0x65,
+	0x73, 0x42, 0x79, 0x55, 0x73, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xad, 0x03, 0x0a, 0x10, 0x52, 0x65, 0x76,
+	0x69, 0x65, 0x77, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x19, 0x0a,
+	0x08, 0x6f, 0x70, 0x65, 0x6e, 0x5f, 0x70, 0x72, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x07, 0x6f, 0x70, 0x65, 0x6e, 0x50, 0x72, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x65, 0x72, 0x67,
+	0x65, 0x64, 0x5f, 0x70, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6d, 0x65,
+	0x72, 0x67, 0x65, 0x64, 0x50, 0x72, 0x73, 0x12, 0x38, 0x0a, 0x18, 0x6d, 0x65, 0x72, 0x67, 0x65,
+	0x64, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x6f, 0x75, 0x74, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77,
+	0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x16, 0x6d, 0x65, 0x72, 0x67, 0x65,
+	0x64, 0x57, 0x69, 0x74, 0x68, 0x6f, 0x75, 0x74, 0x52, 0x65, 0x76, 0x69, 0x65, 0x77, 0x65, 0x72,
+	0x73, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x72, 0x65, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d,
+	0x65, 0x6e, 0x74, 0x73, 0x5f, 0x61, 0x77, 0x61, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x11, 0x72, 0x65, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x41, 0x77,
+	0x61, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x63, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x65, 0x63, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x3d,
+	0x0a, 0x19, 0x61, 0x76, 0x67, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x74, 0x6f, 0x5f, 0x6d, 0x65,
+	0x72, 0x67, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x01, 0x48, 0x00, 0x52, 0x15, 0x61, 0x76, 0x67, 0x54, 0x69, 0x6d, 0x65, 0x54, 0x6f, 0x4d, 0x65,
+	0x72, 0x67, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x88, 0x01, 0x01, 0x12, 0x3d, 0x0a,
+	0x19, 0x70, 0x39, 0x30, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x74, 0x6f, 0x5f, 0x6d, 0x65, 0x72,
+	0x67, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01,
+	0x48, 0x01, 0x52, 0x15, 0x70, 0x39, 0x30, 0x54, 0x69, 0x6d, 0x65, 0x54, 0x6f, 0x4d, 0x65, 0x72,
+	0x67, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x88, 0x01, 0x01, 0x42, 0x1c, 0x0a, 0x1a,
+	0x5f, 0x61, 0x76, 0x67, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x74, 0x6f, 0x5f, 0x6d, 0x65, 0x72,
+	0x67, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x42, 0x1c, 0x0a, 0x1a, 0x5f, 0x70,
+	0x39, 0x30, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x74, 0x6f, 0x5f, 0x6d, 0x65, 0x72, 0x67, 0x65,
+	0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x7b, 0x0a, 0x0e, 0x55, 0x73, 0x65, 0x72,
+	0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x61, 0x6d, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x65, 0x61, 0x6d, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x33, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x76, 0x69, 0x65, 0x77, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x52, 0x05,
+	0x73, 0x74, 0x61, 0x74, 0x73, 0x22, 0x62, 0x0a, 0x0e, 0x54, 0x65, 0x61, 0x6d, 0x53, 0x74, 0x61,
+	0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x61, 0x6d, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x65, 0x61, 0x6d,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x33, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x76, 0x69, 0x65, 0x77, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69,
+	0x63, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x22, 0xbb, 0x01, 0x0a, 0x18, 0x47, 0x65,
+	0x74, 0x46, 0x61, 0x69, 0x72, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x61, 0x6d, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x61, 0x6d, 0x12, 0x2e, 0x0a, 0x04, 0x66, 0x72,
+	0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x2a, 0x0a, 0x02, 0x74, 0x6f,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x21, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68,
+	0x6f, 0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x09, 0x74, 0x68, 0x72,
+	0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x88, 0x01, 0x01, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x74, 0x68,
+	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x22, 0x93, 0x02, 0x0a, 0x0e, 0x46, 0x61, 0x69, 0x72,
+	0x6e, 0x65, 0x73, 0x73, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x73, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x73, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65,
+	0x12, 0x27, 0x0a, 0x0f, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x66, 0x72, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x61, 0x63, 0x74, 0x69, 0x76,
+	0x65, 0x46, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x73, 0x73,
+	0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b,
+	0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x61,
+	0x63, 0x74, 0x75, 0x61, 0x6c, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0b, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x53, 0x68, 0x61, 0x72, 0x65, 0x12, 0x25,
+	0x0a, 0x0e, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x53, 0x68, 0x61, 0x72, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x64, 0x65, 0x76, 0x69, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x66, 0x6c, 0x61, 0x67, 0x67, 0x65, 0x64, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x66, 0x6c, 0x61, 0x67, 0x67, 0x65, 0x64, 0x22, 0xe5, 0x02,
+	0x0a, 0x19, 0x47, 0x65, 0x74, 0x46, 0x61, 0x69, 0x72, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x74,
+	0x65, 0x61, 0x6d, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x74, 0x65, 0x61, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x2a, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x02, 0x74, 0x6f, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f,
+	0x6c, 0x64, 0x12, 0x2b, 0x0a, 0x11, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x61, 0x73, 0x73, 0x69,
+	0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x67, 0x69, 0x6e, 0x69, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x67,
+	0x69, 0x6e, 0x69, 0x12, 0x27, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x0b, 0x6d, 0x61,
+	0x78, 0x4d, 0x69, 0x6e, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x88, 0x01, 0x01, 0x12, 0x35, 0x0a, 0x07,
+	0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61, 0x69, 0x72,
+	0x6e, 0x65, 0x73, 0x73, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62,
+	0x65, 0x72, 0x73, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x6d, 0x69, 0x6e, 0x5f,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x32, 0xcf, 0x01, 0x0a, 0x11, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73,
+	0x74, 0x69, 0x63, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x56, 0x0a, 0x0d, 0x47,
+	0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x21, 0x2e, 0x72,
+	0x65, 0x76, 0x69, 0x65, 0x77, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x22, 0x2e, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x62, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x46, 0x61, 0x69, 0x72, 0x6e, 0x65,
+	0x73, 0x73, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x25, 0x2e, 0x72, 0x65, 0x76, 0x69, 0x65,
+	0x77, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x61, 0x69, 0x72, 0x6e, 0x65,
+	0x73, 0x73, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x26, 0x2e, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x46, 0x61, 0x69, 0x72, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x4c, 0x5a, 0x4a, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x76, 0x69, 0x74, 0x6f, 0x2d, 0x74, 0x65, 0x73, 0x74,
+	0x2f, 0x70, 0x72, 0x2d, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x65, 0x72, 0x2d, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x72,
+	0x65, 0x76, 0x69, 0x65, 0x77, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x3b, 0x72, 0x65, 0x76, 0x69, 0x65,
+	0x77, 0x65, 0x72, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_reviewer_v1_statistics_proto_rawDescOnce sync.Once
+	file_reviewer_v1_statistics_proto_rawDescData = file_reviewer_v1_statistics_proto_rawDesc
+)
+
+func file_reviewer_v1_statistics_proto_rawDescGZIP() []byte {
+	file_reviewer_v1_statistics_proto_rawDescOnce.Do(func() {
+		file_reviewer_v1_statistics_proto_rawDescData = protoimpl.X.CompressGZIP(file_reviewer_v1_statistics_proto_rawDescData)
+	})
+	return file_reviewer_v1_statistics_proto_rawDescData
+}
+
+var file_reviewer_v1_statistics_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_reviewer_v1_statistics_proto_goTypes = []any{
+	(*GetStatisticsRequest)(nil),      // 0: reviewer.v1.GetStatisticsRequest
+	(*GetStatisticsResponse)(nil),     // 1: reviewer.v1.GetStatisticsResponse
+	(*ReviewStatistics)(nil),          // 2: reviewer.v1.ReviewStatistics
+	(*UserStatistics)(nil),            // 3: reviewer.v1.UserStatistics
+	(*TeamStatistics)(nil),            // 4: reviewer.v1.TeamStatistics
+	(*GetFairnessReportRequest)(nil),  // 5: reviewer.v1.GetFairnessReportRequest
+	(*FairnessMember)(nil),            // 6: reviewer.v1.FairnessMember
+	(*GetFairnessReportResponse)(nil), // 7: reviewer.v1.GetFairnessReportResponse
+	nil,                               // 8: reviewer.v1.GetStatisticsResponse.AssignmentsByUserEntry
+	nil,                               // 9: reviewer.v1.GetStatisticsResponse.DeclinesByUserEntry
+	(*timestamppb.Timestamp)(nil),     // 10: google.protobuf.Timestamp
+}
+var file_reviewer_v1_statistics_proto_depIdxs = []int32{
+	10, // 0: reviewer.v1.GetStatisticsRequest.from:type_name -> google.protobuf.Timestamp
+	10, // 1: reviewer.v1.GetStatisticsRequest.to:type_name -> google.protobuf.Timestamp
+	8,  // 2: reviewer.v1.GetStatisticsResponse.assignments_by_user:type_name -> reviewer.v1.GetStatisticsResponse.AssignmentsByUserEntry
+	9,  // 3: reviewer.v1.GetStatisticsResponse.declines_by_user:type_name -> reviewer.v1.GetStatisticsResponse.DeclinesByUserEntry
+	3,  // 4: reviewer.v1.GetStatisticsResponse.users:type_name -> reviewer.v1.UserStatistics
+	4,  // 5: reviewer.v1.GetStatisticsResponse.teams:type_name -> reviewer.v1.TeamStatistics
+	2,  // 6: reviewer.v1.UserStatistics.stats:type_name -> reviewer.v1.ReviewStatistics
+	2,  // 7: reviewer.v1.TeamStatistics.stats:type_name -> reviewer.v1.ReviewStatistics
+	10, // 8: reviewer.v1.GetFairnessReportRequest.from:type_name -> google.protobuf.Timestamp
+	10, // 9: reviewer.v1.GetFairnessReportRequest.to:type_name -> google.protobuf.Timestamp
+	10, // 10: reviewer.v1.GetFairnessReportResponse.from:type_name -> google.protobuf.Timestamp
+	10, // 11: reviewer.v1.GetFairnessReportResponse.to:type_name -> google.protobuf.Timestamp
+	6,  // 12: reviewer.v1.GetFairnessReportResponse.members:type_name -> reviewer.v1.FairnessMember
+	0,  // 13: reviewer.v1.StatisticsService.GetStatistics:input_type -> reviewer.v1.GetStatisticsRequest
+	5,  // 14: reviewer.v1.StatisticsService.GetFairnessReport:input_type -> reviewer.v1.GetFairnessReportRequest
+	1,  // 15: reviewer.v1.StatisticsService.GetStatistics:output_type -> reviewer.v1.GetStatisticsResponse
+	7,  // 16: reviewer.v1.StatisticsService.GetFairnessReport:output_type -> reviewer.v1.GetFairnessReportResponse
+	15, // [15:17] is the sub-list for method output_type
+	13, // [13:15] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_reviewer_v1_statistics_proto_init() }
+func file_reviewer_v1_statistics_proto_init() {
+	if File_reviewer_v1_statistics_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_reviewer_v1_statistics_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*GetStatisticsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_reviewer_v1_statistics_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*GetStatisticsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_reviewer_v1_statistics_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*ReviewStatistics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_reviewer_v1_statistics_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*UserStatistics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_reviewer_v1_statistics_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*TeamStatistics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_reviewer_v1_statistics_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*GetFairnessReportRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_reviewer_v1_statistics_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*FairnessMember); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_reviewer_v1_statistics_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*GetFairnessReportResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_reviewer_v1_statistics_proto_msgTypes[2].OneofWrappers = []any{}
+	file_reviewer_v1_statistics_proto_msgTypes[5].OneofWrappers = []any{}
+	file_reviewer_v1_statistics_proto_msgTypes[7].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_reviewer_v1_statistics_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_reviewer_v1_statistics_proto_goTypes,
+		DependencyIndexes: file_reviewer_v1_statistics_proto_depIdxs,
+		MessageInfos:      file_reviewer_v1_statistics_proto_msgTypes,
+	}.Build()
+	File_reviewer_v1_statistics_proto = out.File
+	file_reviewer_v1_statistics_proto_rawDesc = nil
+	file_reviewer_v1_statistics_proto_goTypes = nil
+	file_reviewer_v1_statistics_proto_depIdxs = nil
+}
@@ -37,6 +37,10 @@ echo "=== Get User Reviews ==="
 curl -X GET "$BASE_URL/users/getReview?user_id=u2"
 echo -e "\n"
 
+echo "=== List Open PRs Of Team ==="
+curl -X GET "$BASE_URL/pullRequest/list?team_name=backend&status=OPEN&limit=10"
+echo -e "\n"
+
 echo "=== Deactivate User ==="
 curl -X POST "$BASE_URL/users/setIsActive" \
   -H "Content-Type: application/json" \
@@ -56,6 +60,10 @@ curl -X POST "$BASE_URL/pullRequest/create" \
   }'
 echo -e "\n"
 
+echo "=== Get PR ==="
+curl -X GET "$BASE_URL/pullRequest/get?pull_request_id=pr-1001"
+echo -e "\n"
+
 echo "=== Reassign Reviewer ==="
 curl -X POST "$BASE_URL/pullRequest/reassign" \
   -H "Content-Type: application/json" \
@@ -65,6 +73,45 @@ curl -X POST "$BASE_URL/pullRequest/reassign" \
   }'
 echo -e "\n"
 
+echo "=== Create PR With Requested Reviewer ==="
+curl -X POST "$BASE_URL/pullRequest/create" \
+  -H "Content-Type: application/json" \
+  -d '{
+    "pull_request_id": "pr-1003",
+    "pull_request_name": "Refactor storage",
+    "author_id": "u1",
+    "requested_reviewers": ["u3"]
+  }'
+echo -e "\n"
+
+echo "=== Remove Reviewer ==="
+curl -X POST "$BASE_URL/pullRequest/removeReviewer" \
+  -H "Content-Type: application/json" \
+  -d '{
+    "pull_request_id": "pr-1003",
+    "user_id": "u3"
+  }'
+echo -e "\n"
+
+echo "=== Add Reviewer ==="
+curl -X POST "$BASE_URL/pullRequest/addReviewer" \
+  -H "Content-Type: application/json" \
+  -d '{
+    "pull_request_id": "pr-1003",
+    "user_id": "u3"
+  }'
+echo -e "\n"
+
+echo "=== Decline Review ==="
+curl -X POST "$BASE_URL/pullRequest/decline" \
+  -H "Content-Type: application/json" \
+  -d '{
+    "pull_request_id": "pr-1003",
+    "user_id": "u3",
+    "reason": "conflict of interest"
+  }'
+echo -e "\n"
+
 echo "=== Merge PR ==="
 curl -X POST "$BASE_URL/pullRequest/merge" \
   -H "Content-Type: application/json" \
@@ -93,3 +140,11 @@ echo -e "\n"
 echo "=== Get Statistics ==="
 curl -X GET "$BASE_URL/statistics"
 echo -e "\n"
+
+echo "=== Export PRs as CSV ==="
+curl -X GET "$BASE_URL/export/pullRequests?team_name=backend" -H "Accept: text/csv"
+echo -e "\n"
+
+echo "=== Export Assignment History as NDJSON ==="
+curl -X GET "$BASE_URL/export/history?format=ndjson"
+echo -e "\n"
@@ -0,0 +1,507 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/avito-test/pr-reviewer-service/internal/handler"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func runTeam(ctx context.Context, a *app, args []string) error {
+	return subcommand("team", args, map[string]func([]string) error{
+		"create": func(args []string) error {
+			fs := newFlagSet(a, "team create")
+			var members stringList
+			fs.Var(&members, "member", "member as id:name or id:name:inactive, repeatable")
+			positional, err := parseArgs(fs, args, "team")
+			if err != nil {
+				return err
+			}
+
+			team := domain.Team{TeamName: positional[0], Members: []domain.TeamMember{}}
+			for _, value := range members {
+				member, err := parseMember(value)
+				if err != nil {
+					return err
+				}
+				team.Members = append(team.Members, member)
+			}
+
+			created, err := a.client.CreateTeam(ctx, team)
+			if err != nil {
+				return err
+			}
+			return a.printer.team(created)
+		},
+		"get": func(args []string) error {
+			positional, err := parseArgs(newFlagSet(a, "team get"), args, "team")
+			if err != nil {
+				return err
+			}
+
+			team, err := a.client.GetTeam(ctx, positional[0])
+			if err != nil {
+				return err
+			}
+			return a.printer.team(team)
+		},
+		"add-member": func(args []string) error {
+			fs := newFlagSet(a, "team add-member")
+			username := fs.String("username", "", "display name of the user")
+			inactive := fs.Bool("inactive", false, "add the user as inactive")
+			positional, err := parseArgs(fs, args, "team", "user_id")
+			if err != nil {
+				return err
+			}
+			if *username == "" {
+				return &usageError{msg: "--username is required"}
+			}
+
+			member := domain.TeamMember{UserID: positional[1], Username: *username, IsActive: !*inactive}
+			team, err := a.client.AddMember(ctx, positional[0], member)
+			if err != nil {
+				return err
+			}
+			return a.printer.team(team)
+		},
+		"import": func(args []string) error {
+			fs := newFlagSet(a, "team import")
+			dryRun := fs.Bool("dry-run", false, "only show what would change")
+			format := fs.String("format", "", "csv or json, by default from the file extension")
+			positional, err := parseArgs(fs, args, "file|-")
+			if err != nil {
+				return err
+			}
+
+			path := positional[0]
+			if *format == "" {
+				*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+			}
+			contentType := map[string]string{"csv": "text/csv", "json": "application/json"}[*format]
+			if contentType == "" {
+				return &usageError{msg: "cannot tell the file format, pass --format csv or --format json"}
+			}
+
+			export := io.Reader(os.Stdin)
+			if path != "-" {
+				file, err := os.Open(path)
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+				export = file
+			}
+
+			report, err := a.client.ImportTeams(ctx, export, contentType, *dryRun)
+			if err != nil {
+				return err
+			}
+			return a.printer.importReport(report)
+		},
+	})
+}
+
+// parseMember reads id:name or id:name:inactive.
+func parseMember(value string) (domain.TeamMember, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" || (len(parts) == 3 && parts[2] != "inactive") {
+		return domain.TeamMember{}, &usageError{msg: fmt.Sprintf("--member must be id:name or id:name:inactive, got %q", value)}
+	}
+	return domain.TeamMember{UserID: parts[0], Username: parts[1], IsActive: len(parts) == 2}, nil
+}
+
+func runUser(ctx context.Context, a *app, args []string) error {
+	setIsActive := func(name string, isActive bool) func([]string) error {
+		return func(args []string) error {
+			positional, err := parseArgs(newFlagSet(a, "user "+name), args, "user_id")
+			if err != nil {
+				return err
+			}
+
+			user, err := a.client.SetIsActive(ctx, positional[0], isActive)
+			if err != nil {
+				return err
+			}
+			return a.printer.user(user)
+		}
+	}
+
+	return subcommand("user", args, map[string]func([]string) error{
+		"activate":   setIsActive("activate", true),
+		"deactivate": setIsActive("deactivate", false),
+		"set-skills": func(args []string) error {
+			fs := newFlagSet(a, "user set-skills")
+			var skills stringList
+			fs.Var(&skills, "skill", "skill tag, repeatable; none clears the skills")
+			positional, err := parseArgs(fs, args, "user_id")
+			if err != nil {
+				return err
+			}
+
+			user, err := a.client.SetSkills(ctx, positional[0], skills)
+			if err != nil {
+				return err
+			}
+			return a.printer.user(user)
+		},
+	})
+}
+
+func runPR(ctx context.Context, a *app, args []string) error {
+	return subcommand("pr", args, map[string]func([]string) error{
+		"create": func(args []string) error {
+			fs := newFlagSet(a, "pr create")
+			name := fs.String("name", "", "pull request title")
+			author := fs.String("author", "", "author user ID")
+			var reviewers stringList
+			fs.Var(&reviewers, "reviewer", "requested reviewer, repeatable")
+			var tags stringList
+			fs.Var(&tags, "tag", "required tag to find a matching reviewer for, repeatable")
+			positional, err := parseArgs(fs, args, "pr_id")
+			if err != nil {
+				return err
+			}
+			if *name == "" || *author == "" {
+				return &usageError{msg: "--name and --author are required"}
+			}
+
+			pr, err := a.client.CreatePR(ctx, handler.CreatePRRequest{
+				PullRequestID:      positional[0],
+				PullRequestName:    *name,
+				AuthorID:           *author,
+				RequestedReviewers: reviewers,
+				RequiredTags:       tags,
+			})
+			if err != nil {
+				return err
+			}
+			return a.printer.pullRequest(pr)
+		},
+		"merge": func(args []string) error {
+			positional, err := parseArgs(newFlagSet(a, "pr merge"), args, "pr_id")
+			if err != nil {
+				return err
+			}
+
+			pr, err := a.client.MergePR(ctx, positional[0])
+			if err != nil {
+				return err
+			}
+			return a.printer.pullRequest(pr)
+		},
+		"reassign": func(args []string) error {
+			fs := newFlagSet(a, "pr reassign")
+			oldUser := fs.String("old", "", "reviewer to replace")
+			newUser := fs.String("new", "", "replacement, picked automatically if empty")
+			reason := fs.String("reason", "", "reason recorded in the history")
+			var exclude stringList
+			fs.Var(&exclude, "exclude", "user to skip when picking automatically, repeatable")
+			positional, err := parseArgs(fs, args, "pr_id")
+			if err != nil {
+				return err
+			}
+			if *oldUser == "" {
+				return &usageError{msg: "--old is required"}
+			}
+
+			pr, replacedBy, err := a.client.Reassign(ctx, handler.ReassignRequest{
+				PullRequestID:  positional[0],
+				OldUserID:      *oldUser,
+				NewUserID:      *newUser,
+				ExcludeUserIDs: exclude,
+				Reason:         *reason,
+			})
+			if err != nil {
+				return err
+			}
+			return a.printer.reassigned(pr, replacedBy)
+		},
+		"get": func(args []string) error {
+			positional, err := parseArgs(newFlagSet(a, "pr get"), args, "pr_id")
+			if err != nil {
+				return err
+			}
+
+			pr, err := a.client.GetPR(ctx, positional[0])
+			if err != nil {
+				return err
+			}
+			return a.printer.pullRequestDetails(pr)
+		},
+		"list": func(args []string) error {
+			fs := newFlagSet(a, "pr list")
+			filters := prFilterFlags(fs)
+			limit := fs.Int("limit", 0, "page size")
+			cursor := fs.String("cursor", "", "cursor from a previous page")
+			all := fs.Bool("all", false, "follow next_cursor until the last page")
+			if _, err := parseArgs(fs, args); err != nil {
+				return err
+			}
+
+			query, err := filters()
+			if err != nil {
+				return err
+			}
+			if *limit != 0 {
+				query.Set("limit", strconv.Itoa(*limit))
+			}
+			if *cursor != "" {
+				query.Set("cursor", *cursor)
+			}
+
+			page, err := a.client.ListPRs(ctx, query)
+			if err != nil {
+				return err
+			}
+			for *all && page.NextCursor != "" {
+				query.Set("cursor", page.NextCursor)
+				next, err := a.client.ListPRs(ctx, query)
+				if err != nil {
+					return err
+				}
+				page.PullRequests = append(page.PullRequests, next.PullRequests...)
+				page.NextCursor = next.NextCursor
+			}
+
+			if err := a.printer.pullRequestList(page); err != nil {
+				return err
+			}
+			if page.NextCursor != "" && a.printer.format == outputTable {
+				fmt.Fprintf(a.stderr, "more results: --cursor %s\n", page.NextCursor)
+			}
+			return nil
+		},
+	})
+}
+
+func runExclusion(ctx context.Context, a *app, args []string) error {
+	return subcommand("exclusion", args, map[string]func([]string) error{
+		"add": func(args []string) error {
+			fs := newFlagSet(a, "exclusion add")
+			kind := fs.String("kind", "pair", "pair: neither reviews the other; author: user_id never reviews other_user_id")
+			reason := fs.String("reason", "", "why the users are excluded")
+			positional, err := parseArgs(fs, args, "user_id", "other_user_id")
+			if err != nil {
+				return err
+			}
+
+			rule, err := a.client.AddExclusion(ctx, handler.AddExclusionRequest{
+				Kind:        domain.ExclusionKind(strings.ToUpper(*kind)),
+				UserID:      positional[0],
+				OtherUserID: positional[1],
+				Reason:      *reason,
+			})
+			if err != nil {
+				return err
+			}
+			return a.printer.exclusionRules([]domain.ExclusionRule{*rule})
+		},
+		"list": func(args []string) error {
+			fs := newFlagSet(a, "exclusion list")
+			userID := fs.String("user", "", "only rules involving this user")
+			if _, err := parseArgs(fs, args); err != nil {
+				return err
+			}
+
+			rules, err := a.client.ListExclusions(ctx, *userID)
+			if err != nil {
+				return err
+			}
+			return a.printer.exclusionRules(rules)
+		},
+		"delete": func(args []string) error {
+			positional, err := parseArgs(newFlagSet(a, "exclusion delete"), args, "rule_id")
+			if err != nil {
+				return err
+			}
+			ruleID, err := strconv.ParseInt(positional[0], 10, 64)
+			if err != nil || ruleID <= 0 {
+				return &usageError{msg: fmt.Sprintf("rule_id must be a positive integer, got %q", positional[0])}
+			}
+
+			if err := a.client.DeleteExclusion(ctx, ruleID); err != nil {
+				return err
+			}
+			fmt.Fprintf(a.printer.out, "deleted rule %d\n", ruleID)
+			return nil
+		},
+	})
+}
+
+func runSync(ctx context.Context, a *app, args []string) error {
+	return subcommand("sync", args, map[string]func([]string) error{
+		"runs": func(args []string) error {
+			if _, err := parseArgs(newFlagSet(a, "sync runs"), args); err != nil {
+				return err
+			}
+
+			runs, err := a.client.SyncRuns(ctx)
+			if err != nil {
+				return err
+			}
+			return a.printer.syncRuns(runs)
+		},
+		"run": func(args []string) error {
+			if _, err := parseArgs(newFlagSet(a, "sync run"), args); err != nil {
+				return err
+			}
+
+			run, err := a.client.RunSync(ctx)
+			if err != nil {
+				return err
+			}
+			if err := a.printer.syncRuns([]domain.SyncRun{*run}); err != nil {
+				return err
+			}
+			if run.Status == domain.SyncStatusBlocked || run.Status == domain.SyncStatusFailed {
+				return fmt.Errorf("sync %s: %s", run.Status, run.Error)
+			}
+			return nil
+		},
+	})
+}
+
+func runStats(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet(a, "stats")
+	filters := statisticsFilterFlags(fs)
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	query, err := filters()
+	if err != nil {
+		return err
+	}
+
+	stats, err := a.client.Statistics(ctx, query)
+	if err != nil {
+		return err
+	}
+	return a.printer.statistics(stats)
+}
+
+func runExport(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet(a, "export")
+	format := fs.String("format", "", "csv or ndjson, csv by default")
+	out := fs.String("out", "", "write to a file instead of stdout")
+	groupBy := fs.String("group-by", "", "statistics only: user or team")
+	prFilters := prFilterFlags(fs)
+	statisticsFilters := statisticsFilterFlags(fs)
+	positional, err := parseArgs(fs, args, "pullRequests|history|statistics")
+	if err != nil {
+		return err
+	}
+
+	kind := positional[0]
+	var query url.Values
+	switch kind {
+	case "pullRequests", "history":
+		query, err = prFilters()
+	case "statistics":
+		query, err = statisticsFilters()
+		if *groupBy != "" {
+			query.Set("group_by", *groupBy)
+		}
+	default:
+		return &usageError{msg: fmt.Sprintf("unknown export %q, expected pullRequests, history or statistics", kind)}
+	}
+	if err != nil {
+		return err
+	}
+	if *format != "" {
+		query.Set("format", *format)
+	}
+
+	if *out == "" {
+		return a.client.Export(ctx, kind, query, a.printer.out)
+	}
+
+	file, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	if err := a.client.Export(ctx, kind, query, file); err != nil {
+		file.Close()
+		os.Remove(*out)
+		return err
+	}
+	return file.Close()
+}
+
+// prFilterFlags registers the /pullRequest/list filters and returns a
+// function that builds the query once the flags are parsed.
+func prFilterFlags(fs *flag.FlagSet) func() (url.Values, error) {
+	status := fs.String("status", "", "OPEN or MERGED")
+	params := map[string]*string{
+		"author_id":    fs.String("author", "", "author user ID"),
+		"reviewer_id":  fs.String("reviewer", "", "assigned reviewer user ID"),
+		"team_name":    fs.String("team-name", "", "author's team"),
+		"name":         fs.String("name", "", "substring of the title"),
+		"created_from": fs.String("created-from", "", "created at or after"),
+		"created_to":   fs.String("created-to", "", "created before"),
+		"merged_from":  fs.String("merged-from", "", "merged at or after"),
+		"merged_to":    fs.String("merged-to", "", "merged before"),
+	}
+
+	return func() (url.Values, error) {
+		query := url.Values{}
+		if *status != "" {
+			query.Set("status", strings.ToUpper(*status))
+		}
+		for param, value := range params {
+			if strings.HasSuffix(param, "_from") || strings.HasSuffix(param, "_to") {
+				if err := checkTime(strings.ReplaceAll(param, "_", "-"), *value); err != nil {
+					return nil, err
+				}
+			}
+			if *value != "" {
+				query.Set(param, *value)
+			}
+		}
+		return query, nil
+	}
+}
+
+// statisticsFilterFlags registers the /statistics filters.
+func statisticsFilterFlags(fs *flag.FlagSet) func() (url.Values, error) {
+	team := fs.String("team", "", "only this team")
+	from := fs.String("from", "", "window start")
+	to := fs.String("to", "", "window end")
+
+	return func() (url.Values, error) {
+		query := url.Values{}
+		if *team != "" {
+			query.Set("team", *team)
+		}
+		if err := checkTime("from", *from); err != nil {
+			return nil, err
+		}
+		if *from != "" {
+			query.Set("from", *from)
+		}
+		if err := checkTime("to", *to); err != nil {
+			return nil, err
+		}
+		if *to != "" {
+			query.Set("to", *to)
+		}
+		return query, nil
+	}
+}
+
+func sortedKeys(handlers map[string]func([]string) error) []string {
+	keys := make([]string, 0, len(handlers))
+	for key := range handlers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"github.com/avito-test/pr-reviewer-service/internal/client"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+)
+
+// Exit codes. Every domain.ErrorCode has its own so that scripts can react
+// to, say, an existing team without parsing messages.
+const (
+	exitOK                 = 0
+	exitError              = 1 // connection failures, INTERNAL_ERROR and anything unexpected
+	exitUsage              = 2
+	exitNotFound           = 3
+	exitExists             = 4 // TEAM_EXISTS, PR_EXISTS and RULE_EXISTS
+	exitPRMerged           = 5
+	exitNotAssigned        = 6
+	exitNoCandidate        = 7
+	exitInvalidReviewer    = 8
+	exitTooManyReviewers   = 9
+	exitInvalidRequest     = 10
+	exitPreconditionFailed = 11
+)
+
+var exitCodes = map[domain.ErrorCode]int{
+	domain.ErrCodeNotFound:           exitNotFound,
+	domain.ErrCodeTeamExists:         exitExists,
+	domain.ErrCodePRExists:           exitExists,
+	domain.ErrCodeRuleExists:         exitExists,
+	domain.ErrCodePRMerged:           exitPRMerged,
+	domain.ErrCodeNotAssigned:        exitNotAssigned,
+	domain.ErrCodeNoCandidate:        exitNoCandidate,
+	domain.ErrCodeInvalidReviewer:    exitInvalidReviewer,
+	domain.ErrCodeTooManyReviewers:   exitTooManyReviewers,
+	domain.ErrCodeInvalidRequest:     exitInvalidRequest,
+	domain.ErrCodePreconditionFailed: exitPreconditionFailed,
+}
+
+// usageError is a mistake in the command line itself.
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+func exitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+
+	var usageErr *usageError
+	if errors.As(err, &usageErr) {
+		return exitUsage
+	}
+
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) {
+		if code, ok := exitCodes[apiErr.Code]; ok {
+			return code
+		}
+	}
+	return exitError
+}
+
+const exitCodesHelp = `Exit codes:
+  0   success
+  1   connection failure, internal server error, unexpected response,
+      or a sync run that was blocked or failed
+  2   invalid command line
+  3   NOT_FOUND
+  4   TEAM_EXISTS, PR_EXISTS, RULE_EXISTS
+  5   PR_MERGED
+  6   NOT_ASSIGNED
+  7   NO_CANDIDATE
+  8   INVALID_REVIEWER
+  9   TOO_MANY_REVIEWERS
+  10  INVALID_REQUEST
+  11  PRECONDITION_FAILED
+`
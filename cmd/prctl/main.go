@@ -0,0 +1,218 @@
+// Command prctl operates the reviewer service over its HTTP API.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/avito-test/pr-reviewer-service/internal/client"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const usage = `Usage: prctl [global flags] <command> [args]
+
+Commands:
+  team create <team> --member id:name[:inactive]...
+  team get <team>
+  team add-member <team> <user_id> --username <name> [--inactive]
+  team import <file|-> [--dry-run] [--format csv|json]
+  user activate <user_id>
+  user deactivate <user_id>
+  user set-skills <user_id> [--skill <tag>]...
+  pr create <pr_id> --name <name> --author <user_id> [--reviewer <user_id>]... [--tag <tag>]...
+  pr merge <pr_id>
+  pr reassign <pr_id> --old <user_id> [--new <user_id>] [--exclude <user_id>]... [--reason <text>]
+  pr get <pr_id>
+  pr list [filters] [--limit n] [--cursor c] [--all]
+  stats [--team <team>] [--from <time>] [--to <time>]
+  exclusion add <user_id> <other_user_id> [--kind pair|author] [--reason <text>]
+  exclusion list [--user <user_id>]
+  exclusion delete <rule_id>
+  sync runs
+  sync run
+  export <pullRequests|history|statistics> [--format csv|ndjson] [--out <file>] [filters]
+
+Global flags:
+  --profile <name>   profile from $PRCTL_CONFIG or ~/.config/prctl/config.yaml ($PRCTL_PROFILE)
+  --url <url>        server address, overrides the profile ($PRCTL_URL)
+  -o, --output       table or json
+  --timeout <dur>    request timeout
+
+Run "prctl <command> -h" for the flags of a command. Times are RFC 3339.
+
+` + exitCodesHelp
+
+type app struct {
+	client  *client.Client
+	printer *printer
+	stderr  io.Writer
+}
+
+type command func(ctx context.Context, a *app, args []string) error
+
+var commands = map[string]command{
+	"team":      runTeam,
+	"user":      runUser,
+	"pr":        runPR,
+	"stats":     runStats,
+	"exclusion": runExclusion,
+	"sync":      runSync,
+	"export":    runExport,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("prctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() { fmt.Fprint(stderr, usage) }
+
+	profileName := fs.String("profile", os.Getenv("PRCTL_PROFILE"), "")
+	baseURL := fs.String("url", "", "")
+	output := fs.String("output", "", "")
+	fs.StringVar(output, "o", "", "")
+	timeout := fs.Duration("timeout", 0, "")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	args = fs.Args()
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+	if args[0] == "help" {
+		fmt.Fprint(stdout, usage)
+		return exitOK
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "prctl: unknown command %q\n\n%s", args[0], usage)
+		return exitUsage
+	}
+
+	profile, err := loadProfile(*profileName)
+	if err != nil {
+		fmt.Fprintln(stderr, "prctl:", err)
+		return exitUsage
+	}
+	if env := os.Getenv("PRCTL_URL"); env != "" {
+		profile.URL = env
+	}
+	if *baseURL != "" {
+		profile.URL = *baseURL
+	}
+	if *output != "" {
+		profile.Output = *output
+	}
+	if *timeout != 0 {
+		profile.Timeout = *timeout
+	}
+	if profile.Output != outputTable && profile.Output != outputJSON {
+		fmt.Fprintf(stderr, "prctl: output must be %s or %s, got %q\n", outputTable, outputJSON, profile.Output)
+		return exitUsage
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	a := &app{
+		client:  client.New(profile.URL, profile.Timeout),
+		printer: &printer{out: stdout, format: profile.Output},
+		stderr:  stderr,
+	}
+
+	err = cmd(ctx, a, args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "prctl:", err)
+	}
+	return exitCode(err)
+}
+
+// parseArgs parses flags that may appear before, between or after the
+// positional arguments, and checks that exactly want positionals were given.
+func parseArgs(fs *flag.FlagSet, args []string, want ...string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, &usageError{msg: err.Error()}
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+
+	if len(positional) != len(want) {
+		if len(want) == 0 {
+			return nil, &usageError{msg: fmt.Sprintf("%s takes no arguments", fs.Name())}
+		}
+		return nil, &usageError{msg: fmt.Sprintf("usage: %s <%s>", fs.Name(), strings.Join(want, "> <"))}
+	}
+	return positional, nil
+}
+
+// newFlagSet returns a flag set that reports errors through parseArgs
+// instead of exiting.
+func newFlagSet(a *app, name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	return fs
+}
+
+// subcommand picks the handler for "<group> <subcommand>" style commands.
+func subcommand(group string, args []string, handlers map[string]func([]string) error) error {
+	if len(args) == 0 {
+		return &usageError{msg: fmt.Sprintf("usage: prctl %s <%s>", group, strings.Join(sortedKeys(handlers), "|"))}
+	}
+	handler, ok := handlers[args[0]]
+	if !ok {
+		return &usageError{msg: fmt.Sprintf("unknown %s command %q, expected one of %s", group, args[0], strings.Join(sortedKeys(handlers), ", "))}
+	}
+	return handler(args[1:])
+}
+
+// stringList is a flag that may be repeated.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// checkTime rejects malformed timestamps before they reach the server, so
+// that the message names the flag rather than the query parameter.
+func checkTime(flagName, value string) error {
+	if value == "" {
+		return nil
+	}
+	if _, err := time.Parse(time.RFC3339, value); err != nil {
+		return &usageError{msg: fmt.Sprintf("--%s must be an RFC 3339 timestamp, got %q", flagName, value)}
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/avito-test/pr-reviewer-service/internal/service"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// printer writes results either as indented JSON, exactly as the API
+// returned them, or as an aligned table.
+type printer struct {
+	out    io.Writer
+	format string
+}
+
+// print writes v as JSON, or calls table with a tabwriter.
+func (p *printer) print(v interface{}, table func(w io.Writer)) error {
+	if p.format == outputJSON {
+		encoder := json.NewEncoder(p.out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	}
+
+	tw := tabwriter.NewWriter(p.out, 0, 0, 2, ' ', 0)
+	table(tw)
+	return tw.Flush()
+}
+
+func (p *printer) team(team *domain.Team) error {
+	return p.print(team, func(w io.Writer) {
+		fmt.Fprintln(w, "TEAM\tUSER_ID\tUSERNAME\tACTIVE")
+		for _, m := range team.Members {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%t\n", team.TeamName, m.UserID, m.Username, m.IsActive)
+		}
+	})
+}
+
+func (p *printer) importReport(report *domain.ImportReport) error {
+	return p.print(report, func(w io.Writer) {
+		fmt.Fprintln(w, "CHANGE\tTEAM\tUSER_ID\tDETAILS")
+		for _, team := range report.TeamsCreated {
+			fmt.Fprintf(w, "create team\t%s\t\t\n", team)
+		}
+		for _, user := range report.UsersCreated {
+			fmt.Fprintf(w, "create user\t%s\t%s\t%s, active=%t\n", user.TeamName, user.UserID, user.Username, user.IsActive)
+		}
+		for _, move := range report.UsersMoved {
+			fmt.Fprintf(w, "move user\t%s\t%s\tfrom %s\n", move.ToTeam, move.UserID, move.FromTeam)
+		}
+		for _, user := range report.UsersUpdated {
+			fmt.Fprintf(w, "update user\t%s\t%s\t%s, active=%t\n", user.TeamName, user.UserID, user.Username, user.IsActive)
+		}
+		for _, userID := range report.UsersDeactivated {
+			fmt.Fprintf(w, "deactivate\t\t%s\t\n", userID)
+		}
+
+		verb := "applied"
+		if report.DryRun {
+			verb = "dry run, nothing written"
+		}
+		fmt.Fprintf(w, "\n%d unchanged (%s)\n", report.Unchanged, verb)
+	})
+}
+
+func (p *printer) exclusionRules(rules []domain.ExclusionRule) error {
+	return p.print(map[string]interface{}{"rules": rules}, func(w io.Writer) {
+		fmt.Fprintln(w, "RULE_ID\tKIND\tUSER_ID\tOTHER_USER_ID\tREASON\tCREATED")
+		for _, rule := range rules {
+			createdAt := rule.CreatedAt
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", rule.RuleID, rule.Kind, rule.UserID, rule.OtherUserID, rule.Reason, formatTime(&createdAt))
+		}
+	})
+}
+
+func (p *printer) syncRuns(runs []domain.SyncRun) error {
+	return p.print(map[string]interface{}{"runs": runs}, func(w io.Writer) {
+		fmt.Fprintln(w, "ID\tSTARTED\tTRIGGER\tSTATUS\tCHANGES\tERROR")
+		for _, run := range runs {
+			startedAt := run.StartedAt
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", run.ID, formatTime(&startedAt), run.Trigger, run.Status, syncChanges(run.Report), run.Error)
+		}
+	})
+}
+
+func syncChanges(report *domain.ImportReport) string {
+	if report == nil {
+		return "-"
+	}
+	return fmt.Sprintf("+%d teams, +%d users, %d moved, %d updated, %d deactivated",
+		len(report.TeamsCreated), len(report.UsersCreated), len(report.UsersMoved), len(report.UsersUpdated), len(report.UsersDeactivated))
+}
+
+func (p *printer) user(user *domain.User) error {
+	return p.print(user, func(w io.Writer) {
+		fmt.Fprintln(w, "USER_ID\tUSERNAME\tTEAM\tACTIVE\tSKILLS")
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\n", user.UserID, user.Username, user.TeamName, user.IsActive, strings.Join(user.Skills, ","))
+	})
+}
+
+func (p *printer) pullRequest(pr *domain.PullRequest) error {
+	return p.print(pr, func(w io.Writer) {
+		fmt.Fprintln(w, "PULL_REQUEST_ID\tNAME\tAUTHOR\tSTATUS\tREVIEWERS")
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status, reviewerList(pr))
+	})
+}
+
+// reviewerList joins the reviewers, adding the required tags each was picked
+// for, as in u2[db,infra].
+func reviewerList(pr *domain.PullRequest) string {
+	reviewers := make([]string, len(pr.AssignedReviewers))
+	for i, userID := range pr.AssignedReviewers {
+		reviewers[i] = userID
+		if tags := pr.ReviewerTags[userID]; len(tags) > 0 {
+			reviewers[i] += "[" + strings.Join(tags, ",") + "]"
+		}
+	}
+	return strings.Join(reviewers, ",")
+}
+
+func (p *printer) pullRequestDetails(pr *domain.PullRequestDetails) error {
+	return p.print(pr, func(w io.Writer) {
+		fmt.Fprintf(w, "ID:\t%s\n", pr.PullRequestID)
+		fmt.Fprintf(w, "Name:\t%s\n", pr.PullRequestName)
+		fmt.Fprintf(w, "Author:\t%s (%s)\n", pr.AuthorID, pr.AuthorTeam)
+		fmt.Fprintf(w, "Status:\t%s\n", pr.Status)
+		if len(pr.RequiredTags) > 0 {
+			fmt.Fprintf(w, "Tags:\t%s\n", strings.Join(pr.RequiredTags, ","))
+		}
+		fmt.Fprintf(w, "Created:\t%s\n", formatTime(pr.CreatedAt))
+		fmt.Fprintf(w, "Merged:\t%s\n", formatTime(pr.MergedAt))
+		for i, reviewer := range pr.Reviewers {
+			label := ""
+			if i == 0 {
+				label = "Reviewers:"
+			}
+			assignedAt := reviewer.AssignedAt
+			reviewerID := reviewer.UserID
+			if tags := pr.ReviewerTags[reviewer.UserID]; len(tags) > 0 {
+				reviewerID += " [" + strings.Join(tags, ",") + "]"
+			}
+			fmt.Fprintf(w, "%s\t%s (since %s)\n", label, reviewerID, formatTime(&assignedAt))
+		}
+	})
+}
+
+func (p *printer) reassigned(pr *domain.PullRequest, replacedBy string) error {
+	resp := map[string]interface{}{"pr": pr, "replaced_by": replacedBy}
+	return p.print(resp, func(w io.Writer) {
+		fmt.Fprintln(w, "PULL_REQUEST_ID\tREPLACED_BY\tREVIEWERS")
+		fmt.Fprintf(w, "%s\t%s\t%s\n", pr.PullRequestID, replacedBy, strings.Join(pr.AssignedReviewers, ","))
+	})
+}
+
+func (p *printer) pullRequestList(page *domain.PRPage) error {
+	return p.print(page, func(w io.Writer) {
+		fmt.Fprintln(w, "PULL_REQUEST_ID\tNAME\tAUTHOR\tSTATUS")
+		for _, pr := range page.PullRequests {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status)
+		}
+	})
+}
+
+func (p *printer) statistics(stats *service.Statistics) error {
+	return p.print(stats, func(w io.Writer) {
+		fmt.Fprintln(w, "USER_ID\tTEAM\tOPEN\tMERGED\tASSIGNMENTS\tREASSIGNED_AWAY\tDECLINES\tAVG_TIME_TO_MERGE")
+		for _, u := range stats.Users {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", u.UserID, u.TeamName, statisticsColumns(u.ReviewStatistics))
+		}
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "TEAM\t\tOPEN\tMERGED\tASSIGNMENTS\tREASSIGNED_AWAY\tDECLINES\tAVG_TIME_TO_MERGE")
+		for _, t := range stats.Teams {
+			fmt.Fprintf(w, "%s\t\t%s\n", t.TeamName, statisticsColumns(t.ReviewStatistics))
+		}
+	})
+}
+
+func statisticsColumns(s domain.ReviewStatistics) string {
+	avg := "-"
+	if s.AvgTimeToMergeSeconds != nil {
+		avg = time.Duration(*s.AvgTimeToMergeSeconds * float64(time.Second)).Round(time.Second).String()
+	}
+	return strings.Join([]string{
+		strconv.Itoa(s.OpenPRs),
+		strconv.Itoa(s.MergedPRs),
+		strconv.Itoa(s.Assignments),
+		strconv.Itoa(s.ReassignmentsAway),
+		strconv.Itoa(s.Declines),
+		avg,
+	}, "\t")
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Profile is one named server in the profile file.
+type Profile struct {
+	URL     string        `yaml:"url"`
+	Output  string        `yaml:"output"`
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// profileFile is ~/.config/prctl/config.yaml, or $PRCTL_CONFIG:
+//
+//	current: local
+//	profiles:
+//	  local:
+//	    url: http://localhost:8080
+//	    output: table
+//	  staging:
+//	    url: https://reviewer.staging.example.com
+//	    output: json
+//	    timeout: 30s
+type profileFile struct {
+	Current  string             `yaml:"current"`
+	Profiles map[string]Profile `yaml:"profiles"`
+}
+
+func defaultProfile() Profile {
+	return Profile{URL: "http://localhost:8080", Output: outputTable, Timeout: 10 * time.Second}
+}
+
+func profilePath() (string, error) {
+	if path := os.Getenv("PRCTL_CONFIG"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "prctl", "config.yaml"), nil
+}
+
+// loadProfile returns the named profile (or the file's current one) with
+// unset fields filled from the defaults. A missing file is not an error
+// unless a profile was asked for by name.
+func loadProfile(name string) (Profile, error) {
+	profile := defaultProfile()
+
+	path, err := profilePath()
+	if err != nil {
+		return profile, err
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		if name != "" {
+			return profile, fmt.Errorf("profile %q requested but %s does not exist", name, path)
+		}
+		return profile, nil
+	}
+	if err != nil {
+		return profile, err
+	}
+
+	var file profileFile
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return profile, fmt.Errorf("invalid profile file %s: %w", path, err)
+	}
+
+	if name == "" {
+		name = file.Current
+	}
+	if name == "" {
+		return profile, nil
+	}
+
+	selected, ok := file.Profiles[name]
+	if !ok {
+		return profile, fmt.Errorf("profile %q is not defined in %s", name, path)
+	}
+	if selected.URL != "" {
+		profile.URL = selected.URL
+	}
+	if selected.Output != "" {
+		profile.Output = selected.Output
+	}
+	if selected.Timeout != 0 {
+		profile.Timeout = selected.Timeout
+	}
+	return profile, nil
+}
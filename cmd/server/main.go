@@ -2,10 +2,25 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
+	"github.com/avito-test/pr-reviewer-service/internal/config"
+	"github.com/avito-test/pr-reviewer-service/internal/grpchandler"
 	"github.com/avito-test/pr-reviewer-service/internal/handler"
+	"github.com/avito-test/pr-reviewer-service/internal/health"
+	"github.com/avito-test/pr-reviewer-service/internal/logging"
+	"github.com/avito-test/pr-reviewer-service/internal/metrics"
+	"github.com/avito-test/pr-reviewer-service/internal/orgsync"
+	"github.com/avito-test/pr-reviewer-service/internal/policy"
+	"github.com/avito-test/pr-reviewer-service/internal/repository"
+	"github.com/avito-test/pr-reviewer-service/internal/repository/memory"
 	"github.com/avito-test/pr-reviewer-service/internal/repository/postgres"
+	"github.com/avito-test/pr-reviewer-service/internal/repository/sqlite"
 	"github.com/avito-test/pr-reviewer-service/internal/service"
-	"log"
+	"github.com/avito-test/pr-reviewer-service/internal/tracing"
+	"google.golang.org/grpc"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -14,71 +29,268 @@ import (
 )
 
 func main() {
-	cfg := postgres.Config{
-		Host:     getEnv("DB_HOST", "localhost"),
-		Port:     getEnv("DB_PORT", "5432"),
-		User:     getEnv("DB_USER", "postgres"),
-		Password: getEnv("DB_PASSWORD", "postgres"),
-		DBName:   getEnv("DB_NAME", "pr_reviewer"),
-		SSLMode:  getEnv("DB_SSLMODE", "disable"),
+	configPath := flag.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML or TOML config file")
+	flag.Parse()
+
+	cfg, err := config.Load(*configPath, os.LookupEnv)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	logger := logging.New(os.Stdout, cfg.Log.Level)
+	slog.SetDefault(logger)
+
+	if args := flag.Args(); len(args) > 0 {
+		switch args[0] {
+		case "migrate":
+			if err := runMigrate(cfg, args[1:], os.Stdout); err != nil {
+				fatal("Migration failed", "error", err)
+			}
+		case "config":
+			if len(args) != 2 || args[1] != "print" {
+				fatal("usage: config print")
+			}
+			if err := cfg.Print(os.Stdout); err != nil {
+				fatal("Failed to print config", "error", err)
+			}
+		default:
+			fatal("Unknown command, expected migrate or config", "command", args[0])
+		}
+		return
 	}
 
-	db, err := postgres.NewPostgresDB(cfg)
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing.Exporter)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		fatal("Failed to set up tracing", "error", err)
 	}
-	defer db.Close()
 
-	log.Println("Successfully connected to database")
+	m := metrics.New()
+
+	var repo *repository.Repository
+	var checks map[string]health.Check
+	switch cfg.Storage {
+	case "postgres":
+		db, err := postgres.NewPostgresDB(postgresConfig(cfg.Database))
+		if err != nil {
+			fatal("Failed to connect to database", "error", err)
+		}
+		defer db.Close()
+
+		slog.Info("Successfully connected to database")
+
+		migrator, err := postgres.NewMigrator(db, cfg.Database.MigrationsPath)
+		if err != nil {
+			fatal("Failed to load migrations", "error", err)
+		}
+		if err := migrator.Up(context.Background()); err != nil {
+			fatal("Failed to run migrations", "error", err)
+		}
 
-	migrationsPath := getEnv("MIGRATIONS_PATH", "./migrations")
-	if err := postgres.RunMigrations(db, migrationsPath); err != nil {
-		log.Fatalf("Failed to run migrations: %v", err)
+		slog.Info("Migrations applied successfully", "version", migrator.Latest())
+
+		m.RegisterDBStats(db)
+		repo = postgres.NewRepository(db)
+		checks = map[string]health.Check{
+			"database": db.PingContext,
+			"migrations": func(ctx context.Context) error {
+				version, err := postgres.SchemaVersion(ctx, db)
+				if err != nil {
+					return err
+				}
+				if version != migrator.Latest() {
+					return fmt.Errorf("schema is at version %d, expected %d", version, migrator.Latest())
+				}
+				return nil
+			},
+		}
+	case "sqlite":
+		db, err := sqlite.NewSQLiteDB(cfg.SQLite.Path)
+		if err != nil {
+			fatal("Failed to open SQLite database", "error", err)
+		}
+		defer db.Close()
+
+		if err := sqlite.RunMigrations(context.Background(), db); err != nil {
+			fatal("Failed to run migrations", "error", err)
+		}
+
+		slog.Info("Migrations applied successfully", "version", sqlite.LatestMigrationVersion())
+
+		m.RegisterDBStats(db)
+		repo = sqlite.NewRepository(db)
+		checks = map[string]health.Check{
+			"database": db.PingContext,
+			"migrations": func(ctx context.Context) error {
+				version, err := sqlite.SchemaVersion(ctx, db)
+				if err != nil {
+					return err
+				}
+				if version != sqlite.LatestMigrationVersion() {
+					return fmt.Errorf("schema is at version %d, expected %d", version, sqlite.LatestMigrationVersion())
+				}
+				return nil
+			},
+		}
+	case "memory":
+		slog.Warn("Using in-memory storage, data is lost on restart")
+		repo = memory.NewRepository()
 	}
 
-	log.Println("Migrations applied successfully")
+	policies, err := policy.NewStore(cfg.Policies.Path, policy.Policy{ReviewerCount: cfg.Reviewers.Count})
+	if err != nil {
+		fatal("Failed to load assignment policies", "error", err)
+	}
 
-	repo := postgres.NewRepository(db)
-	svc := service.NewService(repo)
+	svc := service.NewServiceWithOptions(repo, service.Options{
+		Observer: m,
+		Policies: policies,
+	})
 	handlers := handler.NewHandler(svc)
+	if !cfg.Features.Export {
+		handlers.Export = nil
+	}
+	for name, check := range checks {
+		handlers.Health.Add(name, check)
+	}
+
+	watchCtx, stopWatching := context.WithCancel(context.Background())
+	defer stopWatching()
+	if cfg.Policies.Path != "" {
+		reload := make(chan os.Signal, 1)
+		signal.Notify(reload, syscall.SIGHUP)
+		// A watcher that misses three polls is reported unready. Without
+		// polling it only wakes up on SIGHUP, and the silence check is off.
+		watcher := handlers.Health.AddWorker("policy_watcher", 3*cfg.Policies.ReloadInterval)
+		go func() {
+			defer watcher.Stopped()
+			policies.Watch(watchCtx, cfg.Policies.ReloadInterval, reload, watcher.Beat)
+		}()
+
+		slog.Info("Loaded assignment policies", "path", cfg.Policies.Path, "teams", len(policies.Current().Teams))
+	}
+
+	if cfg.Sync.Source != "" {
+		source, err := orgsync.NewSource(cfg.Sync.Source, cfg.Sync.Token, cfg.Sync.Timeout)
+		if err != nil {
+			fatal("Invalid directory sync source", "error", err)
+		}
+		syncer := orgsync.New(source, svc.Team, repo.SyncRun, orgsync.Options{
+			MaxDeactivatePercent: cfg.Sync.MaxDeactivatePercent,
+			DryRun:               cfg.Sync.DryRun,
+		})
+		handlers.Sync = handler.NewSyncHandler(syncer)
+		// A run may take up to the fetch timeout on top of the interval.
+		scheduler := handlers.Health.AddWorker("directory_sync", 2*cfg.Sync.Interval+cfg.Sync.Timeout)
+		go func() {
+			defer scheduler.Stopped()
+			syncer.Schedule(watchCtx, cfg.Sync.Interval, scheduler.Beat)
+		}()
 
-	router := handlers.InitRoutes()
+		slog.Info("Scheduled directory sync", "source", source.String(), "interval", cfg.Sync.Interval.String(), "dry_run", cfg.Sync.DryRun)
+	}
+
+	router := http.NewServeMux()
+	if cfg.Features.Metrics {
+		router.Handle("/metrics", m.Handler())
+	}
+	router.Handle("/", m.Middleware(tracing.Middleware(handlers.InitRoutes())))
 
-	port := getEnv("PORT", "8080")
+	port := cfg.HTTP.Port
 	server := &http.Server{
 		Addr:         ":" + port,
-		Handler:      router,
-		ReadTimeout:  10 * time.Second,
-		WriteTimeout: 10 * time.Second,
-		IdleTimeout:  60 * time.Second,
+		Handler:      logging.Middleware(logger, router),
+		ReadTimeout:  cfg.HTTP.ReadTimeout,
+		WriteTimeout: cfg.HTTP.WriteTimeout,
+		IdleTimeout:  cfg.HTTP.IdleTimeout,
 	}
 
 	go func() {
-		log.Printf("Starting server on port %s", port)
+		slog.Info("Starting server", "port", port)
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Failed to start server: %v", err)
+			fatal("Failed to start server", "error", err)
 		}
 	}()
 
+	var grpcServer *grpc.Server
+	if cfg.Features.GRPC {
+		grpcPort := cfg.GRPC.Port
+		grpcServer = grpchandler.NewServer(svc, grpc.ChainUnaryInterceptor(
+			tracing.UnaryServerInterceptor(),
+			logging.UnaryServerInterceptor(logger),
+		))
+
+		listener, err := net.Listen("tcp", ":"+grpcPort)
+		if err != nil {
+			fatal("Failed to listen on gRPC port", "port", grpcPort, "error", err)
+		}
+
+		grpcWorker := handlers.Health.AddWorker("grpc_server", 0)
+		go func() {
+			defer grpcWorker.Stopped()
+			slog.Info("Starting gRPC server", "port", grpcPort)
+			if err := grpcServer.Serve(listener); err != nil {
+				fatal("Failed to start gRPC server", "error", err)
+			}
+		}()
+	}
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	log.Println("Shutting down server...")
+	handlers.Health.SetDraining()
+	drainDelay := cfg.Shutdown.DrainDelay
+	slog.Info("Draining before shutdown", "delay", drainDelay.String())
+	time.Sleep(drainDelay)
+
+	slog.Info("Shutting down server")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Shutdown.Timeout)
 	defer cancel()
 
 	if err := server.Shutdown(ctx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+		fatal("Server forced to shutdown", "error", err)
 	}
 
-	log.Println("Server exited")
+	if grpcServer != nil {
+		grpcStopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(grpcStopped)
+		}()
+
+		select {
+		case <-grpcStopped:
+		case <-ctx.Done():
+			grpcServer.Stop()
+		}
+	}
+
+	if err := shutdownTracing(ctx); err != nil {
+		slog.Error("Failed to flush traces", "error", err)
+	}
+
+	slog.Info("Server exited")
 }
 
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+func postgresConfig(db config.DatabaseConfig) postgres.Config {
+	return postgres.Config{
+		DSN:             db.DSN,
+		Host:            db.Host,
+		Port:            db.Port,
+		User:            db.User,
+		Password:        db.Password,
+		DBName:          db.Name,
+		SSLMode:         db.SSLMode,
+		MaxOpenConns:    db.MaxOpenConns,
+		MaxIdleConns:    db.MaxIdleConns,
+		ConnMaxLifetime: db.ConnMaxLifetime,
+		ConnectTimeout:  db.ConnectTimeout,
 	}
-	return defaultValue
+}
+
+func fatal(msg string, args ...interface{}) {
+	slog.Error(msg, args...)
+	os.Exit(1)
 }
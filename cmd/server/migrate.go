@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/avito-test/pr-reviewer-service/internal/config"
+	"github.com/avito-test/pr-reviewer-service/internal/repository/postgres"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+const migrateUsage = "usage: migrate up | down [n] | status | to <version>"
+
+// runMigrate implements the migrate subcommand against the configured
+// database without starting the servers.
+func runMigrate(cfg *config.Config, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+	if cfg.Storage != "postgres" {
+		return fmt.Errorf("migrate only manages postgres storage, %s is migrated on startup", cfg.Storage)
+	}
+
+	db, err := postgres.NewPostgresDB(postgresConfig(cfg.Database))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator, err := postgres.NewMigrator(db, cfg.Database.MigrationsPath)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	switch {
+	case args[0] == "up" && len(args) == 1:
+		err = migrator.Up(ctx)
+	case args[0] == "down" && len(args) <= 2:
+		steps := 1
+		if len(args) == 2 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps <= 0 {
+				return fmt.Errorf("down expects a positive number of steps, got %q", args[1])
+			}
+		}
+		err = migrator.Down(ctx, steps)
+	case args[0] == "to" && len(args) == 2:
+		version, convErr := strconv.Atoi(args[1])
+		if convErr != nil || version < 0 {
+			return fmt.Errorf("to expects a migration version, got %q", args[1])
+		}
+		err = migrator.To(ctx, version)
+	case args[0] == "status" && len(args) == 1:
+		return printMigrationStatus(ctx, migrator, out)
+	default:
+		return errors.New(migrateUsage)
+	}
+	if err != nil {
+		return err
+	}
+
+	version, err := postgres.SchemaVersion(ctx, db)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "schema is at version %d\n", version)
+	return nil
+}
+
+func printMigrationStatus(ctx context.Context, migrator *postgres.Migrator, out io.Writer) error {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, status := range statuses {
+		state, appliedAt := "pending", ""
+		if status.Applied {
+			state, appliedAt = "applied", status.AppliedAt.UTC().Format(time.RFC3339)
+		}
+		if status.Modified {
+			state = "modified"
+		}
+		if status.Missing {
+			state = "missing"
+		}
+		fmt.Fprintf(tw, "%03d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+	}
+	return tw.Flush()
+}
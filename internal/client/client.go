@@ -0,0 +1,264 @@
+// Package client is a Go client for the service's HTTP API.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/avito-test/pr-reviewer-service/internal/handler"
+	"github.com/avito-test/pr-reviewer-service/internal/service"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// APIError is an error response from the server. Code is the server's
+// error code, or INTERNAL_ERROR for responses without one.
+type APIError struct {
+	StatusCode int
+	Code       domain.ErrorCode
+	Message    string
+	RequestID  string
+}
+
+func (e *APIError) Error() string {
+	if e.RequestID != "" {
+		return fmt.Sprintf("%s: %s (request %s)", e.Code, e.Message, e.RequestID)
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+type Client struct {
+	baseURL string
+	http    *http.Client
+}
+
+func New(baseURL string, timeout time.Duration) *Client {
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		http:    &http.Client{Timeout: timeout},
+	}
+}
+
+func (c *Client) CreateTeam(ctx context.Context, team domain.Team) (*domain.Team, error) {
+	var resp struct {
+		Team *domain.Team `json:"team"`
+	}
+	err := c.do(ctx, http.MethodPost, "/team/add", nil, team, &resp)
+	return resp.Team, err
+}
+
+func (c *Client) GetTeam(ctx context.Context, teamName string) (*domain.Team, error) {
+	var team domain.Team
+	err := c.do(ctx, http.MethodGet, "/team/get", url.Values{"team_name": {teamName}}, nil, &team)
+	return &team, err
+}
+
+func (c *Client) AddMember(ctx context.Context, teamName string, member domain.TeamMember) (*domain.Team, error) {
+	var resp struct {
+		Team *domain.Team `json:"team"`
+	}
+	body := handler.AddMemberRequest{TeamName: teamName, TeamMember: member}
+	err := c.do(ctx, http.MethodPost, "/team/addMember", nil, body, &resp)
+	return resp.Team, err
+}
+
+func (c *Client) SetIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error) {
+	var resp struct {
+		User *domain.User `json:"user"`
+	}
+	body := handler.SetIsActiveRequest{UserID: userID, IsActive: isActive}
+	err := c.do(ctx, http.MethodPost, "/users/setIsActive", nil, body, &resp)
+	return resp.User, err
+}
+
+func (c *Client) SetSkills(ctx context.Context, userID string, skills []string) (*domain.User, error) {
+	var resp struct {
+		User *domain.User `json:"user"`
+	}
+	body := handler.SetSkillsRequest{UserID: userID, Skills: skills}
+	err := c.do(ctx, http.MethodPost, "/users/setSkills", nil, body, &resp)
+	return resp.User, err
+}
+
+func (c *Client) CreatePR(ctx context.Context, req handler.CreatePRRequest) (*domain.PullRequest, error) {
+	var resp struct {
+		PR *domain.PullRequest `json:"pr"`
+	}
+	err := c.do(ctx, http.MethodPost, "/pullRequest/create", nil, req, &resp)
+	return resp.PR, err
+}
+
+func (c *Client) MergePR(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	var resp struct {
+		PR *domain.PullRequest `json:"pr"`
+	}
+	err := c.do(ctx, http.MethodPost, "/pullRequest/merge", nil, handler.MergePRRequest{PullRequestID: prID}, &resp)
+	return resp.PR, err
+}
+
+// Reassign returns the updated PR and the user who took over the review.
+func (c *Client) Reassign(ctx context.Context, req handler.ReassignRequest) (*domain.PullRequest, string, error) {
+	var resp struct {
+		PR         *domain.PullRequest `json:"pr"`
+		ReplacedBy string              `json:"replaced_by"`
+	}
+	err := c.do(ctx, http.MethodPost, "/pullRequest/reassign", nil, req, &resp)
+	return resp.PR, resp.ReplacedBy, err
+}
+
+func (c *Client) GetPR(ctx context.Context, prID string) (*domain.PullRequestDetails, error) {
+	var resp struct {
+		PR *domain.PullRequestDetails `json:"pr"`
+	}
+	err := c.do(ctx, http.MethodGet, "/pullRequest/get", url.Values{"pull_request_id": {prID}}, nil, &resp)
+	return resp.PR, err
+}
+
+// ListPRs returns one page; query takes the /pullRequest/list parameters.
+func (c *Client) ListPRs(ctx context.Context, query url.Values) (*domain.PRPage, error) {
+	var page domain.PRPage
+	err := c.do(ctx, http.MethodGet, "/pullRequest/list", query, nil, &page)
+	return &page, err
+}
+
+func (c *Client) Statistics(ctx context.Context, query url.Values) (*service.Statistics, error) {
+	var stats service.Statistics
+	err := c.do(ctx, http.MethodGet, "/statistics", query, nil, &stats)
+	return &stats, err
+}
+
+// Export streams /export/<kind> to w as the server writes it.
+func (c *Client) Export(ctx context.Context, kind string, query url.Values, w io.Writer) error {
+	resp, err := c.send(ctx, http.MethodGet, "/export/"+kind, query, nil, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+func (c *Client) AddExclusion(ctx context.Context, req handler.AddExclusionRequest) (*domain.ExclusionRule, error) {
+	var resp struct {
+		Rule *domain.ExclusionRule `json:"rule"`
+	}
+	err := c.do(ctx, http.MethodPost, "/exclusions/add", nil, req, &resp)
+	return resp.Rule, err
+}
+
+// ListExclusions lists the rules involving userID, or all rules when it is
+// empty.
+func (c *Client) ListExclusions(ctx context.Context, userID string) ([]domain.ExclusionRule, error) {
+	var query url.Values
+	if userID != "" {
+		query = url.Values{"user_id": {userID}}
+	}
+	var resp struct {
+		Rules []domain.ExclusionRule `json:"rules"`
+	}
+	err := c.do(ctx, http.MethodGet, "/exclusions/list", query, nil, &resp)
+	return resp.Rules, err
+}
+
+func (c *Client) DeleteExclusion(ctx context.Context, ruleID int64) error {
+	var resp struct {
+		RuleID int64 `json:"rule_id"`
+	}
+	body := handler.DeleteExclusionRequest{RuleID: ruleID}
+	return c.do(ctx, http.MethodPost, "/exclusions/delete", nil, body, &resp)
+}
+
+func (c *Client) SyncRuns(ctx context.Context) ([]domain.SyncRun, error) {
+	var resp struct {
+		Runs []domain.SyncRun `json:"runs"`
+	}
+	err := c.do(ctx, http.MethodGet, "/sync/runs", nil, nil, &resp)
+	return resp.Runs, err
+}
+
+// RunSync starts a directory sync and waits for its outcome.
+func (c *Client) RunSync(ctx context.Context) (*domain.SyncRun, error) {
+	var run domain.SyncRun
+	err := c.do(ctx, http.MethodPost, "/sync/run", nil, nil, &run)
+	return &run, err
+}
+
+// ImportTeams sends an org export, JSON or CSV as given by contentType, to
+// /team/import.
+func (c *Client) ImportTeams(ctx context.Context, export io.Reader, contentType string, dryRun bool) (*domain.ImportReport, error) {
+	query := url.Values{"dry_run": {strconv.FormatBool(dryRun)}}
+	resp, err := c.send(ctx, http.MethodPost, "/team/import", query, export, contentType)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var report domain.ImportReport
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return nil, fmt.Errorf("failed to decode response from /team/import: %w", err)
+	}
+	return &report, nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	resp, err := c.send(ctx, method, path, query, reader, "application/json")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response from %s: %w", path, err)
+	}
+	return nil
+}
+
+// send performs the request and turns non-2xx responses into *APIError.
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, body io.Reader, contentType string) (*http.Response, error) {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	var errResp handler.ErrorResponse
+	apiErr := &APIError{StatusCode: resp.StatusCode, Code: "INTERNAL_ERROR", Message: resp.Status}
+	if json.NewDecoder(resp.Body).Decode(&errResp) == nil && errResp.Error.Code != "" {
+		apiErr.Code = domain.ErrorCode(errResp.Error.Code)
+		apiErr.Message = errResp.Error.Message
+		apiErr.RequestID = errResp.Error.RequestID
+	}
+	return nil, apiErr
+}
@@ -0,0 +1,291 @@
+// Package config loads the server configuration from an optional YAML or
+// TOML file and environment overrides, and validates it before startup.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Config is the effective server configuration. Every field can be set in
+// the file under its yaml/toml key and overridden by the env variable named
+// in its env tag. Fields tagged secret are redacted when printed.
+type Config struct {
+	Storage   string          `yaml:"storage" toml:"storage" env:"STORAGE"`
+	Database  DatabaseConfig  `yaml:"database" toml:"database"`
+	SQLite    SQLiteConfig    `yaml:"sqlite" toml:"sqlite"`
+	HTTP      HTTPConfig      `yaml:"http" toml:"http"`
+	GRPC      GRPCConfig      `yaml:"grpc" toml:"grpc"`
+	Log       LogConfig       `yaml:"log" toml:"log"`
+	Tracing   TracingConfig   `yaml:"tracing" toml:"tracing"`
+	Reviewers ReviewersConfig `yaml:"reviewers" toml:"reviewers"`
+	Policies  PoliciesConfig  `yaml:"policies" toml:"policies"`
+	Sync      SyncConfig      `yaml:"sync" toml:"sync"`
+	Shutdown  ShutdownConfig  `yaml:"shutdown" toml:"shutdown"`
+	Features  FeaturesConfig  `yaml:"features" toml:"features"`
+}
+
+// DatabaseConfig configures Postgres. DSN, when set, replaces the individual
+// connection fields.
+type DatabaseConfig struct {
+	DSN             string        `yaml:"dsn" toml:"dsn" env:"DB_DSN" secret:"true"`
+	Host            string        `yaml:"host" toml:"host" env:"DB_HOST"`
+	Port            string        `yaml:"port" toml:"port" env:"DB_PORT"`
+	User            string        `yaml:"user" toml:"user" env:"DB_USER"`
+	Password        string        `yaml:"password" toml:"password" env:"DB_PASSWORD" secret:"true"`
+	Name            string        `yaml:"name" toml:"name" env:"DB_NAME"`
+	SSLMode         string        `yaml:"sslmode" toml:"sslmode" env:"DB_SSLMODE"`
+	MaxOpenConns    int           `yaml:"max_open_conns" toml:"max_open_conns" env:"DB_MAX_OPEN_CONNS"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" toml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" toml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME"`
+	ConnectTimeout  time.Duration `yaml:"connect_timeout" toml:"connect_timeout" env:"DB_CONNECT_TIMEOUT"`
+	MigrationsPath  string        `yaml:"migrations_path" toml:"migrations_path" env:"MIGRATIONS_PATH"`
+}
+
+type SQLiteConfig struct {
+	Path string `yaml:"path" toml:"path" env:"SQLITE_PATH"`
+}
+
+type HTTPConfig struct {
+	Port         string        `yaml:"port" toml:"port" env:"PORT"`
+	ReadTimeout  time.Duration `yaml:"read_timeout" toml:"read_timeout" env:"HTTP_READ_TIMEOUT"`
+	WriteTimeout time.Duration `yaml:"write_timeout" toml:"write_timeout" env:"HTTP_WRITE_TIMEOUT"`
+	IdleTimeout  time.Duration `yaml:"idle_timeout" toml:"idle_timeout" env:"HTTP_IDLE_TIMEOUT"`
+}
+
+type GRPCConfig struct {
+	Port string `yaml:"port" toml:"port" env:"GRPC_PORT"`
+}
+
+type LogConfig struct {
+	Level string `yaml:"level" toml:"level" env:"LOG_LEVEL"`
+}
+
+type TracingConfig struct {
+	Exporter string `yaml:"exporter" toml:"exporter" env:"OTEL_TRACES_EXPORTER"`
+}
+
+type ReviewersConfig struct {
+	// Count is how many reviewers are assigned to a new PR and the most a
+	// PR may have.
+	Count int `yaml:"count" toml:"count" env:"REVIEWER_COUNT"`
+}
+
+type PoliciesConfig struct {
+	// Path is the per-team assignment policy file; without it every team
+	// gets reviewers.count random reviewers.
+	Path string `yaml:"path" toml:"path" env:"POLICY_FILE"`
+	// ReloadInterval is how often the file is checked for changes; zero
+	// reloads only on SIGHUP.
+	ReloadInterval time.Duration `yaml:"reload_interval" toml:"reload_interval" env:"POLICY_RELOAD_INTERVAL"`
+}
+
+type SyncConfig struct {
+	// Source is the directory to reconcile teams with: an http(s) URL or a
+	// .csv or .json file in the /team/import format. Empty disables sync.
+	Source string `yaml:"source" toml:"source" env:"SYNC_SOURCE"`
+	// Token is sent as a bearer token to HTTP sources.
+	Token    string        `yaml:"token" toml:"token" env:"SYNC_TOKEN" secret:"true"`
+	Interval time.Duration `yaml:"interval" toml:"interval" env:"SYNC_INTERVAL"`
+	Timeout  time.Duration `yaml:"timeout" toml:"timeout" env:"SYNC_TIMEOUT"`
+	// MaxDeactivatePercent blocks runs that would deactivate more than this
+	// share of the synced teams' active members.
+	MaxDeactivatePercent int  `yaml:"max_deactivate_percent" toml:"max_deactivate_percent" env:"SYNC_MAX_DEACTIVATE_PERCENT"`
+	DryRun               bool `yaml:"dry_run" toml:"dry_run" env:"SYNC_DRY_RUN"`
+}
+
+type ShutdownConfig struct {
+	// DrainDelay is how long the server keeps serving after /readyz starts
+	// failing, so load balancers can stop routing to it.
+	DrainDelay time.Duration `yaml:"drain_delay" toml:"drain_delay" env:"SHUTDOWN_DRAIN_DELAY"`
+	Timeout    time.Duration `yaml:"timeout" toml:"timeout" env:"SHUTDOWN_TIMEOUT"`
+}
+
+type FeaturesConfig struct {
+	GRPC    bool `yaml:"grpc" toml:"grpc" env:"FEATURE_GRPC"`
+	Metrics bool `yaml:"metrics" toml:"metrics" env:"FEATURE_METRICS"`
+	Export  bool `yaml:"export" toml:"export" env:"FEATURE_EXPORT"`
+}
+
+// Default returns the configuration used when nothing is set; it matches the
+// behaviour of the server before the config file existed.
+func Default() *Config {
+	return &Config{
+		Storage: "postgres",
+		Database: DatabaseConfig{
+			Host:            "localhost",
+			Port:            "5432",
+			User:            "postgres",
+			Password:        "postgres",
+			Name:            "pr_reviewer",
+			SSLMode:         "disable",
+			MaxOpenConns:    25,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: 5 * time.Minute,
+			ConnectTimeout:  10 * time.Second,
+			MigrationsPath:  "./migrations",
+		},
+		SQLite: SQLiteConfig{Path: "./pr-reviewer.db"},
+		HTTP: HTTPConfig{
+			Port:         "8080",
+			ReadTimeout:  10 * time.Second,
+			WriteTimeout: 10 * time.Second,
+			IdleTimeout:  60 * time.Second,
+		},
+		GRPC:      GRPCConfig{Port: "9090"},
+		Log:       LogConfig{Level: "info"},
+		Tracing:   TracingConfig{Exporter: "none"},
+		Reviewers: ReviewersConfig{Count: 2},
+		Policies:  PoliciesConfig{ReloadInterval: 30 * time.Second},
+		Sync: SyncConfig{
+			Interval:             time.Hour,
+			Timeout:              30 * time.Second,
+			MaxDeactivatePercent: 20,
+		},
+		Shutdown: ShutdownConfig{
+			DrainDelay: 5 * time.Second,
+			Timeout:    10 * time.Second,
+		},
+		Features: FeaturesConfig{GRPC: true, Metrics: true, Export: true},
+	}
+}
+
+// Load builds the configuration from the defaults, the file at path (if
+// any) and then the environment, and validates the result.
+func Load(path string, lookupEnv func(string) (string, bool)) (*Config, error) {
+	cfg := Default()
+
+	if path != "" {
+		if err := cfg.decodeFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := applyEnv(cfg, lookupEnv); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// decodeFile reads YAML or TOML depending on the extension. Unknown keys are
+// rejected so that a typo does not silently fall back to a default.
+func (c *Config) decodeFile(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("invalid config file %s: %w", path, err)
+		}
+	case ".toml":
+		meta, err := toml.Decode(string(content), c)
+		if err != nil {
+			return fmt.Errorf("invalid config file %s: %w", path, err)
+		}
+		if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("invalid config file %s: unknown key %q", path, undecoded[0].String())
+		}
+	default:
+		return fmt.Errorf("config file %s must have a .yaml, .yml or .toml extension", path)
+	}
+	return nil
+}
+
+var (
+	storages  = []string{"postgres", "sqlite", "memory"}
+	logLevels = []string{"debug", "info", "warn", "error"}
+	exporters = []string{"none", "otlp", "stdout", "console"}
+)
+
+// Validate reports every invalid setting at once, naming each by its file
+// key.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(oneOf(c.Storage, storages), "storage must be one of %s, got %q", strings.Join(storages, ", "), c.Storage)
+
+	switch c.Storage {
+	case "postgres":
+		db := c.Database
+		check(db.DSN != "" || (db.Host != "" && db.Port != "" && db.Name != ""), "database.host, database.port and database.name are required unless database.dsn is set")
+		check(db.MaxOpenConns > 0, "database.max_open_conns must be positive, got %d", db.MaxOpenConns)
+		check(db.MaxIdleConns > 0 && db.MaxIdleConns <= db.MaxOpenConns, "database.max_idle_conns must be between 1 and database.max_open_conns (%d), got %d", db.MaxOpenConns, db.MaxIdleConns)
+		check(db.ConnMaxLifetime > 0, "database.conn_max_lifetime must be positive")
+		check(db.ConnectTimeout > 0, "database.connect_timeout must be positive")
+		check(db.MigrationsPath != "", "database.migrations_path is required")
+	case "sqlite":
+		check(c.SQLite.Path != "", "sqlite.path is required")
+	}
+
+	check(validPort(c.HTTP.Port), "http.port must be a port number, got %q", c.HTTP.Port)
+	check(c.HTTP.ReadTimeout > 0, "http.read_timeout must be positive")
+	check(c.HTTP.WriteTimeout > 0, "http.write_timeout must be positive")
+	check(c.HTTP.IdleTimeout > 0, "http.idle_timeout must be positive")
+	if c.Features.GRPC {
+		check(validPort(c.GRPC.Port), "grpc.port must be a port number, got %q", c.GRPC.Port)
+		check(c.GRPC.Port != c.HTTP.Port, "grpc.port and http.port must differ, both are %s", c.HTTP.Port)
+	}
+
+	check(oneOf(strings.ToLower(c.Log.Level), logLevels), "log.level must be one of %s, got %q", strings.Join(logLevels, ", "), c.Log.Level)
+	check(oneOf(c.Tracing.Exporter, exporters), "tracing.exporter must be one of %s, got %q", strings.Join(exporters, ", "), c.Tracing.Exporter)
+	check(c.Reviewers.Count >= 1 && c.Reviewers.Count <= 10, "reviewers.count must be between 1 and 10, got %d", c.Reviewers.Count)
+	check(c.Policies.ReloadInterval >= 0, "policies.reload_interval must not be negative")
+	if c.Sync.Source != "" {
+		check(c.Sync.Interval > 0, "sync.interval must be positive")
+		check(c.Sync.Timeout > 0, "sync.timeout must be positive")
+		check(c.Sync.MaxDeactivatePercent >= 0 && c.Sync.MaxDeactivatePercent <= 100, "sync.max_deactivate_percent must be between 0 and 100, got %d", c.Sync.MaxDeactivatePercent)
+	}
+	check(c.Shutdown.DrainDelay >= 0, "shutdown.drain_delay must not be negative")
+	check(c.Shutdown.Timeout > 0, "shutdown.timeout must be positive")
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid configuration:\n  %w", joinLines(errs))
+}
+
+func oneOf(value string, allowed []string) bool {
+	for _, a := range allowed {
+		if value == a {
+			return true
+		}
+	}
+	return false
+}
+
+func validPort(port string) bool {
+	var n int
+	_, err := fmt.Sscanf(port, "%d", &n)
+	return err == nil && fmt.Sprint(n) == port && n > 0 && n < 65536
+}
+
+// joinLines is errors.Join with one indented line per error.
+func joinLines(errs []error) error {
+	lines := make([]string, len(errs))
+	for i, err := range errs {
+		lines[i] = err.Error()
+	}
+	return errors.New(strings.Join(lines, "\n  "))
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// applyEnv overrides fields from the env variables named in their env tags.
+func applyEnv(cfg *Config, lookupEnv func(string) (string, bool)) error {
+	return walkFields(reflect.ValueOf(cfg).Elem(), func(field reflect.StructField, value reflect.Value) error {
+		name := field.Tag.Get("env")
+		if name == "" {
+			return nil
+		}
+		raw, ok := lookupEnv(name)
+		if !ok || raw == "" {
+			return nil
+		}
+		if err := setFromString(value, raw); err != nil {
+			return fmt.Errorf("invalid %s: %w", name, err)
+		}
+		return nil
+	})
+}
+
+// walkFields calls fn for every leaf field of a (nested) config struct.
+func walkFields(v reflect.Value, fn func(reflect.StructField, reflect.Value) error) error {
+	for i := 0; i < v.NumField(); i++ {
+		field, value := v.Type().Field(i), v.Field(i)
+		if value.Kind() == reflect.Struct {
+			if err := walkFields(value, fn); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := fn(field, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func setFromString(value reflect.Value, raw string) error {
+	switch {
+	case value.Type() == durationType:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not a duration such as 5s or 1m30s", raw)
+		}
+		value.SetInt(int64(d))
+	case value.Kind() == reflect.String:
+		value.SetString(raw)
+	case value.Kind() == reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", raw)
+		}
+		value.SetInt(int64(n))
+	case value.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", raw)
+		}
+		value.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported field type %s", value.Type())
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"gopkg.in/yaml.v3"
+	"io"
+	"reflect"
+)
+
+const redacted = "[REDACTED]"
+
+// Redacted returns a copy with every secret that is set replaced by a
+// placeholder, safe to print or log.
+func (c *Config) Redacted() *Config {
+	out := *c
+	walkFields(reflect.ValueOf(&out).Elem(), func(field reflect.StructField, value reflect.Value) error {
+		if field.Tag.Get("secret") == "true" && value.String() != "" {
+			value.SetString(redacted)
+		}
+		return nil
+	})
+	return &out
+}
+
+// Print writes the redacted configuration as YAML.
+func (c *Config) Print(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(c.Redacted()); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
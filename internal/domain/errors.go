@@ -7,10 +7,17 @@ type ErrorCode string
 const (
 	ErrCodeTeamExists  ErrorCode = "TEAM_EXISTS"
 	ErrCodePRExists    ErrorCode = "PR_EXISTS"
+	ErrCodeRuleExists  ErrorCode = "RULE_EXISTS"
 	ErrCodePRMerged    ErrorCode = "PR_MERGED"
 	ErrCodeNotAssigned ErrorCode = "NOT_ASSIGNED"
 	ErrCodeNoCandidate ErrorCode = "NO_CANDIDATE"
 	ErrCodeNotFound    ErrorCode = "NOT_FOUND"
+
+	ErrCodePreconditionFailed ErrorCode = "PRECONDITION_FAILED"
+
+	ErrCodeInvalidReviewer  ErrorCode = "INVALID_REVIEWER"
+	ErrCodeTooManyReviewers ErrorCode = "TOO_MANY_REVIEWERS"
+	ErrCodeInvalidRequest   ErrorCode = "INVALID_REQUEST"
 )
 
 type AppError struct {
@@ -39,6 +46,38 @@ var (
 	ErrUserNotFound   = NewAppError(ErrCodeNotFound, "user not found")
 	ErrPRNotFound     = NewAppError(ErrCodeNotFound, "PR not found")
 	ErrAuthorNotFound = NewAppError(ErrCodeNotFound, "author not found")
+	ErrRuleExists     = NewAppError(ErrCodeRuleExists, "exclusion rule already exists")
+	ErrRuleNotFound   = NewAppError(ErrCodeNotFound, "exclusion rule not found")
+
+	ErrPreconditionFailed = NewAppError(ErrCodePreconditionFailed, "resource has been modified")
+
+	ErrPRMergedReviewers = NewAppError(ErrCodePRMerged, "cannot change reviewers on merged PR")
+	ErrSelfReview        = NewAppError(ErrCodeInvalidReviewer, "author cannot review own PR")
+	ErrReviewerNotInTeam = NewAppError(ErrCodeInvalidReviewer, "reviewer is not a member of the author's team")
+	ErrReviewerInactive  = NewAppError(ErrCodeInvalidReviewer, "reviewer is not active")
+	ErrDuplicateReviewer = NewAppError(ErrCodeInvalidReviewer, "reviewer is requested more than once")
+	ErrTooManyRequested  = NewAppError(ErrCodeTooManyReviewers, "too many requested reviewers")
+	ErrTooManyReviewers  = NewAppError(ErrCodeTooManyReviewers, "PR already has the maximum number of reviewers")
+
+	ErrAlreadyAssigned      = NewAppError(ErrCodeInvalidReviewer, "reviewer is already assigned to this PR")
+	ErrReplacementNotInTeam = NewAppError(ErrCodeInvalidReviewer, "replacement is not a member of the old reviewer's team")
+	ErrReplacementExcluded  = NewAppError(ErrCodeInvalidReviewer, "replacement is in the exclusion list")
+	ErrReviewerDeclined     = NewAppError(ErrCodeInvalidReviewer, "reviewer has declined this PR")
+
+	ErrInvalidCursor    = NewAppError(ErrCodeInvalidRequest, "invalid cursor")
+	ErrInvalidPageSize  = NewAppError(ErrCodeInvalidRequest, "limit must be between 1 and 500")
+	ErrInvalidStatus    = NewAppError(ErrCodeInvalidRequest, "status must be OPEN or MERGED")
+	ErrEmptyBatch       = NewAppError(ErrCodeInvalidRequest, "pull_request_ids must not be empty")
+	ErrBatchTooLarge    = NewAppError(ErrCodeInvalidRequest, "too many pull_request_ids")
+	ErrInvalidWindow    = NewAppError(ErrCodeInvalidRequest, "from must be before to")
+	ErrTeamRequired     = NewAppError(ErrCodeInvalidRequest, "team is required")
+	ErrUserIDRequired   = NewAppError(ErrCodeInvalidRequest, "user_id is required")
+	ErrInvalidThreshold = NewAppError(ErrCodeInvalidRequest, "threshold must be a finite, non-negative number")
+	ErrEmptyImport      = NewAppError(ErrCodeInvalidRequest, "import contains no teams")
+	ErrInvalidRuleKind  = NewAppError(ErrCodeInvalidRequest, "kind must be PAIR or AUTHOR")
+	ErrRuleUsersInvalid = NewAppError(ErrCodeInvalidRequest, "user_id and other_user_id must be two different users")
+	ErrRuleIDRequired   = NewAppError(ErrCodeInvalidRequest, "rule_id is required")
+	ErrTooManyTags      = NewAppError(ErrCodeInvalidRequest, "too many tags")
 )
 
 func IsAppError(err error) (*AppError, bool) {
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type ExclusionKind string
+
+const (
+	// ExclusionPair keeps two users from reviewing each other's PRs.
+	ExclusionPair ExclusionKind = "PAIR"
+	// ExclusionAuthor keeps UserID from reviewing PRs authored by
+	// OtherUserID; OtherUserID may still review UserID.
+	ExclusionAuthor ExclusionKind = "AUTHOR"
+)
+
+// ExclusionRule is a conflict of interest that assignment must respect, for
+// example a manager and their direct report.
+type ExclusionRule struct {
+	RuleID      int64         `json:"rule_id"`
+	Kind        ExclusionKind `json:"kind"`
+	UserID      string        `json:"user_id"`
+	OtherUserID string        `json:"other_user_id"`
+	Reason      string        `json:"reason,omitempty"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+// Forbids reports whether the rule keeps reviewerID off PRs by authorID.
+func (r ExclusionRule) Forbids(reviewerID, authorID string) bool {
+	if r.UserID == reviewerID && r.OtherUserID == authorID {
+		return true
+	}
+	return r.Kind == ExclusionPair && r.UserID == authorID && r.OtherUserID == reviewerID
+}
+
+// Involves reports whether userID is one of the rule's users.
+func (r ExclusionRule) Involves(userID string) bool {
+	return r.UserID == userID || r.OtherUserID == userID
+}
+
+func (r ExclusionRule) String() string {
+	var s string
+	if r.Kind == ExclusionPair {
+		s = fmt.Sprintf("rule %d: %s and %s do not review each other", r.RuleID, r.UserID, r.OtherUserID)
+	} else {
+		s = fmt.Sprintf("rule %d: %s does not review %s", r.RuleID, r.UserID, r.OtherUserID)
+	}
+	if r.Reason != "" {
+		s += " (" + r.Reason + ")"
+	}
+	return s
+}
+
+// NewReviewerExcludedError rejects an explicitly chosen reviewer.
+func NewReviewerExcludedError(rule ExclusionRule) *AppError {
+	return NewAppError(ErrCodeInvalidReviewer, "reviewer is excluded by "+rule.String())
+}
+
+// NewNoCandidateError is ErrNoCandidate naming the rules that eliminated
+// otherwise eligible candidates, if any did.
+func NewNoCandidateError(rules []ExclusionRule) *AppError {
+	if len(rules) == 0 {
+		return ErrNoCandidate
+	}
+	reasons := make([]string, len(rules))
+	for i, rule := range rules {
+		reasons[i] = rule.String()
+	}
+	return NewAppError(ErrCodeNoCandidate, ErrNoCandidate.Message+"; excluded by "+strings.Join(reasons, "; "))
+}
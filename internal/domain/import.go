@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// UserMove is a user the import moves from one team to another.
+type UserMove struct {
+	UserID   string `json:"user_id"`
+	FromTeam string `json:"from_team"`
+	ToTeam   string `json:"to_team"`
+}
+
+// ImportReport is the diff between an org export and the stored teams. With
+// DryRun nothing was written; otherwise all of it was, in one transaction.
+type ImportReport struct {
+	DryRun           bool       `json:"dry_run"`
+	TeamsCreated     []string   `json:"teams_created"`
+	UsersCreated     []User     `json:"users_created"`
+	UsersMoved       []UserMove `json:"users_moved"`
+	UsersUpdated     []User     `json:"users_updated"`
+	UsersDeactivated []string   `json:"users_deactivated"`
+	Unchanged        int        `json:"unchanged"`
+}
+
+// ImportPlan is what the repository writes for an import: the teams to
+// create and every user whose row changes.
+type ImportPlan struct {
+	Teams []string
+	Users []User
+}
+
+// ImportSnapshot is the stored state an import is planned against: which of
+// the listed teams exist, and the current rows of the listed users and of
+// every member of the listed teams, by user ID.
+type ImportSnapshot struct {
+	Teams map[string]bool
+	Users map[string]User
+}
+
+// ImportScope returns the team names and user IDs an org export lists.
+func ImportScope(teams []Team) (teamNames, userIDs []string) {
+	for _, team := range teams {
+		teamNames = append(teamNames, team.TeamName)
+		for _, member := range team.Members {
+			userIDs = append(userIDs, member.UserID)
+		}
+	}
+	return teamNames, userIDs
+}
+
+// ParseTeamsCSV reads an org export with one row per user under a
+// team_name,user_id,username[,is_active] header. Rows are grouped by team in
+// order of first appearance; an empty is_active means active.
+func ParseTeamsCSV(body io.Reader) ([]Team, error) {
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, ErrEmptyImport
+	}
+	if err != nil {
+		return nil, invalidCSV(err)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, required := range []string{"team_name", "user_id", "username"} {
+		if _, ok := columns[required]; !ok {
+			return nil, NewAppError(ErrCodeInvalidRequest, "CSV header must include "+required)
+		}
+	}
+	activeColumn, hasActive := columns["is_active"]
+
+	var teams []Team
+	index := make(map[string]int)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, invalidCSV(err)
+		}
+
+		member := TeamMember{
+			UserID:   record[columns["user_id"]],
+			Username: record[columns["username"]],
+			IsActive: true,
+		}
+		if hasActive && record[activeColumn] != "" {
+			if member.IsActive, err = strconv.ParseBool(record[activeColumn]); err != nil {
+				line, _ := reader.FieldPos(activeColumn)
+				return nil, NewAppError(ErrCodeInvalidRequest, fmt.Sprintf("CSV line %d: is_active must be true or false", line))
+			}
+		}
+
+		teamName := record[columns["team_name"]]
+		i, ok := index[teamName]
+		if !ok {
+			i = len(teams)
+			index[teamName] = i
+			teams = append(teams, Team{TeamName: teamName, Members: []TeamMember{}})
+		}
+		teams[i].Members = append(teams[i].Members, member)
+	}
+
+	return teams, nil
+}
+
+func invalidCSV(err error) error {
+	return NewAppError(ErrCodeInvalidRequest, "invalid CSV: "+err.Error())
+}
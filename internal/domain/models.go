@@ -3,10 +3,13 @@ package domain
 import "time"
 
 type User struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	UserID   string   `json:"user_id"`
+	Username string   `json:"username"`
+	TeamName string   `json:"team_name"`
+	IsActive bool     `json:"is_active"`
+	Skills   []string `json:"skills,omitempty"`
+	// Version grows with every write to the user; see WithExpectedVersion.
+	Version int64 `json:"-"`
 }
 
 type TeamMember struct {
@@ -27,14 +30,32 @@ const (
 	PRStatusMerged PRStatus = "MERGED"
 )
 
+// PullRequest is a PR with its current reviewers. ReviewerTags maps the
+// reviewers picked to cover RequiredTags to the tags each of them covers.
 type PullRequest struct {
-	PullRequestID   string    `json:"pull_request_id"`
-	PullRequestName string    `json:"pull_request_name"`
-	AuthorID        string    `json:"author_id"`
-	Status          PRStatus  `json:"status"`
-	AssignedReviewers []string `json:"assigned_reviewers"`
-	CreatedAt       *time.Time `json:"createdAt,omitempty"`
-	MergedAt        *time.Time `json:"mergedAt,omitempty"`
+	PullRequestID     string              `json:"pull_request_id"`
+	PullRequestName   string              `json:"pull_request_name"`
+	AuthorID          string              `json:"author_id"`
+	Status            PRStatus            `json:"status"`
+	AssignedReviewers []string            `json:"assigned_reviewers"`
+	RequiredTags      []string            `json:"required_tags,omitempty"`
+	ReviewerTags      map[string][]string `json:"reviewer_tags,omitempty"`
+	CreatedAt         *time.Time          `json:"createdAt,omitempty"`
+	MergedAt          *time.Time          `json:"mergedAt,omitempty"`
+	// Version grows with every write to the PR or its reviewers.
+	Version int64 `json:"-"`
+}
+
+type ReviewerAssignment struct {
+	UserID     string    `json:"user_id"`
+	AssignedAt time.Time `json:"assigned_at"`
+}
+
+// PullRequestDetails is the full view of a PR returned by lookups by ID.
+type PullRequestDetails struct {
+	PullRequest
+	AuthorTeam string               `json:"author_team"`
+	Reviewers  []ReviewerAssignment `json:"reviewers"`
 }
 
 type PullRequestShort struct {
@@ -43,3 +64,21 @@ type PullRequestShort struct {
 	AuthorID        string   `json:"author_id"`
 	Status          PRStatus `json:"status"`
 }
+
+type AssignmentAction string
+
+const (
+	AssignmentAssigned   AssignmentAction = "ASSIGNED"
+	AssignmentUnassigned AssignmentAction = "UNASSIGNED"
+	AssignmentReassigned AssignmentAction = "REASSIGNED"
+	AssignmentDeclined   AssignmentAction = "DECLINED"
+)
+
+type AssignmentEvent struct {
+	PullRequestID  string           `json:"pull_request_id"`
+	Action         AssignmentAction `json:"action"`
+	UserID         string           `json:"user_id"`
+	PreviousUserID string           `json:"previous_user_id,omitempty"`
+	Reason         string           `json:"reason,omitempty"`
+	CreatedAt      *time.Time       `json:"created_at,omitempty"`
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 500
+)
+
+// PRFilter narrows pull request listings. Zero values mean "any"; date
+// bounds are inclusive on From and exclusive on To.
+type PRFilter struct {
+	Status       PRStatus
+	AuthorID     string
+	ReviewerID   string
+	TeamName     string
+	NameContains string
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+	MergedFrom   *time.Time
+	MergedTo     *time.Time
+}
+
+// PRCursor points at the last pull request of a page. Listings are ordered
+// by created_at descending with pull_request_id as a tie breaker.
+type PRCursor struct {
+	CreatedAt     time.Time
+	PullRequestID string
+}
+
+func (c PRCursor) Encode() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.PullRequestID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodePRCursor(s string) (*PRCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	createdAt, prID, ok := strings.Cut(string(raw), "|")
+	if !ok || prID == "" {
+		return nil, ErrInvalidCursor
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &PRCursor{CreatedAt: t, PullRequestID: prID}, nil
+}
+
+type PRPage struct {
+	PullRequests []PullRequestShort `json:"pull_requests"`
+	NextCursor   string             `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,75 @@
+package domain
+
+import "time"
+
+// StatisticsFilter limits statistics to the members of one team and to
+// events inside [From, To). Each metric is windowed by its own event time:
+// PR creation, assignment, reassignment, decline or merge.
+type StatisticsFilter struct {
+	TeamName string
+	From     *time.Time
+	To       *time.Time
+}
+
+type ReviewStatistics struct {
+	OpenPRs                int      `json:"open_prs"`
+	MergedPRs              int      `json:"merged_prs"`
+	MergedWithoutReviewers int      `json:"merged_without_reviewers"`
+	Assignments            int      `json:"assignments"`
+	ReassignmentsAway      int      `json:"reassignments_away"`
+	Declines               int      `json:"declines"`
+	AvgTimeToMergeSeconds  *float64 `json:"avg_time_to_merge_seconds,omitempty"`
+	P90TimeToMergeSeconds  *float64 `json:"p90_time_to_merge_seconds,omitempty"`
+}
+
+type UserStatistics struct {
+	UserID   string `json:"user_id"`
+	TeamName string `json:"team_name"`
+	ReviewStatistics
+}
+
+type TeamStatistics struct {
+	TeamName string `json:"team_name"`
+	ReviewStatistics
+}
+
+const DefaultFairnessThreshold = 0.25
+
+type FairnessFilter struct {
+	TeamName string
+	From     time.Time
+	To       time.Time
+	// Threshold is DefaultFairnessThreshold when nil.
+	Threshold *float64
+}
+
+// MemberLoad is the raw input of the fairness report: how long a member was
+// active inside the window and how many reviews they were assigned there.
+type MemberLoad struct {
+	UserID        string
+	IsActive      bool
+	ActiveSeconds float64
+	Assignments   int
+}
+
+type FairnessMember struct {
+	UserID         string  `json:"user_id"`
+	IsActive       bool    `json:"is_active"`
+	ActiveFraction float64 `json:"active_fraction"`
+	Assignments    int     `json:"assignments"`
+	ActualShare    float64 `json:"actual_share"`
+	ExpectedShare  float64 `json:"expected_share"`
+	Deviation      float64 `json:"deviation"`
+	Flagged        bool    `json:"flagged"`
+}
+
+type FairnessReport struct {
+	TeamName         string           `json:"team_name"`
+	From             time.Time        `json:"from"`
+	To               time.Time        `json:"to"`
+	Threshold        float64          `json:"threshold"`
+	TotalAssignments int              `json:"total_assignments"`
+	Gini             float64          `json:"gini"`
+	MaxMinRatio      *float64         `json:"max_min_ratio,omitempty"`
+	Members          []FairnessMember `json:"members"`
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+type SyncStatus string
+
+const (
+	SyncStatusApplied   SyncStatus = "applied"
+	SyncStatusUnchanged SyncStatus = "unchanged"
+	SyncStatusDryRun    SyncStatus = "dry_run"
+	// SyncStatusBlocked means a safety threshold stopped the run before it
+	// wrote anything.
+	SyncStatusBlocked SyncStatus = "blocked"
+	SyncStatusFailed  SyncStatus = "failed"
+	// SyncStatusSkipped means another process held the sync lock, so the run
+	// neither fetched nor wrote anything. Skipped runs are not recorded.
+	SyncStatusSkipped SyncStatus = "skipped"
+)
+
+// SyncRun is the outcome of one directory sync. Report is the diff that was
+// applied or, for blocked and dry runs, the one that would have been.
+type SyncRun struct {
+	ID         int64         `json:"id"`
+	Source     string        `json:"source"`
+	Trigger    string        `json:"trigger"`
+	StartedAt  time.Time     `json:"started_at"`
+	FinishedAt time.Time     `json:"finished_at"`
+	Status     SyncStatus    `json:"status"`
+	Error      string        `json:"error,omitempty"`
+	Report     *ImportReport `json:"report,omitempty"`
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// MaxTags caps both a user's skills and a PR's required tags.
+const MaxTags = 32
+
+var tagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.+-]{0,63}$`)
+
+// TagMatch records that ReviewerID was picked to cover Tag. ReviewerID is
+// empty when nobody could.
+type TagMatch struct {
+	Tag        string
+	ReviewerID string
+}
+
+// NormalizeTags lowercases and trims tags, drops duplicates and sorts them.
+func NormalizeTags(tags []string) ([]string, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	seen := make(map[string]bool, len(tags))
+	var normalized []string
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if !tagPattern.MatchString(tag) {
+			return nil, NewAppError(ErrCodeInvalidRequest, fmt.Sprintf("invalid tag %q: use up to 64 of a-z, 0-9, _ . + -", tag))
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	if len(normalized) > MaxTags {
+		return nil, ErrTooManyTags
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+// HasSkill reports whether the user is tagged with skill.
+func (u User) HasSkill(skill string) bool {
+	for _, s := range u.Skills {
+		if s == skill {
+			return true
+		}
+	}
+	return false
+}
+
+// TagMatches flattens RequiredTags and ReviewerTags for storage, one entry
+// per required tag.
+func (pr *PullRequest) TagMatches() []TagMatch {
+	reviewerByTag := make(map[string]string)
+	for reviewerID, tags := range pr.ReviewerTags {
+		for _, tag := range tags {
+			reviewerByTag[tag] = reviewerID
+		}
+	}
+
+	matches := make([]TagMatch, 0, len(pr.RequiredTags))
+	for _, tag := range pr.RequiredTags {
+		matches = append(matches, TagMatch{Tag: tag, ReviewerID: reviewerByTag[tag]})
+	}
+	return matches
+}
+
+// SetTagMatches is the inverse of TagMatches. Reviewers no longer assigned
+// to the PR are dropped from ReviewerTags, leaving their tags uncovered.
+func (pr *PullRequest) SetTagMatches(matches []TagMatch) {
+	pr.RequiredTags = nil
+	pr.ReviewerTags = nil
+	assigned := make(map[string]bool, len(pr.AssignedReviewers))
+	for _, id := range pr.AssignedReviewers {
+		assigned[id] = true
+	}
+
+	for _, match := range matches {
+		pr.RequiredTags = append(pr.RequiredTags, match.Tag)
+		if match.ReviewerID == "" || !assigned[match.ReviewerID] {
+			continue
+		}
+		if pr.ReviewerTags == nil {
+			pr.ReviewerTags = make(map[string][]string)
+		}
+		pr.ReviewerTags[match.ReviewerID] = append(pr.ReviewerTags[match.ReviewerID], match.Tag)
+	}
+	sort.Strings(pr.RequiredTags)
+	for _, tags := range pr.ReviewerTags {
+		sort.Strings(tags)
+	}
+}
@@ -0,0 +1,19 @@
+package domain
+
+import "context"
+
+type expectedVersionKey struct{}
+
+// WithExpectedVersion makes the write done under ctx apply only while the
+// user or PR is still at version, the one its If-Match ETag was checked
+// against. Losing that race fails with ErrPreconditionFailed instead of
+// overwriting the other write.
+func WithExpectedVersion(ctx context.Context, version int64) context.Context {
+	return context.WithValue(ctx, expectedVersionKey{}, version)
+}
+
+// ExpectedVersion returns the version set by WithExpectedVersion.
+func ExpectedVersion(ctx context.Context) (int64, bool) {
+	version, ok := ctx.Value(expectedVersionKey{}).(int64)
+	return version, ok
+}
@@ -0,0 +1,134 @@
+package grpchandler
+
+import (
+	reviewerv1 "github.com/avito-test/pr-reviewer-service/api/proto/reviewer/v1"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/avito-test/pr-reviewer-service/internal/service"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+func teamToProto(team *domain.Team) *reviewerv1.Team {
+	members := make([]*reviewerv1.TeamMember, 0, len(team.Members))
+	for _, member := range team.Members {
+		members = append(members, &reviewerv1.TeamMember{
+			UserId:   member.UserID,
+			Username: member.Username,
+			IsActive: member.IsActive,
+		})
+	}
+
+	return &reviewerv1.Team{
+		TeamName: team.TeamName,
+		Members:  members,
+	}
+}
+
+func teamFromProto(team *reviewerv1.Team) *domain.Team {
+	members := make([]domain.TeamMember, 0, len(team.GetMembers()))
+	for _, member := range team.GetMembers() {
+		members = append(members, domain.TeamMember{
+			UserID:   member.GetUserId(),
+			Username: member.GetUsername(),
+			IsActive: member.GetIsActive(),
+		})
+	}
+
+	return &domain.Team{
+		TeamName: team.GetTeamName(),
+		Members:  members,
+	}
+}
+
+func userToProto(user *domain.User) *reviewerv1.User {
+	return &reviewerv1.User{
+		UserId:   user.UserID,
+		Username: user.Username,
+		TeamName: user.TeamName,
+		IsActive: user.IsActive,
+	}
+}
+
+func statusToProto(status domain.PRStatus) reviewerv1.PRStatus {
+	switch status {
+	case domain.PRStatusOpen:
+		return reviewerv1.PRStatus_PR_STATUS_OPEN
+	case domain.PRStatusMerged:
+		return reviewerv1.PRStatus_PR_STATUS_MERGED
+	default:
+		return reviewerv1.PRStatus_PR_STATUS_UNSPECIFIED
+	}
+}
+
+func statusFromProto(status reviewerv1.PRStatus) domain.PRStatus {
+	switch status {
+	case reviewerv1.PRStatus_PR_STATUS_OPEN:
+		return domain.PRStatusOpen
+	case reviewerv1.PRStatus_PR_STATUS_MERGED:
+		return domain.PRStatusMerged
+	default:
+		return ""
+	}
+}
+
+func pullRequestToProto(pr *domain.PullRequest) *reviewerv1.PullRequest {
+	result := &reviewerv1.PullRequest{
+		PullRequestId:     pr.PullRequestID,
+		PullRequestName:   pr.PullRequestName,
+		AuthorId:          pr.AuthorID,
+		Status:            statusToProto(pr.Status),
+		AssignedReviewers: pr.AssignedReviewers,
+	}
+
+	if pr.CreatedAt != nil {
+		result.CreatedAt = timestamppb.New(*pr.CreatedAt)
+	}
+	if pr.MergedAt != nil {
+		result.MergedAt = timestamppb.New(*pr.MergedAt)
+	}
+
+	return result
+}
+
+func pullRequestShortToProto(pr domain.PullRequestShort) *reviewerv1.PullRequestShort {
+	return &reviewerv1.PullRequestShort{
+		PullRequestId:   pr.PullRequestID,
+		PullRequestName: pr.PullRequestName,
+		AuthorId:        pr.AuthorID,
+		Status:          statusToProto(pr.Status),
+	}
+}
+
+func listInputFromProto(filter *reviewerv1.PullRequestFilter, page *reviewerv1.PageRequest) service.ListPRsInput {
+	return service.ListPRsInput{
+		Filter: domain.PRFilter{
+			Status:       statusFromProto(filter.GetStatus()),
+			AuthorID:     filter.GetAuthorId(),
+			ReviewerID:   filter.GetReviewerId(),
+			TeamName:     filter.GetTeamName(),
+			NameContains: filter.GetNameContains(),
+			CreatedFrom:  timeFromProto(filter.GetCreatedFrom()),
+			CreatedTo:    timeFromProto(filter.GetCreatedTo()),
+			MergedFrom:   timeFromProto(filter.GetMergedFrom()),
+			MergedTo:     timeFromProto(filter.GetMergedTo()),
+		},
+		Cursor: page.GetCursor(),
+		Limit:  int(page.GetLimit()),
+	}
+}
+
+func pullRequestShortsToProto(prs []domain.PullRequestShort) []*reviewerv1.PullRequestShort {
+	result := make([]*reviewerv1.PullRequestShort, 0, len(prs))
+	for _, pr := range prs {
+		result = append(result, pullRequestShortToProto(pr))
+	}
+	return result
+}
+
+func timeFromProto(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
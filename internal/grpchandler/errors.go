@@ -0,0 +1,49 @@
+package grpchandler
+
+import (
+	"context"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/avito-test/pr-reviewer-service/internal/logging"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const errorDomain = "pr-reviewer-service"
+
+func toStatusError(ctx context.Context, err error) error {
+	if appErr, ok := domain.IsAppError(err); ok {
+		code := codes.InvalidArgument
+
+		switch appErr.Code {
+		case domain.ErrCodeNotFound:
+			code = codes.NotFound
+		case domain.ErrCodeTeamExists, domain.ErrCodePRExists, domain.ErrCodeRuleExists:
+			code = codes.AlreadyExists
+		case domain.ErrCodePRMerged, domain.ErrCodeNotAssigned, domain.ErrCodeNoCandidate, domain.ErrCodeTooManyReviewers, domain.ErrCodePreconditionFailed:
+			code = codes.FailedPrecondition
+		}
+
+		return withReason(status.New(code, appErr.Message), string(appErr.Code))
+	}
+
+	logging.FromContext(ctx).Error("internal error", "error", err)
+	return withReason(status.New(codes.Internal, "internal server error"), "INTERNAL_ERROR")
+}
+
+func invalidArgument(message string) error {
+	return withReason(status.New(codes.InvalidArgument, message), "INVALID_REQUEST")
+}
+
+// withReason attaches the domain error code so clients can branch on it the
+// same way HTTP clients branch on ErrorResponse.Error.Code.
+func withReason(st *status.Status, reason string) error {
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: errorDomain,
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
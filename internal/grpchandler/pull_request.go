@@ -0,0 +1,136 @@
+package grpchandler
+
+import (
+	"context"
+	reviewerv1 "github.com/avito-test/pr-reviewer-service/api/proto/reviewer/v1"
+	"github.com/avito-test/pr-reviewer-service/internal/service"
+)
+
+type PullRequestServer struct {
+	reviewerv1.UnimplementedPullRequestServiceServer
+	service service.PullRequestService
+}
+
+func NewPullRequestServer(service service.PullRequestService) *PullRequestServer {
+	return &PullRequestServer{service: service}
+}
+
+func (s *PullRequestServer) CreatePullRequest(ctx context.Context, req *reviewerv1.CreatePullRequestRequest) (*reviewerv1.CreatePullRequestResponse, error) {
+	if req.GetPullRequestId() == "" || req.GetAuthorId() == "" {
+		return nil, invalidArgument("pull_request_id and author_id are required")
+	}
+
+	pr, err := s.service.CreatePR(ctx, service.CreatePRInput{
+		PullRequestID:      req.GetPullRequestId(),
+		PullRequestName:    req.GetPullRequestName(),
+		AuthorID:           req.GetAuthorId(),
+		RequestedReviewers: req.GetRequestedReviewers(),
+	})
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return &reviewerv1.CreatePullRequestResponse{Pr: pullRequestToProto(pr)}, nil
+}
+
+func (s *PullRequestServer) GetPullRequest(ctx context.Context, req *reviewerv1.GetPullRequestRequest) (*reviewerv1.GetPullRequestResponse, error) {
+	if req.GetPullRequestId() == "" {
+		return nil, invalidArgument("pull_request_id is required")
+	}
+
+	pr, err := s.service.GetPR(ctx, req.GetPullRequestId())
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return &reviewerv1.GetPullRequestResponse{Pr: pullRequestToProto(pr)}, nil
+}
+
+func (s *PullRequestServer) ListPullRequests(ctx context.Context, req *reviewerv1.ListPullRequestsRequest) (*reviewerv1.ListPullRequestsResponse, error) {
+	page, err := s.service.ListPRs(ctx, listInputFromProto(req.GetFilter(), req.GetPage()))
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return &reviewerv1.ListPullRequestsResponse{
+		PullRequests: pullRequestShortsToProto(page.PullRequests),
+		NextCursor:   page.NextCursor,
+	}, nil
+}
+
+func (s *PullRequestServer) MergePullRequest(ctx context.Context, req *reviewerv1.MergePullRequestRequest) (*reviewerv1.MergePullRequestResponse, error) {
+	if req.GetPullRequestId() == "" {
+		return nil, invalidArgument("pull_request_id is required")
+	}
+
+	pr, err := s.service.MergePR(ctx, req.GetPullRequestId())
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return &reviewerv1.MergePullRequestResponse{Pr: pullRequestToProto(pr)}, nil
+}
+
+func (s *PullRequestServer) ReassignReviewer(ctx context.Context, req *reviewerv1.ReassignReviewerRequest) (*reviewerv1.ReassignReviewerResponse, error) {
+	if req.GetPullRequestId() == "" || req.GetOldUserId() == "" {
+		return nil, invalidArgument("pull_request_id and old_user_id are required")
+	}
+
+	pr, replacedBy, err := s.service.ReassignReviewer(ctx, service.ReassignInput{
+		PullRequestID:  req.GetPullRequestId(),
+		OldUserID:      req.GetOldUserId(),
+		NewUserID:      req.GetNewUserId(),
+		ExcludeUserIDs: req.GetExcludeUserIds(),
+		Reason:         req.GetReason(),
+	})
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return &reviewerv1.ReassignReviewerResponse{
+		Pr:         pullRequestToProto(pr),
+		ReplacedBy: replacedBy,
+	}, nil
+}
+
+func (s *PullRequestServer) DeclineReview(ctx context.Context, req *reviewerv1.DeclineReviewRequest) (*reviewerv1.DeclineReviewResponse, error) {
+	if req.GetPullRequestId() == "" || req.GetUserId() == "" || req.GetReason() == "" {
+		return nil, invalidArgument("pull_request_id, user_id and reason are required")
+	}
+
+	pr, replacedBy, err := s.service.DeclineReview(ctx, req.GetPullRequestId(), req.GetUserId(), req.GetReason())
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return &reviewerv1.DeclineReviewResponse{
+		Pr:         pullRequestToProto(pr),
+		ReplacedBy: replacedBy,
+	}, nil
+}
+
+func (s *PullRequestServer) AddReviewer(ctx context.Context, req *reviewerv1.AddReviewerRequest) (*reviewerv1.AddReviewerResponse, error) {
+	if req.GetPullRequestId() == "" || req.GetUserId() == "" {
+		return nil, invalidArgument("pull_request_id and user_id are required")
+	}
+
+	pr, err := s.service.AddReviewer(ctx, req.GetPullRequestId(), req.GetUserId())
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return &reviewerv1.AddReviewerResponse{Pr: pullRequestToProto(pr)}, nil
+}
+
+func (s *PullRequestServer) RemoveReviewer(ctx context.Context, req *reviewerv1.RemoveReviewerRequest) (*reviewerv1.RemoveReviewerResponse, error) {
+	if req.GetPullRequestId() == "" || req.GetUserId() == "" {
+		return nil, invalidArgument("pull_request_id and user_id are required")
+	}
+
+	pr, err := s.service.RemoveReviewer(ctx, req.GetPullRequestId(), req.GetUserId())
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return &reviewerv1.RemoveReviewerResponse{Pr: pullRequestToProto(pr)}, nil
+}
@@ -0,0 +1,18 @@
+package grpchandler
+
+import (
+	reviewerv1 "github.com/avito-test/pr-reviewer-service/api/proto/reviewer/v1"
+	"github.com/avito-test/pr-reviewer-service/internal/service"
+	"google.golang.org/grpc"
+)
+
+func NewServer(service *service.Service, opts ...grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(opts...)
+
+	reviewerv1.RegisterTeamServiceServer(server, NewTeamServer(service.Team))
+	reviewerv1.RegisterUserServiceServer(server, NewUserServer(service.User))
+	reviewerv1.RegisterPullRequestServiceServer(server, NewPullRequestServer(service.PullRequest))
+	reviewerv1.RegisterStatisticsServiceServer(server, NewStatisticsServer(service.Statistics))
+
+	return server
+}
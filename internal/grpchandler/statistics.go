@@ -0,0 +1,126 @@
+package grpchandler
+
+import (
+	"context"
+	reviewerv1 "github.com/avito-test/pr-reviewer-service/api/proto/reviewer/v1"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/avito-test/pr-reviewer-service/internal/service"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type StatisticsServer struct {
+	reviewerv1.UnimplementedStatisticsServiceServer
+	service service.StatisticsService
+}
+
+func NewStatisticsServer(service service.StatisticsService) *StatisticsServer {
+	return &StatisticsServer{service: service}
+}
+
+func (s *StatisticsServer) GetStatistics(ctx context.Context, req *reviewerv1.GetStatisticsRequest) (*reviewerv1.GetStatisticsResponse, error) {
+	filter := domain.StatisticsFilter{
+		TeamName: req.GetTeam(),
+		From:     timeFromProto(req.GetFrom()),
+		To:       timeFromProto(req.GetTo()),
+	}
+
+	stats, err := s.service.GetStatistics(ctx, filter)
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	assignments := make(map[string]int64, len(stats.AssignmentsByUser))
+	for userID, count := range stats.AssignmentsByUser {
+		assignments[userID] = int64(count)
+	}
+
+	declines := make(map[string]int64, len(stats.DeclinesByUser))
+	for userID, count := range stats.DeclinesByUser {
+		declines[userID] = int64(count)
+	}
+
+	users := make([]*reviewerv1.UserStatistics, 0, len(stats.Users))
+	for _, user := range stats.Users {
+		users = append(users, &reviewerv1.UserStatistics{
+			UserId:   user.UserID,
+			TeamName: user.TeamName,
+			Stats:    reviewStatisticsToProto(user.ReviewStatistics),
+		})
+	}
+
+	teams := make([]*reviewerv1.TeamStatistics, 0, len(stats.Teams))
+	for _, team := range stats.Teams {
+		teams = append(teams, &reviewerv1.TeamStatistics{
+			TeamName: team.TeamName,
+			Stats:    reviewStatisticsToProto(team.ReviewStatistics),
+		})
+	}
+
+	return &reviewerv1.GetStatisticsResponse{
+		TotalPrs:          int64(stats.TotalPRs),
+		AssignmentsByUser: assignments,
+		DeclinesByUser:    declines,
+		Users:             users,
+		Teams:             teams,
+	}, nil
+}
+
+func (s *StatisticsServer) GetFairnessReport(ctx context.Context, req *reviewerv1.GetFairnessReportRequest) (*reviewerv1.GetFairnessReportResponse, error) {
+	if req.GetTeam() == "" {
+		return nil, invalidArgument("team is required")
+	}
+
+	filter := domain.FairnessFilter{
+		TeamName:  req.GetTeam(),
+		Threshold: req.Threshold,
+	}
+	if req.GetFrom() != nil {
+		filter.From = req.GetFrom().AsTime()
+	}
+	if req.GetTo() != nil {
+		filter.To = req.GetTo().AsTime()
+	}
+
+	report, err := s.service.GetFairnessReport(ctx, filter)
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	members := make([]*reviewerv1.FairnessMember, 0, len(report.Members))
+	for _, member := range report.Members {
+		members = append(members, &reviewerv1.FairnessMember{
+			UserId:         member.UserID,
+			IsActive:       member.IsActive,
+			ActiveFraction: member.ActiveFraction,
+			Assignments:    int64(member.Assignments),
+			ActualShare:    member.ActualShare,
+			ExpectedShare:  member.ExpectedShare,
+			Deviation:      member.Deviation,
+			Flagged:        member.Flagged,
+		})
+	}
+
+	return &reviewerv1.GetFairnessReportResponse{
+		TeamName:         report.TeamName,
+		From:             timestamppb.New(report.From),
+		To:               timestamppb.New(report.To),
+		Threshold:        report.Threshold,
+		TotalAssignments: int64(report.TotalAssignments),
+		Gini:             report.Gini,
+		MaxMinRatio:      report.MaxMinRatio,
+		Members:          members,
+	}, nil
+}
+
+func reviewStatisticsToProto(stats domain.ReviewStatistics) *reviewerv1.ReviewStatistics {
+	return &reviewerv1.ReviewStatistics{
+		OpenPrs:                int64(stats.OpenPRs),
+		MergedPrs:              int64(stats.MergedPRs),
+		MergedWithoutReviewers: int64(stats.MergedWithoutReviewers),
+		Assignments:            int64(stats.Assignments),
+		ReassignmentsAway:      int64(stats.ReassignmentsAway),
+		Declines:               int64(stats.Declines),
+		AvgTimeToMergeSeconds:  stats.AvgTimeToMergeSeconds,
+		P90TimeToMergeSeconds:  stats.P90TimeToMergeSeconds,
+	}
+}
@@ -0,0 +1,42 @@
+package grpchandler
+
+import (
+	"context"
+	reviewerv1 "github.com/avito-test/pr-reviewer-service/api/proto/reviewer/v1"
+	"github.com/avito-test/pr-reviewer-service/internal/service"
+)
+
+type TeamServer struct {
+	reviewerv1.UnimplementedTeamServiceServer
+	service service.TeamService
+}
+
+func NewTeamServer(service service.TeamService) *TeamServer {
+	return &TeamServer{service: service}
+}
+
+func (s *TeamServer) CreateTeam(ctx context.Context, req *reviewerv1.CreateTeamRequest) (*reviewerv1.CreateTeamResponse, error) {
+	if req.GetTeam().GetTeamName() == "" {
+		return nil, invalidArgument("team.team_name is required")
+	}
+
+	team, err := s.service.CreateTeam(ctx, teamFromProto(req.GetTeam()))
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return &reviewerv1.CreateTeamResponse{Team: teamToProto(team)}, nil
+}
+
+func (s *TeamServer) GetTeam(ctx context.Context, req *reviewerv1.GetTeamRequest) (*reviewerv1.GetTeamResponse, error) {
+	if req.GetTeamName() == "" {
+		return nil, invalidArgument("team_name is required")
+	}
+
+	team, err := s.service.GetTeam(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return &reviewerv1.GetTeamResponse{Team: teamToProto(team)}, nil
+}
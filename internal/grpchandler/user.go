@@ -0,0 +1,59 @@
+package grpchandler
+
+import (
+	"context"
+	reviewerv1 "github.com/avito-test/pr-reviewer-service/api/proto/reviewer/v1"
+	"github.com/avito-test/pr-reviewer-service/internal/service"
+)
+
+type UserServer struct {
+	reviewerv1.UnimplementedUserServiceServer
+	service service.UserService
+}
+
+func NewUserServer(service service.UserService) *UserServer {
+	return &UserServer{service: service}
+}
+
+func (s *UserServer) GetUser(ctx context.Context, req *reviewerv1.GetUserRequest) (*reviewerv1.GetUserResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, invalidArgument("user_id is required")
+	}
+
+	user, err := s.service.GetUser(ctx, req.GetUserId())
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return &reviewerv1.GetUserResponse{User: userToProto(user)}, nil
+}
+
+func (s *UserServer) SetIsActive(ctx context.Context, req *reviewerv1.SetIsActiveRequest) (*reviewerv1.SetIsActiveResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, invalidArgument("user_id is required")
+	}
+
+	user, err := s.service.SetIsActive(ctx, req.GetUserId(), req.GetIsActive())
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return &reviewerv1.SetIsActiveResponse{User: userToProto(user)}, nil
+}
+
+func (s *UserServer) GetReview(ctx context.Context, req *reviewerv1.GetReviewRequest) (*reviewerv1.GetReviewResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, invalidArgument("user_id is required")
+	}
+
+	page, err := s.service.GetReviewPRs(ctx, req.GetUserId(), listInputFromProto(req.GetFilter(), req.GetPage()))
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return &reviewerv1.GetReviewResponse{
+		UserId:       req.GetUserId(),
+		PullRequests: pullRequestShortsToProto(page.PullRequests),
+		NextCursor:   page.NextCursor,
+	}, nil
+}
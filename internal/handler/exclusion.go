@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/avito-test/pr-reviewer-service/internal/service"
+	"net/http"
+)
+
+type ExclusionHandler struct {
+	service service.ExclusionService
+}
+
+func NewExclusionHandler(service service.ExclusionService) *ExclusionHandler {
+	return &ExclusionHandler{service: service}
+}
+
+type AddExclusionRequest struct {
+	Kind        domain.ExclusionKind `json:"kind"`
+	UserID      string               `json:"user_id"`
+	OtherUserID string               `json:"other_user_id"`
+	Reason      string               `json:"reason"`
+}
+
+func (h *ExclusionHandler) Add(w http.ResponseWriter, r *http.Request) {
+	var req AddExclusionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	rule, err := h.service.CreateRule(r.Context(), domain.ExclusionRule{
+		Kind:        req.Kind,
+		UserID:      req.UserID,
+		OtherUserID: req.OtherUserID,
+		Reason:      req.Reason,
+	})
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"rule": rule,
+	}
+	respondWithJSON(w, http.StatusCreated, response)
+}
+
+// List returns every rule, or with user_id only the rules involving that
+// user.
+func (h *ExclusionHandler) List(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.service.ListRules(r.Context(), r.URL.Query().Get("user_id"))
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"rules": rules,
+	}
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+type DeleteExclusionRequest struct {
+	RuleID int64 `json:"rule_id"`
+}
+
+func (h *ExclusionHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	var req DeleteExclusionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if err := h.service.DeleteRule(r.Context(), req.RuleID); err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"rule_id": req.RuleID,
+	}
+	respondWithJSON(w, http.StatusOK, response)
+}
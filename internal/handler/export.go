@@ -0,0 +1,318 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/avito-test/pr-reviewer-service/internal/logging"
+	"github.com/avito-test/pr-reviewer-service/internal/service"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	exportFormatCSV    = "csv"
+	exportFormatNDJSON = "ndjson"
+
+	// exportFlushRows is how many rows are buffered before they are pushed
+	// to the client.
+	exportFlushRows = 100
+
+	// exportWriteTimeout is how far the write deadline is pushed out when the
+	// export starts and on every flush. The server's WriteTimeout covers the
+	// whole response and would cut a long export short; this one only drops
+	// clients that stop reading.
+	exportWriteTimeout = 30 * time.Second
+)
+
+type ExportHandler struct {
+	pullRequests service.PullRequestService
+	statistics   service.StatisticsService
+}
+
+func NewExportHandler(pullRequests service.PullRequestService, statistics service.StatisticsService) *ExportHandler {
+	return &ExportHandler{pullRequests: pullRequests, statistics: statistics}
+}
+
+var exportPRColumns = []string{
+	"pull_request_id", "pull_request_name", "author_id", "author_team", "status", "created_at", "merged_at", "reviewers",
+}
+
+func (h *ExportHandler) PullRequests(w http.ResponseWriter, r *http.Request) {
+	format, filter, ok := parseExportRequest(w, r)
+	if !ok {
+		return
+	}
+
+	out := newExportWriter(w, r, format, "pull_requests", exportPRColumns)
+	err := h.pullRequests.ExportPRs(r.Context(), filter, func(pr domain.PullRequestDetails) error {
+		return out.write(pr, []string{
+			pr.PullRequestID,
+			pr.PullRequestName,
+			pr.AuthorID,
+			pr.AuthorTeam,
+			string(pr.Status),
+			formatExportTime(pr.CreatedAt),
+			formatExportTime(pr.MergedAt),
+			strings.Join(pr.AssignedReviewers, ";"),
+		})
+	})
+	out.finish(err)
+}
+
+var exportHistoryColumns = []string{
+	"pull_request_id", "action", "user_id", "previous_user_id", "reason", "created_at",
+}
+
+func (h *ExportHandler) History(w http.ResponseWriter, r *http.Request) {
+	format, filter, ok := parseExportRequest(w, r)
+	if !ok {
+		return
+	}
+
+	out := newExportWriter(w, r, format, "assignment_history", exportHistoryColumns)
+	err := h.pullRequests.ExportAssignmentHistory(r.Context(), filter, func(event domain.AssignmentEvent) error {
+		return out.write(event, []string{
+			event.PullRequestID,
+			string(event.Action),
+			event.UserID,
+			event.PreviousUserID,
+			event.Reason,
+			formatExportTime(event.CreatedAt),
+		})
+	})
+	out.finish(err)
+}
+
+var exportStatisticsColumns = []string{
+	"open_prs", "merged_prs", "merged_without_reviewers", "assignments", "reassignments_away", "declines",
+	"avg_time_to_merge_seconds", "p90_time_to_merge_seconds",
+}
+
+// Statistics exports one row per user, or per team with group_by=team.
+// Unlike the other exports it is not streamed from the repository: the rows
+// are aggregates, so there are only as many as users or teams, and they are
+// the same rows GET /statistics already returns in a single response.
+func (h *ExportHandler) Statistics(w http.ResponseWriter, r *http.Request) {
+	format, err := negotiateExportFormat(r)
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	groupBy := r.URL.Query().Get("group_by")
+	if groupBy != "" && groupBy != "user" && groupBy != "team" {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "group_by must be user or team")
+		return
+	}
+
+	filter, err := parseStatisticsQuery(r.URL.Query())
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	stats, err := h.statistics.GetStatistics(r.Context(), filter)
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	if groupBy == "team" {
+		out := newExportWriter(w, r, format, "team_statistics", append([]string{"team_name"}, exportStatisticsColumns...))
+		for _, team := range stats.Teams {
+			if err = out.write(team, append([]string{team.TeamName}, statisticsRecord(team.ReviewStatistics)...)); err != nil {
+				break
+			}
+		}
+		out.finish(err)
+		return
+	}
+
+	out := newExportWriter(w, r, format, "user_statistics", append([]string{"user_id", "team_name"}, exportStatisticsColumns...))
+	for _, user := range stats.Users {
+		if err = out.write(user, append([]string{user.UserID, user.TeamName}, statisticsRecord(user.ReviewStatistics)...)); err != nil {
+			break
+		}
+	}
+	out.finish(err)
+}
+
+func statisticsRecord(stats domain.ReviewStatistics) []string {
+	return []string{
+		strconv.Itoa(stats.OpenPRs),
+		strconv.Itoa(stats.MergedPRs),
+		strconv.Itoa(stats.MergedWithoutReviewers),
+		strconv.Itoa(stats.Assignments),
+		strconv.Itoa(stats.ReassignmentsAway),
+		strconv.Itoa(stats.Declines),
+		formatExportFloat(stats.AvgTimeToMergeSeconds),
+		formatExportFloat(stats.P90TimeToMergeSeconds),
+	}
+}
+
+// parseExportRequest reads the format and the listing filters, answering
+// with an error itself when either is invalid.
+func parseExportRequest(w http.ResponseWriter, r *http.Request) (string, domain.PRFilter, bool) {
+	format, err := negotiateExportFormat(r)
+	if err != nil {
+		handleAppError(w, r, err)
+		return "", domain.PRFilter{}, false
+	}
+
+	input, err := parseListQuery(r.URL.Query())
+	if err != nil {
+		handleAppError(w, r, err)
+		return "", domain.PRFilter{}, false
+	}
+
+	return format, input.Filter, true
+}
+
+// negotiateExportFormat prefers an explicit format parameter, then the
+// first supported media type in Accept, and falls back to CSV.
+func negotiateExportFormat(r *http.Request) (string, error) {
+	switch format := r.URL.Query().Get("format"); format {
+	case "":
+	case exportFormatCSV, exportFormatNDJSON:
+		return format, nil
+	default:
+		return "", domain.NewAppError(domain.ErrCodeInvalidRequest, "format must be csv or ndjson")
+	}
+
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accept))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case "text/csv":
+			return exportFormatCSV, nil
+		case "application/x-ndjson", "application/ndjson":
+			return exportFormatNDJSON, nil
+		}
+	}
+
+	return exportFormatCSV, nil
+}
+
+// exportWriter streams rows as CSV or newline-delimited JSON. Headers are
+// sent with the first row so that a failure before any output can still be
+// answered with a regular error response.
+type exportWriter struct {
+	w        http.ResponseWriter
+	r        *http.Request
+	format   string
+	filename string
+	columns  []string
+	csv      *csv.Writer
+	json     *json.Encoder
+	rows     int
+	started  bool
+}
+
+func newExportWriter(w http.ResponseWriter, r *http.Request, format, filename string, columns []string) *exportWriter {
+	return &exportWriter{w: w, r: r, format: format, filename: filename, columns: columns}
+}
+
+func (e *exportWriter) start() error {
+	e.started = true
+
+	contentType, ext := "text/csv; charset=utf-8", ".csv"
+	if e.format == exportFormatNDJSON {
+		contentType, ext = "application/x-ndjson", ".ndjson"
+	}
+	e.w.Header().Set("Content-Type", contentType)
+	e.w.Header().Set("Content-Disposition", `attachment; filename="`+e.filename+ext+`"`)
+	e.extendDeadline()
+	e.w.WriteHeader(http.StatusOK)
+
+	if e.format == exportFormatNDJSON {
+		e.json = json.NewEncoder(e.w)
+		return nil
+	}
+	e.csv = csv.NewWriter(e.w)
+	return e.csv.Write(e.columns)
+}
+
+func (e *exportWriter) write(value interface{}, record []string) error {
+	if !e.started {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	if e.format == exportFormatNDJSON {
+		err = e.json.Encode(value)
+	} else {
+		err = e.csv.Write(record)
+	}
+	if err != nil {
+		return err
+	}
+
+	e.rows++
+	if e.rows%exportFlushRows == 0 {
+		return e.flush()
+	}
+	return nil
+}
+
+func (e *exportWriter) flush() error {
+	if e.csv != nil {
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+	if flusher, ok := e.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	e.extendDeadline()
+	return nil
+}
+
+// extendDeadline gives the export another exportWriteTimeout to write. Writers
+// without deadline support, such as httptest.ResponseRecorder, are left alone.
+func (e *exportWriter) extendDeadline() {
+	_ = http.NewResponseController(e.w).SetWriteDeadline(time.Now().Add(exportWriteTimeout))
+}
+
+// finish completes the export. An error after rows were sent aborts the
+// connection so clients cannot mistake a truncated export for a full one.
+func (e *exportWriter) finish(err error) {
+	if err == nil && !e.started {
+		err = e.start()
+	}
+	if err == nil {
+		err = e.flush()
+	}
+	if err == nil {
+		return
+	}
+
+	if !e.started {
+		handleAppError(e.w, e.r, err)
+		return
+	}
+	logging.FromContext(e.r.Context()).Error("export aborted", "file", e.filename, "rows", e.rows, "error", err)
+	panic(http.ErrAbortHandler)
+}
+
+func formatExportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatExportFloat(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'f', -1, 64)
+}
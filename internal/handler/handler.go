@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"github.com/avito-test/pr-reviewer-service/api"
+	"github.com/avito-test/pr-reviewer-service/internal/health"
 	"github.com/avito-test/pr-reviewer-service/internal/service"
 	"net/http"
 )
@@ -10,6 +12,12 @@ type Handler struct {
 	User        *UserHandler
 	PullRequest *PullRequestHandler
 	Statistics  *StatisticsHandler
+	Exclusion   *ExclusionHandler
+	Export      *ExportHandler
+	Policy      *PolicyHandler
+	Sync        *SyncHandler
+	V2          *V2Handler
+	Health      *health.Checker
 }
 
 func NewHandler(service *service.Service) *Handler {
@@ -18,6 +26,11 @@ func NewHandler(service *service.Service) *Handler {
 		User:        NewUserHandler(service.User),
 		PullRequest: NewPullRequestHandler(service.PullRequest),
 		Statistics:  NewStatisticsHandler(service.Statistics),
+		Exclusion:   NewExclusionHandler(service.Exclusion),
+		Export:      NewExportHandler(service.PullRequest, service.Statistics),
+		Policy:      NewPolicyHandler(service.Policies),
+		V2:          NewV2Handler(service),
+		Health:      health.NewChecker(health.DefaultTimeout),
 	}
 }
 
@@ -26,19 +39,60 @@ func (h *Handler) InitRoutes() http.Handler {
 
 	mux.HandleFunc("/team/add", h.Team.CreateTeam)
 	mux.HandleFunc("/team/get", h.Team.GetTeam)
+	mux.HandleFunc("/team/addMember", h.Team.AddMember)
+	mux.HandleFunc("/team/import", h.Team.ImportTeams)
 
 	mux.HandleFunc("/users/setIsActive", h.User.SetIsActive)
+	mux.HandleFunc("/users/setSkills", h.User.SetSkills)
 	mux.HandleFunc("/users/getReview", h.User.GetReview)
 
 	mux.HandleFunc("/pullRequest/create", h.PullRequest.CreatePR)
 	mux.HandleFunc("/pullRequest/merge", h.PullRequest.MergePR)
+	mux.HandleFunc("/pullRequest/get", h.PullRequest.GetPR)
+	mux.HandleFunc("/pullRequest/getBatch", h.PullRequest.GetBatch)
+	mux.HandleFunc("/pullRequest/list", h.PullRequest.List)
 	mux.HandleFunc("/pullRequest/reassign", h.PullRequest.Reassign)
+	mux.HandleFunc("/pullRequest/decline", h.PullRequest.Decline)
+	mux.HandleFunc("/pullRequest/addReviewer", h.PullRequest.AddReviewer)
+	mux.HandleFunc("/pullRequest/removeReviewer", h.PullRequest.RemoveReviewer)
+	mux.HandleFunc("/pullRequest/history", h.PullRequest.GetHistory)
 
 	mux.HandleFunc("/statistics", h.Statistics.GetStatistics)
+	mux.HandleFunc("/statistics/fairness", h.Statistics.GetFairness)
+
+	mux.HandleFunc("/exclusions/add", h.Exclusion.Add)
+	mux.HandleFunc("/exclusions/list", h.Exclusion.List)
+	mux.HandleFunc("/exclusions/delete", h.Exclusion.Delete)
+
+	// Export is nil when the feature is switched off.
+	if h.Export != nil {
+		mux.HandleFunc("/export/pullRequests", h.Export.PullRequests)
+		mux.HandleFunc("/export/history", h.Export.History)
+		mux.HandleFunc("/export/statistics", h.Export.Statistics)
+	}
+
+	mux.HandleFunc("/policies", h.Policy.GetPolicies)
+
+	// Sync is nil unless a directory source is configured.
+	if h.Sync != nil {
+		mux.HandleFunc("/sync/runs", h.Sync.GetRuns)
+		mux.HandleFunc("/sync/run", h.Sync.RunNow)
+	}
+
+	mux.Handle(v2Prefix+"/", h.V2)
 
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		respondWithJSON(w, http.StatusOK, map[string]string{"status": "ok"})
 	})
 
+	mux.Handle("/livez", h.Health.LivenessHandler())
+	mux.Handle("/readyz", h.Health.ReadinessHandler())
+
+	mux.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(api.OpenAPISpec)
+	})
+
 	return mux
 }
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"mime"
+	"net/http"
+	"strconv"
+)
+
+type ImportTeamsRequest struct {
+	Teams []domain.Team `json:"teams"`
+}
+
+// ImportTeams accepts an org export as JSON ({"teams": [...]}, the shape of
+// /team/add) or, with Content-Type text/csv, one row per user under a
+// team_name,user_id,username[,is_active] header.
+func (h *TeamHandler) ImportTeams(w http.ResponseWriter, r *http.Request) {
+	dryRun := false
+	if value := r.URL.Query().Get("dry_run"); value != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "dry_run must be true or false")
+			return
+		}
+	}
+
+	var teams []domain.Team
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "text/csv" {
+		var err error
+		if teams, err = domain.ParseTeamsCSV(r.Body); err != nil {
+			handleAppError(w, r, err)
+			return
+		}
+	} else {
+		var req ImportTeamsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+			return
+		}
+		teams = req.Teams
+	}
+
+	report, err := h.service.ImportTeams(r.Context(), teams, dryRun)
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, report)
+}
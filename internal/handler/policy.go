@@ -0,0 +1,20 @@
+package handler
+
+import (
+	"github.com/avito-test/pr-reviewer-service/internal/service"
+	"net/http"
+)
+
+type PolicyHandler struct {
+	policies service.PolicySource
+}
+
+func NewPolicyHandler(policies service.PolicySource) *PolicyHandler {
+	return &PolicyHandler{policies: policies}
+}
+
+// GetPolicies returns the assignment policies currently in effect, with the
+// team entries already resolved against the default.
+func (h *PolicyHandler) GetPolicies(w http.ResponseWriter, r *http.Request) {
+	respondWithJSON(w, http.StatusOK, h.policies.Current())
+}
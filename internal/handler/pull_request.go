@@ -2,8 +2,12 @@ package handler
 
 import (
 	"encoding/json"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
 	"github.com/avito-test/pr-reviewer-service/internal/service"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 )
 
 type PullRequestHandler struct {
@@ -15,9 +19,21 @@ func NewPullRequestHandler(service service.PullRequestService) *PullRequestHandl
 }
 
 type CreatePRRequest struct {
-	PullRequestID   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
-	AuthorID        string `json:"author_id"`
+	PullRequestID      string   `json:"pull_request_id"`
+	PullRequestName    string   `json:"pull_request_name"`
+	AuthorID           string   `json:"author_id"`
+	RequestedReviewers []string `json:"requested_reviewers"`
+	RequiredTags       []string `json:"required_tags"`
+}
+
+func (r *CreatePRRequest) toInput() service.CreatePRInput {
+	return service.CreatePRInput{
+		PullRequestID:      r.PullRequestID,
+		PullRequestName:    r.PullRequestName,
+		AuthorID:           r.AuthorID,
+		RequestedReviewers: r.RequestedReviewers,
+		RequiredTags:       r.RequiredTags,
+	}
 }
 
 type MergePRRequest struct {
@@ -25,8 +41,36 @@ type MergePRRequest struct {
 }
 
 type ReassignRequest struct {
+	PullRequestID  string   `json:"pull_request_id"`
+	OldUserID      string   `json:"old_user_id"`
+	NewUserID      string   `json:"new_user_id"`
+	ExcludeUserIDs []string `json:"exclude_user_ids"`
+	Reason         string   `json:"reason"`
+}
+
+func (r *ReassignRequest) toInput() service.ReassignInput {
+	return service.ReassignInput{
+		PullRequestID:  r.PullRequestID,
+		OldUserID:      r.OldUserID,
+		NewUserID:      r.NewUserID,
+		ExcludeUserIDs: r.ExcludeUserIDs,
+		Reason:         r.Reason,
+	}
+}
+
+type GetBatchRequest struct {
+	PullRequestIDs []string `json:"pull_request_ids"`
+}
+
+type ReviewerRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	UserID        string `json:"user_id"`
+}
+
+type DeclineRequest struct {
 	PullRequestID string `json:"pull_request_id"`
-	OldUserID     string `json:"old_user_id"`
+	UserID        string `json:"user_id"`
+	Reason        string `json:"reason"`
 }
 
 func (h *PullRequestHandler) CreatePR(w http.ResponseWriter, r *http.Request) {
@@ -36,9 +80,9 @@ func (h *PullRequestHandler) CreatePR(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pr, err := h.service.CreatePR(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID)
+	pr, err := h.service.CreatePR(r.Context(), req.toInput())
 	if err != nil {
-		handleAppError(w, err)
+		handleAppError(w, r, err)
 		return
 	}
 
@@ -57,7 +101,26 @@ func (h *PullRequestHandler) MergePR(w http.ResponseWriter, r *http.Request) {
 
 	pr, err := h.service.MergePR(r.Context(), req.PullRequestID)
 	if err != nil {
-		handleAppError(w, err)
+		handleAppError(w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"pr": pr,
+	}
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+func (h *PullRequestHandler) GetPR(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "pull_request_id is required")
+		return
+	}
+
+	pr, err := h.service.GetPRDetails(r.Context(), prID)
+	if err != nil {
+		handleAppError(w, r, err)
 		return
 	}
 
@@ -67,6 +130,94 @@ func (h *PullRequestHandler) MergePR(w http.ResponseWriter, r *http.Request) {
 	respondWithJSON(w, http.StatusOK, response)
 }
 
+func (h *PullRequestHandler) GetBatch(w http.ResponseWriter, r *http.Request) {
+	var req GetBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	prs, notFound, err := h.service.GetPRDetailsBatch(r.Context(), req.PullRequestIDs)
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"pull_requests": prs,
+		"not_found":     notFound,
+	}
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+func (h *PullRequestHandler) List(w http.ResponseWriter, r *http.Request) {
+	input, err := parseListQuery(r.URL.Query())
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	page, err := h.service.ListPRs(r.Context(), input)
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, page)
+}
+
+// parseListQuery reads the filter and pagination parameters shared by the
+// pull request listings. Dates are RFC 3339.
+func parseListQuery(query url.Values) (service.ListPRsInput, error) {
+	input := service.ListPRsInput{
+		Filter: domain.PRFilter{
+			Status:       domain.PRStatus(query.Get("status")),
+			AuthorID:     query.Get("author_id"),
+			ReviewerID:   query.Get("reviewer_id"),
+			TeamName:     query.Get("team_name"),
+			NameContains: query.Get("name"),
+		},
+		Cursor: query.Get("cursor"),
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			return input, domain.ErrInvalidPageSize
+		}
+		input.Limit = n
+	}
+
+	var err error
+	if input.Filter.CreatedFrom, err = parseTimeParam(query, "created_from"); err != nil {
+		return input, err
+	}
+	if input.Filter.CreatedTo, err = parseTimeParam(query, "created_to"); err != nil {
+		return input, err
+	}
+	if input.Filter.MergedFrom, err = parseTimeParam(query, "merged_from"); err != nil {
+		return input, err
+	}
+	if input.Filter.MergedTo, err = parseTimeParam(query, "merged_to"); err != nil {
+		return input, err
+	}
+
+	return input, nil
+}
+
+func parseTimeParam(query url.Values, param string) (*time.Time, error) {
+	value := query.Get(param)
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, domain.NewAppError(domain.ErrCodeInvalidRequest, param+" must be an RFC 3339 timestamp")
+	}
+	return &t, nil
+}
+
 func (h *PullRequestHandler) Reassign(w http.ResponseWriter, r *http.Request) {
 	var req ReassignRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -74,9 +225,34 @@ func (h *PullRequestHandler) Reassign(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pr, replacedBy, err := h.service.ReassignReviewer(r.Context(), req.PullRequestID, req.OldUserID)
+	pr, replacedBy, err := h.service.ReassignReviewer(r.Context(), req.toInput())
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"pr":          pr,
+		"replaced_by": replacedBy,
+	}
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+func (h *PullRequestHandler) Decline(w http.ResponseWriter, r *http.Request) {
+	var req DeclineRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.Reason == "" {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "reason is required")
+		return
+	}
+
+	pr, replacedBy, err := h.service.DeclineReview(r.Context(), req.PullRequestID, req.UserID, req.Reason)
 	if err != nil {
-		handleAppError(w, err)
+		handleAppError(w, r, err)
 		return
 	}
 
@@ -86,3 +262,61 @@ func (h *PullRequestHandler) Reassign(w http.ResponseWriter, r *http.Request) {
 	}
 	respondWithJSON(w, http.StatusOK, response)
 }
+
+func (h *PullRequestHandler) AddReviewer(w http.ResponseWriter, r *http.Request) {
+	var req ReviewerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	pr, err := h.service.AddReviewer(r.Context(), req.PullRequestID, req.UserID)
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"pr": pr,
+	}
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+func (h *PullRequestHandler) RemoveReviewer(w http.ResponseWriter, r *http.Request) {
+	var req ReviewerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	pr, err := h.service.RemoveReviewer(r.Context(), req.PullRequestID, req.UserID)
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"pr": pr,
+	}
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+func (h *PullRequestHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "pull_request_id is required")
+		return
+	}
+
+	history, err := h.service.GetAssignmentHistory(r.Context(), prID)
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"pull_request_id": prID,
+		"history":         history,
+	}
+	respondWithJSON(w, http.StatusOK, response)
+}
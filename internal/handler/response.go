@@ -3,13 +3,15 @@ package handler
 import (
 	"encoding/json"
 	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/avito-test/pr-reviewer-service/internal/logging"
 	"net/http"
 )
 
 type ErrorResponse struct {
 	Error struct {
-		Code    string `json:"code"`
-		Message string `json:"message"`
+		Code      string `json:"code"`
+		Message   string `json:"message"`
+		RequestID string `json:"request_id,omitempty"`
 	} `json:"error"`
 }
 
@@ -25,20 +27,23 @@ func respondWithError(w http.ResponseWriter, statusCode int, code domain.ErrorCo
 	var errResp ErrorResponse
 	errResp.Error.Code = string(code)
 	errResp.Error.Message = message
+	errResp.Error.RequestID = w.Header().Get(logging.RequestIDHeader)
 	respondWithJSON(w, statusCode, errResp)
 }
 
-func handleAppError(w http.ResponseWriter, err error) {
+func handleAppError(w http.ResponseWriter, r *http.Request, err error) {
 	if appErr, ok := domain.IsAppError(err); ok {
 		statusCode := http.StatusInternalServerError
 
 		switch appErr.Code {
 		case domain.ErrCodeNotFound:
 			statusCode = http.StatusNotFound
-		case domain.ErrCodeTeamExists, domain.ErrCodePRExists:
+		case domain.ErrCodeTeamExists, domain.ErrCodePRExists, domain.ErrCodeRuleExists:
 			statusCode = http.StatusConflict
-		case domain.ErrCodePRMerged, domain.ErrCodeNotAssigned, domain.ErrCodeNoCandidate:
+		case domain.ErrCodePRMerged, domain.ErrCodeNotAssigned, domain.ErrCodeNoCandidate, domain.ErrCodeTooManyReviewers:
 			statusCode = http.StatusConflict
+		case domain.ErrCodePreconditionFailed:
+			statusCode = http.StatusPreconditionFailed
 		default:
 			statusCode = http.StatusBadRequest
 		}
@@ -47,5 +52,6 @@ func handleAppError(w http.ResponseWriter, err error) {
 		return
 	}
 
+	logging.FromContext(r.Context()).Error("internal error", "method", r.Method, "path", r.URL.Path, "error", err)
 	respondWithError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
 }
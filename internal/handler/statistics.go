@@ -1,8 +1,12 @@
 package handler
 
 import (
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
 	"github.com/avito-test/pr-reviewer-service/internal/service"
+	"math"
 	"net/http"
+	"net/url"
+	"strconv"
 )
 
 type StatisticsHandler struct {
@@ -14,11 +18,72 @@ func NewStatisticsHandler(service service.StatisticsService) *StatisticsHandler
 }
 
 func (h *StatisticsHandler) GetStatistics(w http.ResponseWriter, r *http.Request) {
-	stats, err := h.service.GetStatistics(r.Context())
+	filter, err := parseStatisticsQuery(r.URL.Query())
 	if err != nil {
-		handleAppError(w, err)
+		handleAppError(w, r, err)
+		return
+	}
+
+	stats, err := h.service.GetStatistics(r.Context(), filter)
+	if err != nil {
+		handleAppError(w, r, err)
 		return
 	}
 
 	respondWithJSON(w, http.StatusOK, stats)
 }
+
+func (h *StatisticsHandler) GetFairness(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseFairnessQuery(r.URL.Query())
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	report, err := h.service.GetFairnessReport(r.Context(), filter)
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, report)
+}
+
+func parseStatisticsQuery(query url.Values) (domain.StatisticsFilter, error) {
+	filter := domain.StatisticsFilter{TeamName: query.Get("team")}
+
+	var err error
+	if filter.From, err = parseTimeParam(query, "from"); err != nil {
+		return filter, err
+	}
+	if filter.To, err = parseTimeParam(query, "to"); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+func parseFairnessQuery(query url.Values) (domain.FairnessFilter, error) {
+	window, err := parseStatisticsQuery(query)
+	if err != nil {
+		return domain.FairnessFilter{}, err
+	}
+
+	filter := domain.FairnessFilter{TeamName: window.TeamName}
+	if window.From != nil {
+		filter.From = *window.From
+	}
+	if window.To != nil {
+		filter.To = *window.To
+	}
+
+	if value := query.Get("threshold"); value != "" {
+		threshold, err := strconv.ParseFloat(value, 64)
+		if err != nil || threshold < 0 || math.IsNaN(threshold) || math.IsInf(threshold, 0) {
+			return filter, domain.ErrInvalidThreshold
+		}
+		filter.Threshold = &threshold
+	}
+
+	return filter, nil
+}
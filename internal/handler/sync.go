@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"context"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"net/http"
+)
+
+// SyncRunner is the directory sync as seen by the API.
+type SyncRunner interface {
+	Run(ctx context.Context, trigger string) domain.SyncRun
+	Runs(ctx context.Context) ([]domain.SyncRun, error)
+}
+
+type SyncHandler struct {
+	syncer SyncRunner
+}
+
+func NewSyncHandler(syncer SyncRunner) *SyncHandler {
+	return &SyncHandler{syncer: syncer}
+}
+
+// GetRuns lists the recorded sync runs, newest first.
+func (h *SyncHandler) GetRuns(w http.ResponseWriter, r *http.Request) {
+	runs, err := h.syncer.Runs(r.Context())
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{"runs": runs})
+}
+
+// RunNow syncs immediately and returns the run. Blocked and failed runs are
+// outcomes, not request errors, so they are returned with 200 as well. The
+// run is not cancelled if the client goes away. Only POST starts a run.
+func (h *SyncHandler) RunNow(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		respondWithError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method "+r.Method+" is not allowed")
+		return
+	}
+
+	run := h.syncer.Run(context.WithoutCancel(r.Context()), "api")
+	respondWithJSON(w, http.StatusOK, run)
+}
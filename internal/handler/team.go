@@ -28,7 +28,7 @@ func (h *TeamHandler) CreateTeam(w http.ResponseWriter, r *http.Request) {
 
 	createdTeam, err := h.service.CreateTeam(r.Context(), &team)
 	if err != nil {
-		handleAppError(w, err)
+		handleAppError(w, r, err)
 		return
 	}
 
@@ -38,6 +38,30 @@ func (h *TeamHandler) CreateTeam(w http.ResponseWriter, r *http.Request) {
 	respondWithJSON(w, http.StatusCreated, response)
 }
 
+type AddMemberRequest struct {
+	TeamName string `json:"team_name"`
+	domain.TeamMember
+}
+
+func (h *TeamHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	var req AddMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	team, err := h.service.AddMember(r.Context(), req.TeamName, req.TeamMember)
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"team": team,
+	}
+	respondWithJSON(w, http.StatusOK, response)
+}
+
 func (h *TeamHandler) GetTeam(w http.ResponseWriter, r *http.Request) {
 	teamName := r.URL.Query().Get("team_name")
 	if teamName == "" {
@@ -47,7 +71,7 @@ func (h *TeamHandler) GetTeam(w http.ResponseWriter, r *http.Request) {
 
 	team, err := h.service.GetTeam(r.Context(), teamName)
 	if err != nil {
-		handleAppError(w, err)
+		handleAppError(w, r, err)
 		return
 	}
 
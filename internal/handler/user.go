@@ -28,7 +28,31 @@ func (h *UserHandler) SetIsActive(w http.ResponseWriter, r *http.Request) {
 
 	user, err := h.service.SetIsActive(r.Context(), req.UserID, req.IsActive)
 	if err != nil {
-		handleAppError(w, err)
+		handleAppError(w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"user": user,
+	}
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+type SetSkillsRequest struct {
+	UserID string   `json:"user_id"`
+	Skills []string `json:"skills"`
+}
+
+func (h *UserHandler) SetSkills(w http.ResponseWriter, r *http.Request) {
+	var req SetSkillsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	user, err := h.service.SetSkills(r.Context(), req.UserID, req.Skills)
+	if err != nil {
+		handleAppError(w, r, err)
 		return
 	}
 
@@ -45,15 +69,24 @@ func (h *UserHandler) GetReview(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	prs, err := h.service.GetReviewPRs(r.Context(), userID)
+	input, err := parseListQuery(r.URL.Query())
 	if err != nil {
-		handleAppError(w, err)
+		handleAppError(w, r, err)
+		return
+	}
+
+	page, err := h.service.GetReviewPRs(r.Context(), userID, input)
+	if err != nil {
+		handleAppError(w, r, err)
 		return
 	}
 
 	response := map[string]interface{}{
 		"user_id":       userID,
-		"pull_requests": prs,
+		"pull_requests": page.PullRequests,
+	}
+	if page.NextCursor != "" {
+		response["next_cursor"] = page.NextCursor
 	}
 	respondWithJSON(w, http.StatusOK, response)
 }
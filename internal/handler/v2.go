@@ -0,0 +1,621 @@
+package handler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/avito-test/pr-reviewer-service/internal/service"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+const v2Prefix = "/v2"
+
+// V2Handler serves the resource-oriented API. It routes by hand because the
+// standard mux does not match path parameters or methods.
+type V2Handler struct {
+	service *service.Service
+}
+
+func NewV2Handler(service *service.Service) *V2Handler {
+	return &V2Handler{service: service}
+}
+
+type PatchUserRequest struct {
+	IsActive *bool `json:"is_active"`
+}
+
+type AddReviewerRequest struct {
+	UserID string `json:"user_id"`
+}
+
+type ReassignReviewerRequest struct {
+	NewUserID      string   `json:"new_user_id"`
+	ExcludeUserIDs []string `json:"exclude_user_ids"`
+	Reason         string   `json:"reason"`
+}
+
+type DeclineReviewRequest struct {
+	Reason string `json:"reason"`
+}
+
+type ReassignResponse struct {
+	PullRequest *domain.PullRequest `json:"pull_request"`
+	ReplacedBy  string              `json:"replaced_by"`
+}
+
+func (h *V2Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	segments, ok := pathSegments(strings.TrimPrefix(r.URL.EscapedPath(), v2Prefix))
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "malformed path")
+		return
+	}
+
+	_, handle := h.resolve(segments)
+	handle(w, r)
+}
+
+// Handler returns the route template r matches, such as
+// /v2/pull-requests/{pull_request_id}, or "" when it matches none. It lets
+// httpx.Route label metrics and spans by template instead of by /v2/.
+func (h *V2Handler) Handler(r *http.Request) (http.Handler, string) {
+	segments, ok := pathSegments(strings.TrimPrefix(r.URL.EscapedPath(), v2Prefix))
+	if !ok {
+		return h, ""
+	}
+	route, _ := h.resolve(segments)
+	return h, route
+}
+
+// resolve returns the route template for segments and the handler serving
+// it. Unknown paths resolve to an empty template and a 404 handler.
+func (h *V2Handler) resolve(segments []string) (string, http.HandlerFunc) {
+	switch {
+	case matchPath(segments, "teams"):
+		return v2Prefix + "/teams", h.methods(map[string]http.HandlerFunc{
+			http.MethodPost: h.createTeam,
+		})
+	case matchPath(segments, "teams", "*"):
+		return v2Prefix + "/teams/{team_name}", h.methods(map[string]http.HandlerFunc{
+			http.MethodGet: func(w http.ResponseWriter, r *http.Request) { h.getTeam(w, r, segments[1]) },
+		})
+	case matchPath(segments, "users", "*"):
+		return v2Prefix + "/users/{user_id}", h.methods(map[string]http.HandlerFunc{
+			http.MethodGet:   func(w http.ResponseWriter, r *http.Request) { h.getUser(w, r, segments[1]) },
+			http.MethodPatch: func(w http.ResponseWriter, r *http.Request) { h.patchUser(w, r, segments[1]) },
+		})
+	case matchPath(segments, "users", "*", "reviews"):
+		return v2Prefix + "/users/{user_id}/reviews", h.methods(map[string]http.HandlerFunc{
+			http.MethodGet: func(w http.ResponseWriter, r *http.Request) { h.getUserReviews(w, r, segments[1]) },
+		})
+	case matchPath(segments, "pull-requests"):
+		return v2Prefix + "/pull-requests", h.methods(map[string]http.HandlerFunc{
+			http.MethodGet:  h.listPRs,
+			http.MethodPost: h.createPR,
+		})
+	case matchPath(segments, "pull-requests", "*"):
+		prID, action := splitAction(segments[1])
+		switch action {
+		case "":
+			return v2Prefix + "/pull-requests/{pull_request_id}", h.methods(map[string]http.HandlerFunc{
+				http.MethodGet: func(w http.ResponseWriter, r *http.Request) { h.getPR(w, r, prID) },
+			})
+		case "merge":
+			return v2Prefix + "/pull-requests/{pull_request_id}:merge", h.methods(map[string]http.HandlerFunc{
+				http.MethodPost: func(w http.ResponseWriter, r *http.Request) { h.mergePR(w, r, prID) },
+			})
+		default:
+			return "", notFoundHandler("unknown action " + action)
+		}
+	case matchPath(segments, "pull-requests", "*", "history"):
+		return v2Prefix + "/pull-requests/{pull_request_id}/history", h.methods(map[string]http.HandlerFunc{
+			http.MethodGet: func(w http.ResponseWriter, r *http.Request) { h.getHistory(w, r, segments[1]) },
+		})
+	case matchPath(segments, "pull-requests", "*", "reviewers"):
+		return v2Prefix + "/pull-requests/{pull_request_id}/reviewers", h.methods(map[string]http.HandlerFunc{
+			http.MethodPost: func(w http.ResponseWriter, r *http.Request) { h.addReviewer(w, r, segments[1]) },
+		})
+	case matchPath(segments, "pull-requests", "*", "reviewers", "*"):
+		userID, action := splitAction(segments[3])
+		switch action {
+		case "":
+			return v2Prefix + "/pull-requests/{pull_request_id}/reviewers/{user_id}", h.methods(map[string]http.HandlerFunc{
+				http.MethodDelete: func(w http.ResponseWriter, r *http.Request) { h.removeReviewer(w, r, segments[1], userID) },
+			})
+		case "reassign":
+			return v2Prefix + "/pull-requests/{pull_request_id}/reviewers/{user_id}:reassign", h.methods(map[string]http.HandlerFunc{
+				http.MethodPost: func(w http.ResponseWriter, r *http.Request) { h.reassign(w, r, segments[1], userID) },
+			})
+		case "decline":
+			return v2Prefix + "/pull-requests/{pull_request_id}/reviewers/{user_id}:decline", h.methods(map[string]http.HandlerFunc{
+				http.MethodPost: func(w http.ResponseWriter, r *http.Request) { h.decline(w, r, segments[1], userID) },
+			})
+		default:
+			return "", notFoundHandler("unknown action " + action)
+		}
+	case matchPath(segments, "statistics"):
+		return v2Prefix + "/statistics", h.methods(map[string]http.HandlerFunc{
+			http.MethodGet: h.getStatistics,
+		})
+	case matchPath(segments, "statistics", "fairness"):
+		return v2Prefix + "/statistics/fairness", h.methods(map[string]http.HandlerFunc{
+			http.MethodGet: h.getFairness,
+		})
+	default:
+		return "", notFoundHandler("resource not found")
+	}
+}
+
+func (h *V2Handler) methods(methods map[string]http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.route(w, r, methods)
+	}
+}
+
+func notFoundHandler(message string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		respondWithError(w, http.StatusNotFound, domain.ErrCodeNotFound, message)
+	}
+}
+
+func (h *V2Handler) route(w http.ResponseWriter, r *http.Request, methods map[string]http.HandlerFunc) {
+	if handle, ok := methods[r.Method]; ok {
+		handle(w, r)
+		return
+	}
+
+	allowed := make([]string, 0, len(methods))
+	for method := range methods {
+		allowed = append(allowed, method)
+	}
+	sort.Strings(allowed)
+	w.Header().Set("Allow", strings.Join(allowed, ", "))
+	respondWithError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method "+r.Method+" is not allowed")
+}
+
+func (h *V2Handler) createTeam(w http.ResponseWriter, r *http.Request) {
+	var team domain.Team
+	if err := json.NewDecoder(r.Body).Decode(&team); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if team.TeamName == "" {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "team_name is required")
+		return
+	}
+
+	if team.Members == nil {
+		team.Members = []domain.TeamMember{}
+	}
+
+	createdTeam, err := h.service.Team.CreateTeam(r.Context(), &team)
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Location", resourcePath("teams", createdTeam.TeamName))
+	respondWithResource(w, http.StatusCreated, createdTeam)
+}
+
+func (h *V2Handler) getTeam(w http.ResponseWriter, r *http.Request, teamName string) {
+	team, err := h.service.Team.GetTeam(r.Context(), teamName)
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	respondWithCacheableResource(w, r, team)
+}
+
+func (h *V2Handler) getUser(w http.ResponseWriter, r *http.Request, userID string) {
+	user, err := h.service.User.GetUser(r.Context(), userID)
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	respondWithCacheableResource(w, r, user)
+}
+
+func (h *V2Handler) patchUser(w http.ResponseWriter, r *http.Request, userID string) {
+	var req PatchUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.IsActive == nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "is_active is required")
+		return
+	}
+
+	current, err := h.service.User.GetUser(r.Context(), userID)
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	ctx, ok := checkIfMatch(w, r, current, current.Version)
+	if !ok {
+		return
+	}
+
+	user, err := h.service.User.SetIsActive(ctx, userID, *req.IsActive)
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	respondWithResource(w, http.StatusOK, user)
+}
+
+func (h *V2Handler) getUserReviews(w http.ResponseWriter, r *http.Request, userID string) {
+	input, err := parseListQuery(r.URL.Query())
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	page, err := h.service.User.GetReviewPRs(r.Context(), userID, input)
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, page)
+}
+
+func (h *V2Handler) listPRs(w http.ResponseWriter, r *http.Request) {
+	input, err := parseListQuery(r.URL.Query())
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	page, err := h.service.PullRequest.ListPRs(r.Context(), input)
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, page)
+}
+
+func (h *V2Handler) createPR(w http.ResponseWriter, r *http.Request) {
+	var req CreatePRRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.PullRequestID == "" || req.AuthorID == "" {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "pull_request_id and author_id are required")
+		return
+	}
+
+	pr, err := h.service.PullRequest.CreatePR(r.Context(), req.toInput())
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Location", resourcePath("pull-requests", pr.PullRequestID))
+	respondWithResource(w, http.StatusCreated, pr)
+}
+
+func (h *V2Handler) getPR(w http.ResponseWriter, r *http.Request, prID string) {
+	pr, err := h.service.PullRequest.GetPR(r.Context(), prID)
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	respondWithCacheableResource(w, r, pr)
+}
+
+func (h *V2Handler) mergePR(w http.ResponseWriter, r *http.Request, prID string) {
+	current, err := h.service.PullRequest.GetPR(r.Context(), prID)
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	ctx, ok := checkIfMatch(w, r, current, current.Version)
+	if !ok {
+		return
+	}
+
+	pr, err := h.service.PullRequest.MergePR(ctx, prID)
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	respondWithResource(w, http.StatusOK, pr)
+}
+
+func (h *V2Handler) reassign(w http.ResponseWriter, r *http.Request, prID, oldUserID string) {
+	var req ReassignReviewerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	current, err := h.service.PullRequest.GetPR(r.Context(), prID)
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	ctx, ok := checkIfMatch(w, r, current, current.Version)
+	if !ok {
+		return
+	}
+
+	pr, replacedBy, err := h.service.PullRequest.ReassignReviewer(ctx, service.ReassignInput{
+		PullRequestID:  prID,
+		OldUserID:      oldUserID,
+		NewUserID:      req.NewUserID,
+		ExcludeUserIDs: req.ExcludeUserIDs,
+		Reason:         req.Reason,
+	})
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	w.Header().Set("ETag", etag(pr))
+	respondWithJSON(w, http.StatusOK, ReassignResponse{
+		PullRequest: pr,
+		ReplacedBy:  replacedBy,
+	})
+}
+
+func (h *V2Handler) decline(w http.ResponseWriter, r *http.Request, prID, userID string) {
+	var req DeclineReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.Reason == "" {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "reason is required")
+		return
+	}
+
+	current, err := h.service.PullRequest.GetPR(r.Context(), prID)
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	ctx, ok := checkIfMatch(w, r, current, current.Version)
+	if !ok {
+		return
+	}
+
+	pr, replacedBy, err := h.service.PullRequest.DeclineReview(ctx, prID, userID, req.Reason)
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	w.Header().Set("ETag", etag(pr))
+	respondWithJSON(w, http.StatusOK, ReassignResponse{
+		PullRequest: pr,
+		ReplacedBy:  replacedBy,
+	})
+}
+
+func (h *V2Handler) getHistory(w http.ResponseWriter, r *http.Request, prID string) {
+	history, err := h.service.PullRequest.GetAssignmentHistory(r.Context(), prID)
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"history": history,
+	})
+}
+
+func (h *V2Handler) addReviewer(w http.ResponseWriter, r *http.Request, prID string) {
+	var req AddReviewerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.UserID == "" {
+		respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "user_id is required")
+		return
+	}
+
+	current, err := h.service.PullRequest.GetPR(r.Context(), prID)
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	ctx, ok := checkIfMatch(w, r, current, current.Version)
+	if !ok {
+		return
+	}
+
+	pr, err := h.service.PullRequest.AddReviewer(ctx, prID, req.UserID)
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	respondWithResource(w, http.StatusOK, pr)
+}
+
+func (h *V2Handler) removeReviewer(w http.ResponseWriter, r *http.Request, prID, userID string) {
+	current, err := h.service.PullRequest.GetPR(r.Context(), prID)
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	ctx, ok := checkIfMatch(w, r, current, current.Version)
+	if !ok {
+		return
+	}
+
+	pr, err := h.service.PullRequest.RemoveReviewer(ctx, prID, userID)
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	respondWithResource(w, http.StatusOK, pr)
+}
+
+func (h *V2Handler) getStatistics(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseStatisticsQuery(r.URL.Query())
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	stats, err := h.service.Statistics.GetStatistics(r.Context(), filter)
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, stats)
+}
+
+func (h *V2Handler) getFairness(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseFairnessQuery(r.URL.Query())
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	report, err := h.service.Statistics.GetFairnessReport(r.Context(), filter)
+	if err != nil {
+		handleAppError(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, report)
+}
+
+func pathSegments(escapedPath string) ([]string, bool) {
+	trimmed := strings.Trim(escapedPath, "/")
+	if trimmed == "" {
+		return nil, true
+	}
+
+	segments := strings.Split(trimmed, "/")
+	for i, segment := range segments {
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil {
+			return nil, false
+		}
+		segments[i] = unescaped
+	}
+	return segments, true
+}
+
+func matchPath(segments []string, pattern ...string) bool {
+	if len(segments) != len(pattern) {
+		return false
+	}
+	for i, p := range pattern {
+		if p == "*" {
+			if segments[i] == "" {
+				return false
+			}
+			continue
+		}
+		if segments[i] != p {
+			return false
+		}
+	}
+	return true
+}
+
+// splitAction separates a custom method suffix such as ":merge" from a
+// resource identifier.
+func splitAction(segment string) (string, string) {
+	if i := strings.LastIndex(segment, ":"); i > 0 {
+		return segment[:i], segment[i+1:]
+	}
+	return segment, ""
+}
+
+func resourcePath(collection, id string) string {
+	return v2Prefix + "/" + collection + "/" + url.PathEscape(id)
+}
+
+func etag(resource interface{}) string {
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches is the weak comparison If-None-Match uses.
+func etagMatches(header, tag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// checkIfMatch enforces optimistic concurrency: when the client sends
+// If-Match, the current representation must still carry that ETag under
+// strong comparison, so weak tags never match. The returned context binds
+// the write to the version that was checked, so a concurrent write that
+// lands in between still fails with 412.
+func checkIfMatch(w http.ResponseWriter, r *http.Request, current interface{}, version int64) (context.Context, bool) {
+	header := r.Header.Get("If-Match")
+	if header == "" {
+		return r.Context(), true
+	}
+
+	tag := etag(current)
+	for _, candidate := range strings.Split(header, ",") {
+		switch strings.TrimSpace(candidate) {
+		case "*":
+			return r.Context(), true
+		case tag:
+			return domain.WithExpectedVersion(r.Context(), version), true
+		}
+	}
+
+	handleAppError(w, r, domain.ErrPreconditionFailed)
+	return nil, false
+}
+
+func respondWithResource(w http.ResponseWriter, statusCode int, resource interface{}) {
+	w.Header().Set("ETag", etag(resource))
+	respondWithJSON(w, statusCode, resource)
+}
+
+func respondWithCacheableResource(w http.ResponseWriter, r *http.Request, resource interface{}) {
+	tag := etag(resource)
+	if header := r.Header.Get("If-None-Match"); header != "" && etagMatches(header, tag) {
+		w.Header().Set("ETag", tag)
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	respondWithResource(w, http.StatusOK, resource)
+}
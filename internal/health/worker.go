@@ -0,0 +1,47 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// Worker tracks a background goroutine. The goroutine calls Stopped when it
+// exits and, if it runs periodically, Beat after each iteration.
+type Worker struct {
+	maxSilence time.Duration
+	lastBeat   atomic.Int64
+	stopped    atomic.Bool
+}
+
+// AddWorker registers a readiness check for a background goroutine. With a
+// positive maxSilence the worker is also unready when it has not called
+// Beat for that long.
+func (c *Checker) AddWorker(name string, maxSilence time.Duration) *Worker {
+	w := &Worker{maxSilence: maxSilence}
+	w.Beat()
+	c.Add(name, w.check)
+	return w
+}
+
+func (w *Worker) Beat() {
+	w.lastBeat.Store(time.Now().UnixNano())
+}
+
+func (w *Worker) Stopped() {
+	w.stopped.Store(true)
+}
+
+func (w *Worker) check(ctx context.Context) error {
+	if w.stopped.Load() {
+		return fmt.Errorf("worker has exited")
+	}
+
+	if w.maxSilence > 0 {
+		if silence := time.Since(time.Unix(0, w.lastBeat.Load())); silence > w.maxSilence {
+			return fmt.Errorf("no heartbeat for %s", silence.Round(time.Second))
+		}
+	}
+	return nil
+}
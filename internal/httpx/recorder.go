@@ -0,0 +1,41 @@
+// Package httpx holds helpers shared by the HTTP middlewares.
+package httpx
+
+import "net/http"
+
+// StatusRecorder remembers the status code written through it. Status is
+// 200 until WriteHeader is called, matching net/http.
+type StatusRecorder struct {
+	http.ResponseWriter
+	Status      int
+	wroteHeader bool
+}
+
+func NewStatusRecorder(w http.ResponseWriter) *StatusRecorder {
+	return &StatusRecorder{ResponseWriter: w, Status: http.StatusOK}
+}
+
+func (r *StatusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.Status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *StatusRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	return r.ResponseWriter.Write(b)
+}
+
+// Flush keeps streaming responses such as exports working through the
+// middlewares.
+func (r *StatusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (r *StatusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
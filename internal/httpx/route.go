@@ -0,0 +1,52 @@
+package httpx
+
+import "net/http"
+
+// RouteMatcher is implemented by *http.ServeMux and by middlewares created
+// with Wrap, so route lookups see through any number of middlewares.
+type RouteMatcher interface {
+	Handler(r *http.Request) (http.Handler, string)
+}
+
+// Route returns the pattern next routes r to, "unmatched" when no pattern
+// matches, or the raw path when next cannot tell. When the matched handler is
+// a router itself, its route template wins over the mux pattern. Patterns
+// keep metric and span names low-cardinality.
+func Route(next http.Handler, r *http.Request) string {
+	matcher, ok := next.(RouteMatcher)
+	if !ok {
+		return r.URL.Path
+	}
+
+	handler, pattern := matcher.Handler(r)
+	if pattern == "" {
+		return "unmatched"
+	}
+	if router, ok := handler.(RouteMatcher); ok {
+		if _, route := router.Handler(r); route != "" {
+			return route
+		}
+	}
+	return pattern
+}
+
+// Wrap returns fn as a handler that still answers route lookups for next.
+func Wrap(next http.Handler, fn http.HandlerFunc) http.Handler {
+	return &wrapped{next: next, fn: fn}
+}
+
+type wrapped struct {
+	next http.Handler
+	fn   http.HandlerFunc
+}
+
+func (h *wrapped) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.fn(w, r)
+}
+
+func (h *wrapped) Handler(r *http.Request) (http.Handler, string) {
+	if matcher, ok := h.next.(RouteMatcher); ok {
+		return matcher.Handler(r)
+	}
+	return h.next, ""
+}
@@ -0,0 +1,40 @@
+package logging
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// UnaryServerInterceptor is the gRPC counterpart of Middleware. The request
+// ID travels in the x-request-id metadata key and is echoed in the header.
+func UnaryServerInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	key := strings.ToLower(RequestIDHeader)
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		var incoming string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(key); len(values) > 0 {
+				incoming = values[0]
+			}
+		}
+
+		requestID := requestIDOrNew(incoming)
+		grpc.SetHeader(ctx, metadata.Pairs(key, requestID))
+
+		ctx = withRequestID(ctx, logger, requestID)
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		FromContext(ctx).Info("grpc request",
+			"method", info.FullMethod,
+			"code", status.Code(err).String(),
+			"latency_ms", float64(time.Since(start).Microseconds())/1000,
+		)
+		return resp, err
+	}
+}
@@ -0,0 +1,31 @@
+package logging
+
+import (
+	"github.com/avito-test/pr-reviewer-service/internal/httpx"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Middleware assigns or propagates X-Request-ID, puts a logger tagged with it
+// into the request context and logs every request once it is served.
+func Middleware(logger *slog.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := requestIDOrNew(r.Header.Get(RequestIDHeader))
+		w.Header().Set(RequestIDHeader, requestID)
+
+		ctx := withRequestID(r.Context(), logger, requestID)
+		start := time.Now()
+		recorder := httpx.NewStatusRecorder(w)
+		defer func() {
+			FromContext(ctx).Info("http request",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"status", recorder.Status,
+				"latency_ms", float64(time.Since(start).Microseconds())/1000,
+			)
+		}()
+
+		next.ServeHTTP(recorder, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,79 @@
+// Package logging carries a request-scoped slog.Logger through context and
+// tags every line with the request ID.
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds client supplied IDs so they cannot flood logs.
+const maxRequestIDLength = 128
+
+type contextKey int
+
+const (
+	loggerKey contextKey = iota
+	requestIDKey
+)
+
+// New returns a JSON logger writing to w. level is one of debug, info, warn
+// or error; anything else means info.
+func New(w io.Writer, level string) *slog.Logger {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		l = slog.LevelInfo
+	}
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: l}))
+}
+
+func NewContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey, logger)
+}
+
+// FromContext returns the logger stored in ctx, or slog.Default.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
+
+// withRequestID stores the request ID and a logger tagged with it in ctx.
+func withRequestID(ctx context.Context, logger *slog.Logger, requestID string) context.Context {
+	ctx = context.WithValue(ctx, requestIDKey, requestID)
+	return NewContext(ctx, logger.With("request_id", requestID))
+}
+
+// requestIDOrNew keeps a well-formed incoming ID and generates one otherwise.
+func requestIDOrNew(incoming string) string {
+	if validRequestID(incoming) {
+		return incoming
+	}
+
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	return strings.IndexFunc(id, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_.:", r))
+	}) < 0
+}
@@ -0,0 +1,115 @@
+package metrics
+
+import (
+	"database/sql"
+	"github.com/avito-test/pr-reviewer-service/internal/httpx"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const namespace = "pr_reviewer_"
+
+// Metrics are the service's metrics. It implements service.Observer to count
+// domain events.
+type Metrics struct {
+	Registry *Registry
+
+	httpRequests *CounterVec
+	httpDuration *HistogramVec
+
+	prsCreated        *CounterVec
+	reviewersAssigned *CounterVec
+	reassignments     *CounterVec
+	noCandidate       *CounterVec
+	prsMerged         *CounterVec
+}
+
+func New() *Metrics {
+	r := NewRegistry()
+
+	return &Metrics{
+		Registry: r,
+
+		httpRequests: r.NewCounterVec(namespace+"http_requests_total", "HTTP requests by route and status code.", "method", "route", "code"),
+		httpDuration: r.NewHistogramVec(namespace+"http_request_duration_seconds", "HTTP request latency by route and status code.", DefaultBuckets, "method", "route", "code"),
+
+		prsCreated:        r.NewCounterVec(namespace+"prs_created_total", "Pull requests created."),
+		reviewersAssigned: r.NewCounterVec(namespace+"reviewers_assigned_total", "Reviewers assigned, on creation, manually or as replacements."),
+		reassignments:     r.NewCounterVec(namespace+"reassignments_total", "Reviewers replaced by reassignment or decline."),
+		noCandidate:       r.NewCounterVec(namespace+"no_candidate_total", "Reassignments that failed with NO_CANDIDATE."),
+		prsMerged:         r.NewCounterVec(namespace+"prs_merged_total", "Pull requests merged."),
+	}
+}
+
+func (m *Metrics) PRCreated() {
+	m.prsCreated.Inc()
+}
+
+func (m *Metrics) ReviewersAssigned(n int) {
+	m.reviewersAssigned.Add(float64(n))
+}
+
+func (m *Metrics) ReviewerReassigned() {
+	m.reassignments.Inc()
+}
+
+func (m *Metrics) NoCandidate() {
+	m.noCandidate.Inc()
+}
+
+func (m *Metrics) PRMerged() {
+	m.prsMerged.Inc()
+}
+
+// RegisterDBStats exports the connection pool statistics of db.
+func (m *Metrics) RegisterDBStats(db *sql.DB) {
+	stat := func(fn func(sql.DBStats) float64) func() float64 {
+		return func() float64 { return fn(db.Stats()) }
+	}
+
+	m.Registry.NewGaugeFunc(namespace+"db_max_open_connections", "Maximum number of open connections to the database.",
+		stat(func(s sql.DBStats) float64 { return float64(s.MaxOpenConnections) }))
+	m.Registry.NewGaugeFunc(namespace+"db_open_connections", "Established connections, both in use and idle.",
+		stat(func(s sql.DBStats) float64 { return float64(s.OpenConnections) }))
+	m.Registry.NewGaugeFunc(namespace+"db_in_use_connections", "Connections currently in use.",
+		stat(func(s sql.DBStats) float64 { return float64(s.InUse) }))
+	m.Registry.NewGaugeFunc(namespace+"db_idle_connections", "Idle connections.",
+		stat(func(s sql.DBStats) float64 { return float64(s.Idle) }))
+	m.Registry.NewCounterFunc(namespace+"db_wait_count_total", "Connections waited for.",
+		stat(func(s sql.DBStats) float64 { return float64(s.WaitCount) }))
+	m.Registry.NewCounterFunc(namespace+"db_wait_duration_seconds_total", "Time blocked waiting for a new connection.",
+		stat(func(s sql.DBStats) float64 { return s.WaitDuration.Seconds() }))
+	m.Registry.NewCounterFunc(namespace+"db_max_idle_closed_total", "Connections closed due to SetMaxIdleConns.",
+		stat(func(s sql.DBStats) float64 { return float64(s.MaxIdleClosed) }))
+	m.Registry.NewCounterFunc(namespace+"db_max_idle_time_closed_total", "Connections closed due to SetConnMaxIdleTime.",
+		stat(func(s sql.DBStats) float64 { return float64(s.MaxIdleTimeClosed) }))
+	m.Registry.NewCounterFunc(namespace+"db_max_lifetime_closed_total", "Connections closed due to SetConnMaxLifetime.",
+		stat(func(s sql.DBStats) float64 { return float64(s.MaxLifetimeClosed) }))
+}
+
+// Handler serves the registry in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		m.Registry.WriteText(w)
+	})
+}
+
+// Middleware records the count and latency of requests served by next,
+// labelled with the matched route pattern (see httpx.Route).
+func (m *Metrics) Middleware(next http.Handler) http.Handler {
+	return httpx.Wrap(next, func(w http.ResponseWriter, r *http.Request) {
+		route := httpx.Route(next, r)
+
+		start := time.Now()
+		recorder := httpx.NewStatusRecorder(w)
+		defer func() {
+			code := strconv.Itoa(recorder.Status)
+			m.httpRequests.Inc(r.Method, route, code)
+			m.httpDuration.Observe(time.Since(start).Seconds(), r.Method, route, code)
+		}()
+
+		next.ServeHTTP(recorder, r)
+	})
+}
@@ -0,0 +1,111 @@
+// Package orgsync keeps teams in line with an external directory, such as an
+// HR system export, by periodically importing it.
+package orgsync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DirectorySource returns the teams and members the directory currently
+// lists. String names the source in run records and logs.
+type DirectorySource interface {
+	Fetch(ctx context.Context) ([]domain.Team, error)
+	String() string
+}
+
+// NewSource picks the implementation for location: an http(s) URL or a path
+// to a .csv or .json file. token is sent as a bearer token to HTTP sources.
+func NewSource(location, token string, timeout time.Duration) (DirectorySource, error) {
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		return &HTTPSource{URL: location, Token: token, Client: &http.Client{Timeout: timeout}}, nil
+	}
+
+	switch ext := strings.ToLower(filepath.Ext(location)); ext {
+	case ".csv", ".json":
+		return &FileSource{Path: location}, nil
+	default:
+		return nil, fmt.Errorf("directory source %s must be an http(s) URL or a .csv or .json file", location)
+	}
+}
+
+// FileSource reads an export written to disk by another job. The format
+// follows the extension.
+type FileSource struct {
+	Path string
+}
+
+func (s *FileSource) Fetch(ctx context.Context) ([]domain.Team, error) {
+	file, err := os.Open(s.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return decodeTeams(file, strings.ToLower(filepath.Ext(s.Path)) == ".csv")
+}
+
+func (s *FileSource) String() string {
+	return s.Path
+}
+
+// HTTPSource downloads the export. The response may be JSON or, with
+// Content-Type text/csv, CSV.
+type HTTPSource struct {
+	URL    string
+	Token  string
+	Client *http.Client
+}
+
+func (s *HTTPSource) Fetch(ctx context.Context) ([]domain.Team, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json, text/csv")
+	if s.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.Token)
+	}
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("directory responded with %s", resp.Status)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return decodeTeams(resp.Body, mediaType == "text/csv")
+}
+
+func (s *HTTPSource) String() string {
+	return s.URL
+}
+
+// decodeTeams reads the formats accepted by /team/import: CSV, or JSON in
+// the {"teams": [...]} shape.
+func decodeTeams(body io.Reader, csv bool) ([]domain.Team, error) {
+	if csv {
+		return domain.ParseTeamsCSV(body)
+	}
+
+	var export struct {
+		Teams []domain.Team `json:"teams"`
+	}
+	if err := json.NewDecoder(body).Decode(&export); err != nil {
+		return nil, fmt.Errorf("invalid directory export: %w", err)
+	}
+	return export.Teams, nil
+}
@@ -0,0 +1,180 @@
+package orgsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/avito-test/pr-reviewer-service/internal/repository"
+	"github.com/avito-test/pr-reviewer-service/internal/service"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// maxRuns is how many of the latest runs Runs lists.
+const maxRuns = 100
+
+type Options struct {
+	// MaxDeactivatePercent blocks a run that would deactivate more than this
+	// share of the active members of the synced teams.
+	MaxDeactivatePercent int
+	// DryRun computes and records the diff without applying it.
+	DryRun bool
+}
+
+// Syncer reconciles teams with a directory through TeamService.ImportTeams,
+// so a sync creates, moves and deactivates users exactly as an import of
+// the same export would. Runs are recorded in the repository, which every
+// replica shares.
+type Syncer struct {
+	source DirectorySource
+	teams  service.TeamService
+	runs   repository.SyncRunRepository
+	opts   Options
+
+	// running serializes runs within the process; the repository's sync
+	// lock keeps other replicas out.
+	running sync.Mutex
+}
+
+func New(source DirectorySource, teams service.TeamService, runs repository.SyncRunRepository, opts Options) *Syncer {
+	return &Syncer{source: source, teams: teams, runs: runs, opts: opts}
+}
+
+// Run syncs once and records the outcome. Concurrent calls in the process
+// wait for each other; if another replica is syncing, the run is skipped.
+func (s *Syncer) Run(ctx context.Context, trigger string) domain.SyncRun {
+	s.running.Lock()
+	defer s.running.Unlock()
+
+	run := domain.SyncRun{Source: s.source.String(), Trigger: trigger, StartedAt: time.Now().UTC()}
+	var err error
+	acquired, lockErr := s.runs.WithLock(ctx, func() error {
+		run.Status, run.Report, err = s.reconcile(ctx)
+		run.FinishedAt = time.Now().UTC()
+		if err != nil {
+			run.Error = err.Error()
+		}
+		return s.runs.CreateRun(ctx, &run)
+	})
+	switch {
+	case !acquired && lockErr != nil:
+		err = fmt.Errorf("failed to take the sync lock: %w", lockErr)
+		run.FinishedAt = time.Now().UTC()
+		run.Status = domain.SyncStatusFailed
+		run.Error = err.Error()
+	case !acquired:
+		run.FinishedAt = time.Now().UTC()
+		run.Status = domain.SyncStatusSkipped
+	case lockErr != nil:
+		slog.Error("Failed to record directory sync run", "source", run.Source, "error", lockErr)
+	}
+
+	logger := slog.With("run", run.ID, "source", run.Source, "trigger", trigger, "status", run.Status)
+	report := run.Report
+	switch run.Status {
+	case domain.SyncStatusSkipped:
+		logger.Info("Directory sync skipped, another replica is running it")
+	case domain.SyncStatusFailed, domain.SyncStatusBlocked:
+		logger.Error("Directory sync did not apply", "error", err)
+	case domain.SyncStatusApplied, domain.SyncStatusDryRun:
+		logger.Info("Directory sync finished",
+			"teams_created", len(report.TeamsCreated),
+			"users_created", len(report.UsersCreated),
+			"users_moved", len(report.UsersMoved),
+			"users_updated", len(report.UsersUpdated),
+			"users_deactivated", len(report.UsersDeactivated))
+	}
+	return run
+}
+
+// Runs returns the latest recorded runs of every replica, newest first.
+func (s *Syncer) Runs(ctx context.Context) ([]domain.SyncRun, error) {
+	return s.runs.ListRuns(ctx, maxRuns)
+}
+
+// Schedule runs immediately and then every interval until ctx is done. beat,
+// when set, is called after every run.
+func (s *Syncer) Schedule(ctx context.Context, interval time.Duration, beat func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.Run(ctx, "schedule")
+		if beat != nil {
+			beat()
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Syncer) reconcile(ctx context.Context) (domain.SyncStatus, *domain.ImportReport, error) {
+	teams, err := s.source.Fetch(ctx)
+	if err != nil {
+		return domain.SyncStatusFailed, nil, fmt.Errorf("failed to fetch directory: %w", err)
+	}
+
+	// The thresholds are checked inside the import transaction, against the
+	// very diff that is then applied.
+	var checked *domain.ImportReport
+	report, err := s.teams.ImportTeamsChecked(ctx, teams, s.opts.DryRun, func(plan *domain.ImportReport, current domain.ImportSnapshot) error {
+		checked = plan
+		return s.checkThresholds(teams, plan, current)
+	})
+	var blocked *thresholdError
+	switch {
+	case errors.As(err, &blocked):
+		return domain.SyncStatusBlocked, checked, err
+	case err != nil:
+		return domain.SyncStatusFailed, checked, err
+	case !hasChanges(report):
+		return domain.SyncStatusUnchanged, report, nil
+	case report.DryRun:
+		return domain.SyncStatusDryRun, report, nil
+	}
+	return domain.SyncStatusApplied, report, nil
+}
+
+// thresholdError is a safety threshold refusing a run.
+type thresholdError struct {
+	deactivated, active, limit int
+}
+
+func (e *thresholdError) Error() string {
+	return fmt.Sprintf("run would deactivate %d of %d active users, more than the %d%% limit", e.deactivated, e.active, e.limit)
+}
+
+// checkThresholds refuses mass deactivations, which usually mean a broken or
+// truncated export rather than people leaving.
+func (s *Syncer) checkThresholds(teams []domain.Team, plan *domain.ImportReport, current domain.ImportSnapshot) error {
+	deactivated := len(plan.UsersDeactivated)
+	if deactivated == 0 {
+		return nil
+	}
+
+	listed := make(map[string]bool, len(teams))
+	for _, team := range teams {
+		listed[team.TeamName] = true
+	}
+	active := 0
+	for _, user := range current.Users {
+		if user.IsActive && listed[user.TeamName] {
+			active++
+		}
+	}
+
+	if deactivated*100 > s.opts.MaxDeactivatePercent*active {
+		return &thresholdError{deactivated: deactivated, active: active, limit: s.opts.MaxDeactivatePercent}
+	}
+	return nil
+}
+
+func hasChanges(report *domain.ImportReport) bool {
+	return len(report.TeamsCreated) > 0 || len(report.UsersCreated) > 0 || len(report.UsersMoved) > 0 ||
+		len(report.UsersUpdated) > 0 || len(report.UsersDeactivated) > 0
+}
@@ -0,0 +1,197 @@
+// Package policy holds the per-team reviewer assignment policies and reloads
+// them from a YAML or TOML file without restarting the server.
+package policy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+type Strategy string
+
+const (
+	// StrategyRandom picks uniformly among the candidates.
+	StrategyRandom Strategy = "random"
+	// StrategyLeastLoaded prefers the candidates with the fewest review
+	// assignments over the recent load window.
+	StrategyLeastLoaded Strategy = "least_loaded"
+)
+
+var strategies = []Strategy{StrategyRandom, StrategyLeastLoaded}
+
+const MaxReviewerCount = 10
+
+// Policy decides how reviewers are picked for PRs authored in a team.
+type Policy struct {
+	Strategy      Strategy `json:"strategy" yaml:"strategy" toml:"strategy"`
+	ReviewerCount int      `json:"reviewer_count" yaml:"reviewer_count" toml:"reviewer_count"`
+	// FallbackTeams are drawn from, in order, when the author's team has
+	// too few candidates.
+	FallbackTeams []string `json:"fallback_teams" yaml:"fallback_teams" toml:"fallback_teams"`
+	// Exclude lists users that are never picked automatically.
+	Exclude []string `json:"exclude" yaml:"exclude" toml:"exclude"`
+}
+
+// Default is the policy used when no file is configured: two random
+// reviewers from the author's team.
+func Default() Policy {
+	return Policy{Strategy: StrategyRandom, ReviewerCount: 2, FallbackTeams: []string{}, Exclude: []string{}}
+}
+
+// Excludes reports whether userID must not be picked automatically.
+func (p Policy) Excludes(userID string) bool {
+	for _, id := range p.Exclude {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// Snapshot is an immutable, validated set of policies. Team entries already
+// have the default filled in for every field they leave unset.
+type Snapshot struct {
+	Version  int               `json:"version"`
+	LoadedAt time.Time         `json:"loaded_at"`
+	Source   string            `json:"source,omitempty"`
+	Checksum string            `json:"checksum,omitempty"`
+	Default  Policy            `json:"default"`
+	Teams    map[string]Policy `json:"teams"`
+	// ReloadError is the reason the latest reload was rejected, if it was.
+	ReloadError string `json:"reload_error,omitempty"`
+}
+
+// For returns the policy for PRs authored in teamName.
+func (s *Snapshot) For(teamName string) Policy {
+	if p, ok := s.Teams[teamName]; ok {
+		return p
+	}
+	return s.Default
+}
+
+// file is the on-disk layout.
+type file struct {
+	Default Policy            `yaml:"default" toml:"default"`
+	Teams   map[string]Policy `yaml:"teams" toml:"teams"`
+}
+
+// Parse decodes and validates a policy file; ext selects YAML or TOML.
+// Fields missing from the file's default come from defaults.
+func Parse(content []byte, ext string, defaults Policy) (*Snapshot, error) {
+	var f file
+	switch strings.ToLower(ext) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&f); err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+	case ".toml":
+		meta, err := toml.Decode(string(content), &f)
+		if err != nil {
+			return nil, err
+		}
+		if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+			return nil, fmt.Errorf("unknown key %q", undecoded[0].String())
+		}
+	default:
+		return nil, fmt.Errorf("policy file must have a .yaml, .yml or .toml extension")
+	}
+
+	snapshot := &Snapshot{
+		Default: inherit(f.Default, defaults, ""),
+		Teams:   make(map[string]Policy, len(f.Teams)),
+	}
+	for name, p := range f.Teams {
+		snapshot.Teams[name] = inherit(p, snapshot.Default, name)
+	}
+
+	if err := snapshot.validate(); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// inherit fills the unset fields of team's policy p from parent. An
+// explicitly empty list clears the parent's list, and a team never inherits
+// itself as a fallback.
+func inherit(p, parent Policy, team string) Policy {
+	if p.Strategy == "" {
+		p.Strategy = parent.Strategy
+	}
+	if p.ReviewerCount == 0 {
+		p.ReviewerCount = parent.ReviewerCount
+	}
+	if p.FallbackTeams == nil {
+		p.FallbackTeams = []string{}
+		for _, fallback := range parent.FallbackTeams {
+			if fallback != team {
+				p.FallbackTeams = append(p.FallbackTeams, fallback)
+			}
+		}
+	}
+	if p.Exclude == nil {
+		p.Exclude = append([]string{}, parent.Exclude...)
+	}
+	return p
+}
+
+func (s *Snapshot) validate() error {
+	var errs []string
+	check := func(key string, p Policy, team string) {
+		valid := false
+		for _, strategy := range strategies {
+			valid = valid || p.Strategy == strategy
+		}
+		if !valid {
+			errs = append(errs, fmt.Sprintf("%s.strategy must be random or least_loaded, got %q", key, p.Strategy))
+		}
+		if p.ReviewerCount < 1 || p.ReviewerCount > MaxReviewerCount {
+			errs = append(errs, fmt.Sprintf("%s.reviewer_count must be between 1 and %d, got %d", key, MaxReviewerCount, p.ReviewerCount))
+		}
+
+		seen := make(map[string]bool)
+		for _, fallback := range p.FallbackTeams {
+			switch {
+			case fallback == "":
+				errs = append(errs, fmt.Sprintf("%s.fallback_teams must not contain empty names", key))
+			case fallback == team:
+				errs = append(errs, fmt.Sprintf("%s.fallback_teams must not contain the team itself", key))
+			case seen[fallback]:
+				errs = append(errs, fmt.Sprintf("%s.fallback_teams lists %s twice", key, fallback))
+			}
+			seen[fallback] = true
+		}
+		for _, userID := range p.Exclude {
+			if userID == "" {
+				errs = append(errs, fmt.Sprintf("%s.exclude must not contain empty user IDs", key))
+			}
+		}
+	}
+
+	check("default", s.Default, "")
+	names := make([]string, 0, len(s.Teams))
+	for name := range s.Teams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if name == "" {
+			errs = append(errs, "teams must not contain an empty team name")
+			continue
+		}
+		check("teams."+name, s.Teams[name], name)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(errs, "\n  "))
+}
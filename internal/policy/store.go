@@ -0,0 +1,150 @@
+package policy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Store holds the current snapshot and swaps in a new one only after the
+// file it was reloaded from has been fully validated. Readers never see a
+// partially applied file.
+type Store struct {
+	path     string
+	defaults Policy
+
+	mu      sync.RWMutex
+	current *Snapshot
+}
+
+// NewStore loads the policies at path, or serves defaults for every team
+// when path is empty. Fields unset in defaults come from Default. An invalid
+// file is a startup error.
+func NewStore(path string, defaults Policy) (*Store, error) {
+	s := &Store{path: path, defaults: inherit(defaults, Default(), "")}
+	if path == "" {
+		s.current = &Snapshot{
+			Version:  1,
+			LoadedAt: time.Now().UTC(),
+			Default:  s.defaults,
+			Teams:    map[string]Policy{},
+		}
+		return s, nil
+	}
+
+	if _, err := s.Reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Current returns the snapshot in effect. It must not be modified.
+func (s *Store) Current() *Snapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.current
+}
+
+// Reload re-reads the file and swaps it in if its content changed. A file
+// that fails to parse or validate leaves the current policies in place and
+// is reported in the snapshot's ReloadError.
+func (s *Store) Reload() (bool, error) {
+	if s.path == "" {
+		return false, nil
+	}
+
+	snapshot, err := s.load()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err != nil {
+		err = fmt.Errorf("invalid policy file %s:\n  %w", s.path, err)
+		if s.current != nil {
+			rejected := *s.current
+			rejected.ReloadError = err.Error()
+			s.current = &rejected
+		}
+		return false, err
+	}
+
+	if s.current != nil {
+		if snapshot.Checksum == s.current.Checksum {
+			if s.current.ReloadError != "" {
+				unchanged := *s.current
+				unchanged.ReloadError = ""
+				s.current = &unchanged
+			}
+			return false, nil
+		}
+		snapshot.Version = s.current.Version + 1
+	} else {
+		snapshot.Version = 1
+	}
+	s.current = snapshot
+	return true, nil
+}
+
+func (s *Store) load() (*Snapshot, error) {
+	content, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := Parse(content, filepath.Ext(s.path), s.defaults)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(content)
+	snapshot.Checksum = hex.EncodeToString(sum[:])
+	snapshot.Source = s.path
+	snapshot.LoadedAt = time.Now().UTC()
+	return snapshot, nil
+}
+
+// Watch reloads on every value received from trigger (for example SIGHUP)
+// and, with a positive interval, polls the file. beat, when set, is called
+// after every reload attempt. It returns when ctx is done.
+func (s *Store) Watch(ctx context.Context, interval time.Duration, trigger <-chan os.Signal, beat func()) {
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	// lastError keeps polling from logging the same rejection every tick.
+	var lastError string
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick:
+		case <-trigger:
+		}
+
+		changed, err := s.Reload()
+		if beat != nil {
+			beat()
+		}
+		switch {
+		case err != nil:
+			if err.Error() != lastError {
+				slog.Error("Rejected policy file, keeping previous policies", "error", err)
+			}
+			lastError = err.Error()
+			continue
+		case changed:
+			snapshot := s.Current()
+			slog.Info("Reloaded assignment policies", "version", snapshot.Version, "teams", len(snapshot.Teams))
+		}
+		lastError = ""
+	}
+}
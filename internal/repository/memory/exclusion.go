@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"context"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+)
+
+type ExclusionRepo struct {
+	s *store
+}
+
+func (r *ExclusionRepo) CreateRule(ctx context.Context, rule *domain.ExclusionRule) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, existing := range r.s.exclusions {
+		if existing.Kind == rule.Kind && existing.UserID == rule.UserID && existing.OtherUserID == rule.OtherUserID {
+			return domain.ErrRuleExists
+		}
+	}
+
+	r.s.lastRuleID++
+	rule.RuleID = r.s.lastRuleID
+	rule.CreatedAt = now()
+	r.s.exclusions = append(r.s.exclusions, *rule)
+	return nil
+}
+
+func (r *ExclusionRepo) DeleteRule(ctx context.Context, ruleID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for i, rule := range r.s.exclusions {
+		if rule.RuleID == ruleID {
+			r.s.exclusions = append(r.s.exclusions[:i], r.s.exclusions[i+1:]...)
+			return nil
+		}
+	}
+	return domain.ErrRuleNotFound
+}
+
+// ListRules relies on exclusions being kept in rule_id order.
+func (r *ExclusionRepo) ListRules(ctx context.Context, userID string) ([]domain.ExclusionRule, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	rules := []domain.ExclusionRule{}
+	for _, rule := range r.s.exclusions {
+		if userID == "" || rule.Involves(userID) {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
@@ -0,0 +1,90 @@
+// Package memory implements the repositories on mutex-guarded maps. It keeps
+// the semantics of the postgres package and is meant for tests and local
+// development; nothing is persisted.
+package memory
+
+import (
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/avito-test/pr-reviewer-service/internal/repository"
+	"sync"
+	"time"
+)
+
+// store holds every table. All repositories of one NewRepository call share
+// it, the way the postgres repositories share a database.
+type store struct {
+	mu sync.RWMutex
+
+	teams        map[string]time.Time
+	users        map[string]*userRecord
+	activity     []activityRecord
+	pullRequests map[string]*prRecord
+	history      []domain.AssignmentEvent
+	exclusions   []domain.ExclusionRule
+	lastRuleID   int64
+	syncRuns     []domain.SyncRun
+
+	// syncLock is the sync lock, held outside mu for the whole run.
+	syncLock sync.Mutex
+}
+
+type userRecord struct {
+	user      domain.User
+	createdAt time.Time
+}
+
+type activityRecord struct {
+	userID    string
+	isActive  bool
+	changedAt time.Time
+}
+
+type prRecord struct {
+	pr        domain.PullRequest
+	createdAt time.Time
+	mergedAt  *time.Time
+	reviewers map[string]time.Time
+	declines  map[string]declineRecord
+	tags      []domain.TagMatch
+}
+
+type declineRecord struct {
+	reason     string
+	declinedAt time.Time
+}
+
+func NewRepository() *repository.Repository {
+	s := &store{
+		teams:        make(map[string]time.Time),
+		users:        make(map[string]*userRecord),
+		pullRequests: make(map[string]*prRecord),
+	}
+	return repository.WithTracing(&repository.Repository{
+		Team:        &TeamRepo{s: s},
+		User:        &UserRepo{s: s},
+		PullRequest: &PullRequestRepo{s: s},
+		Statistics:  &StatisticsRepo{s: s},
+		Exclusion:   &ExclusionRepo{s: s},
+		SyncRun:     &SyncRunRepo{s: s},
+	}, "memory")
+}
+
+// now mirrors the TIMESTAMP columns: UTC with microsecond precision.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+// inWindow reports whether t is inside [from, to), either bound optional.
+func inWindow(t time.Time, from, to *time.Time) bool {
+	if from != nil && t.Before(*from) {
+		return false
+	}
+	if to != nil && !t.Before(*to) {
+		return false
+	}
+	return true
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
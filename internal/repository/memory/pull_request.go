@@ -0,0 +1,518 @@
+package memory
+
+import (
+	"context"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"sort"
+	"strings"
+	"time"
+)
+
+type PullRequestRepo struct {
+	s *store
+}
+
+func (r *PullRequestRepo) CreatePR(ctx context.Context, pr *domain.PullRequest) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.pullRequests[pr.PullRequestID]; ok {
+		return domain.ErrPRExists
+	}
+	if _, ok := r.s.users[pr.AuthorID]; !ok {
+		return domain.ErrAuthorNotFound
+	}
+	for _, reviewerID := range pr.AssignedReviewers {
+		if _, ok := r.s.users[reviewerID]; !ok {
+			return domain.ErrUserNotFound
+		}
+	}
+
+	record := &prRecord{
+		pr: domain.PullRequest{
+			PullRequestID:   pr.PullRequestID,
+			PullRequestName: pr.PullRequestName,
+			AuthorID:        pr.AuthorID,
+			Status:          pr.Status,
+			Version:         1,
+		},
+		createdAt: now(),
+		reviewers: make(map[string]time.Time),
+		declines:  make(map[string]declineRecord),
+		tags:      pr.TagMatches(),
+	}
+	r.s.pullRequests[pr.PullRequestID] = record
+
+	for _, reviewerID := range pr.AssignedReviewers {
+		record.reviewers[reviewerID] = now()
+		r.s.addHistory(domain.AssignmentEvent{
+			PullRequestID: pr.PullRequestID,
+			Action:        domain.AssignmentAssigned,
+			UserID:        reviewerID,
+		})
+	}
+	return nil
+}
+
+func (r *PullRequestRepo) GetPR(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	record, ok := r.s.pullRequests[prID]
+	if !ok {
+		return nil, domain.ErrPRNotFound
+	}
+	pr := record.pullRequest()
+	pr.SetTagMatches(record.tags)
+	return &pr, nil
+}
+
+// GetPRDetails loads the PRs with the given IDs. Missing IDs are skipped; the
+// result is in no particular order.
+func (r *PullRequestRepo) GetPRDetails(ctx context.Context, prIDs []string) ([]domain.PullRequestDetails, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var details []domain.PullRequestDetails
+	seen := make(map[string]bool)
+	for _, prID := range prIDs {
+		record, ok := r.s.pullRequests[prID]
+		if !ok || seen[prID] {
+			continue
+		}
+		seen[prID] = true
+		details = append(details, r.s.details(record, byUserID))
+	}
+	return details, nil
+}
+
+func (r *PullRequestRepo) PRExists(ctx context.Context, prID string) (bool, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	_, ok := r.s.pullRequests[prID]
+	return ok, nil
+}
+
+func (r *PullRequestRepo) MergePR(ctx context.Context, prID string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	record, ok := r.s.pullRequests[prID]
+	if !ok {
+		return nil
+	}
+	if err := bumpVersion(ctx, &record.pr.Version); err != nil {
+		return err
+	}
+	if record.pr.Status == domain.PRStatusMerged {
+		return nil
+	}
+	record.pr.Status = domain.PRStatusMerged
+	record.mergedAt = timePtr(now())
+	return nil
+}
+
+func (r *PullRequestRepo) AssignReviewer(ctx context.Context, prID, userID, reason string, maxReviewers int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	record, err := r.s.reviewTarget(prID, userID)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(ctx, record.pr.Version); err != nil {
+		return err
+	}
+	_, assigned := record.reviewers[userID]
+	if !assigned && len(record.reviewers) >= maxReviewers {
+		return domain.ErrTooManyReviewers
+	}
+	record.pr.Version++
+	if assigned {
+		return nil
+	}
+
+	record.reviewers[userID] = now()
+	r.s.addHistory(domain.AssignmentEvent{
+		PullRequestID: prID,
+		Action:        domain.AssignmentAssigned,
+		UserID:        userID,
+		Reason:        reason,
+	})
+	return nil
+}
+
+func (r *PullRequestRepo) UnassignReviewer(ctx context.Context, prID, userID, reason string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	record, ok := r.s.pullRequests[prID]
+	if !ok {
+		return nil
+	}
+	if err := bumpVersion(ctx, &record.pr.Version); err != nil {
+		return err
+	}
+	if _, ok := record.reviewers[userID]; !ok {
+		return nil
+	}
+
+	delete(record.reviewers, userID)
+	r.s.addHistory(domain.AssignmentEvent{
+		PullRequestID: prID,
+		Action:        domain.AssignmentUnassigned,
+		UserID:        userID,
+		Reason:        reason,
+	})
+	return nil
+}
+
+// ReplaceReviewer swaps reviewers atomically, failing with ErrNotAssigned if
+// the old reviewer is not assigned.
+func (r *PullRequestRepo) ReplaceReviewer(ctx context.Context, prID, oldUserID, newUserID, reason string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	record, err := r.s.swapTarget(prID, oldUserID, newUserID)
+	if err != nil {
+		return err
+	}
+	if err := bumpVersion(ctx, &record.pr.Version); err != nil {
+		return err
+	}
+
+	delete(record.reviewers, oldUserID)
+	record.reviewers[newUserID] = now()
+	r.s.moveTags(record, oldUserID, newUserID)
+	r.s.addHistory(domain.AssignmentEvent{
+		PullRequestID:  prID,
+		Action:         domain.AssignmentReassigned,
+		UserID:         newUserID,
+		PreviousUserID: oldUserID,
+		Reason:         reason,
+	})
+	return nil
+}
+
+// DeclineReviewer removes a reviewer, remembers the decline and, when
+// newUserID is set, assigns the replacement in the same step.
+func (r *PullRequestRepo) DeclineReviewer(ctx context.Context, prID, userID, newUserID, reason string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	record, err := r.s.swapTarget(prID, userID, newUserID)
+	if err != nil {
+		return err
+	}
+	if err := bumpVersion(ctx, &record.pr.Version); err != nil {
+		return err
+	}
+
+	delete(record.reviewers, userID)
+	record.declines[userID] = declineRecord{reason: reason, declinedAt: now()}
+	r.s.moveTags(record, userID, newUserID)
+	r.s.addHistory(domain.AssignmentEvent{
+		PullRequestID: prID,
+		Action:        domain.AssignmentDeclined,
+		UserID:        userID,
+		Reason:        reason,
+	})
+
+	if newUserID == "" {
+		return nil
+	}
+
+	record.reviewers[newUserID] = now()
+	r.s.addHistory(domain.AssignmentEvent{
+		PullRequestID:  prID,
+		Action:         domain.AssignmentReassigned,
+		UserID:         newUserID,
+		PreviousUserID: userID,
+		Reason:         reason,
+	})
+	return nil
+}
+
+func (r *PullRequestRepo) GetDeclinedReviewers(ctx context.Context, prID string) ([]string, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	record, ok := r.s.pullRequests[prID]
+	if !ok {
+		return nil, nil
+	}
+
+	var userIDs []string
+	for userID := range record.declines {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Strings(userIDs)
+	return userIDs, nil
+}
+
+func (r *PullRequestRepo) GetAssignmentHistory(ctx context.Context, prID string) ([]domain.AssignmentEvent, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	events := []domain.AssignmentEvent{}
+	for _, event := range r.s.history {
+		if event.PullRequestID == prID {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (r *PullRequestRepo) IsReviewerAssigned(ctx context.Context, prID, userID string) (bool, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	record, ok := r.s.pullRequests[prID]
+	if !ok {
+		return false, nil
+	}
+	_, assigned := record.reviewers[userID]
+	return assigned, nil
+}
+
+// ListPRs returns up to limit pull requests matching filter, newest first,
+// starting after the given cursor. The returned cursor is nil on the last
+// page.
+func (r *PullRequestRepo) ListPRs(ctx context.Context, filter domain.PRFilter, after *domain.PRCursor, limit int) ([]domain.PullRequestShort, *domain.PRCursor, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	prs := []domain.PullRequestShort{}
+	var next *domain.PRCursor
+	for _, record := range r.s.filterPRs(filter) {
+		if after != nil && !beforeCursor(record, after) {
+			continue
+		}
+		if len(prs) == limit {
+			last := prs[len(prs)-1]
+			next = &domain.PRCursor{CreatedAt: r.s.pullRequests[last.PullRequestID].createdAt, PullRequestID: last.PullRequestID}
+			break
+		}
+		prs = append(prs, domain.PullRequestShort{
+			PullRequestID:   record.pr.PullRequestID,
+			PullRequestName: record.pr.PullRequestName,
+			AuthorID:        record.pr.AuthorID,
+			Status:          record.pr.Status,
+		})
+	}
+	return prs, next, nil
+}
+
+// StreamPRs snapshots the matching PRs and calls fn outside the lock, so a
+// slow consumer does not block writers.
+func (r *PullRequestRepo) StreamPRs(ctx context.Context, filter domain.PRFilter, fn func(domain.PullRequestDetails) error) error {
+	r.s.mu.RLock()
+	var details []domain.PullRequestDetails
+	for _, record := range r.s.filterPRs(filter) {
+		details = append(details, r.s.details(record, byAssignedAt))
+	}
+	r.s.mu.RUnlock()
+
+	for _, pr := range details {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(pr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *PullRequestRepo) StreamAssignmentHistory(ctx context.Context, filter domain.PRFilter, fn func(domain.AssignmentEvent) error) error {
+	r.s.mu.RLock()
+	matching := make(map[string]bool)
+	for _, record := range r.s.filterPRs(filter) {
+		matching[record.pr.PullRequestID] = true
+	}
+	var events []domain.AssignmentEvent
+	for _, event := range r.s.history {
+		if matching[event.PullRequestID] {
+			events = append(events, event)
+		}
+	}
+	r.s.mu.RUnlock()
+
+	for _, event := range events {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *prRecord) pullRequest() domain.PullRequest {
+	pr := r.pr
+	pr.CreatedAt = timePtr(r.createdAt)
+	if r.mergedAt != nil {
+		pr.MergedAt = timePtr(*r.mergedAt)
+	}
+	pr.AssignedReviewers = []string{}
+	for _, assignment := range r.assignments(byUserID) {
+		pr.AssignedReviewers = append(pr.AssignedReviewers, assignment.UserID)
+	}
+	return pr
+}
+
+const (
+	byUserID = iota
+	byAssignedAt
+)
+
+func (r *prRecord) assignments(order int) []domain.ReviewerAssignment {
+	assignments := []domain.ReviewerAssignment{}
+	for userID, assignedAt := range r.reviewers {
+		assignments = append(assignments, domain.ReviewerAssignment{UserID: userID, AssignedAt: assignedAt})
+	}
+	sort.Slice(assignments, func(i, j int) bool {
+		a, b := assignments[i], assignments[j]
+		if order == byAssignedAt && !a.AssignedAt.Equal(b.AssignedAt) {
+			return a.AssignedAt.Before(b.AssignedAt)
+		}
+		return a.UserID < b.UserID
+	})
+	return assignments
+}
+
+// details builds the full view of a PR. The caller must hold the lock.
+func (s *store) details(record *prRecord, order int) domain.PullRequestDetails {
+	d := domain.PullRequestDetails{
+		PullRequest: record.pullRequest(),
+		Reviewers:   record.assignments(order),
+	}
+	d.AssignedReviewers = []string{}
+	for _, assignment := range d.Reviewers {
+		d.AssignedReviewers = append(d.AssignedReviewers, assignment.UserID)
+	}
+	if author, ok := s.users[record.pr.AuthorID]; ok {
+		d.AuthorTeam = author.user.TeamName
+	}
+	d.SetTagMatches(record.tags)
+	return d
+}
+
+// filterPRs returns the PRs matching filter ordered by created_at and
+// pull_request_id, both descending. The caller must hold the lock.
+func (s *store) filterPRs(filter domain.PRFilter) []*prRecord {
+	var records []*prRecord
+	for _, record := range s.pullRequests {
+		if s.matches(record, filter) {
+			records = append(records, record)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return beforeCursor(records[j], &domain.PRCursor{CreatedAt: records[i].createdAt, PullRequestID: records[i].pr.PullRequestID})
+	})
+	return records
+}
+
+func (s *store) matches(record *prRecord, filter domain.PRFilter) bool {
+	pr := record.pr
+	if filter.Status != "" && pr.Status != filter.Status {
+		return false
+	}
+	if filter.AuthorID != "" && pr.AuthorID != filter.AuthorID {
+		return false
+	}
+	if filter.ReviewerID != "" {
+		if _, ok := record.reviewers[filter.ReviewerID]; !ok {
+			return false
+		}
+	}
+	if filter.TeamName != "" {
+		author, ok := s.users[pr.AuthorID]
+		if !ok || author.user.TeamName != filter.TeamName {
+			return false
+		}
+	}
+	if filter.NameContains != "" && !strings.Contains(strings.ToLower(pr.PullRequestName), strings.ToLower(filter.NameContains)) {
+		return false
+	}
+	if !inWindow(record.createdAt, filter.CreatedFrom, filter.CreatedTo) {
+		return false
+	}
+	if filter.MergedFrom != nil || filter.MergedTo != nil {
+		if record.mergedAt == nil || !inWindow(*record.mergedAt, filter.MergedFrom, filter.MergedTo) {
+			return false
+		}
+	}
+	return true
+}
+
+// beforeCursor reports whether the PR sorts after the cursor in listing
+// order, i.e. (created_at, pull_request_id) < cursor.
+func beforeCursor(record *prRecord, cursor *domain.PRCursor) bool {
+	if !record.createdAt.Equal(cursor.CreatedAt) {
+		return record.createdAt.Before(cursor.CreatedAt)
+	}
+	return record.pr.PullRequestID < cursor.PullRequestID
+}
+
+// reviewTarget checks the foreign keys of a new reviewer row. The caller must
+// hold the lock.
+func (s *store) reviewTarget(prID, userID string) (*prRecord, error) {
+	record, ok := s.pullRequests[prID]
+	if !ok {
+		return nil, domain.ErrPRNotFound
+	}
+	if _, ok := s.users[userID]; !ok {
+		return nil, domain.ErrUserNotFound
+	}
+	return record, nil
+}
+
+// swapTarget validates removing oldUserID and, when set, adding newUserID
+// before anything is changed, so a failed swap leaves no partial state.
+func (s *store) swapTarget(prID, oldUserID, newUserID string) (*prRecord, error) {
+	record, ok := s.pullRequests[prID]
+	if !ok {
+		return nil, domain.ErrNotAssigned
+	}
+	if _, ok := record.reviewers[oldUserID]; !ok {
+		return nil, domain.ErrNotAssigned
+	}
+	if newUserID == "" {
+		return record, nil
+	}
+	if _, err := s.reviewTarget(prID, newUserID); err != nil {
+		return nil, err
+	}
+	if _, ok := record.reviewers[newUserID]; ok && newUserID != oldUserID {
+		return nil, domain.ErrAlreadyAssigned
+	}
+	return record, nil
+}
+
+// moveTags hands the tags oldUserID covered to newUserID where they have the
+// skill and leaves the rest uncovered. The caller must hold the write lock.
+func (s *store) moveTags(record *prRecord, oldUserID, newUserID string) {
+	var replacement domain.User
+	if user, ok := s.users[newUserID]; ok {
+		replacement = user.user
+	}
+	for i := range record.tags {
+		if record.tags[i].ReviewerID != oldUserID {
+			continue
+		}
+		record.tags[i].ReviewerID = ""
+		if replacement.HasSkill(record.tags[i].Tag) {
+			record.tags[i].ReviewerID = newUserID
+		}
+	}
+}
+
+// addHistory appends an event stamped with the current time. The caller must
+// hold the write lock.
+func (s *store) addHistory(event domain.AssignmentEvent) {
+	event.CreatedAt = timePtr(now())
+	s.history = append(s.history, event)
+}
@@ -0,0 +1,192 @@
+package memory
+
+import (
+	"context"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"math"
+	"sort"
+	"time"
+)
+
+type StatisticsRepo struct {
+	s *store
+}
+
+func (r *StatisticsRepo) GetUserStatistics(ctx context.Context, filter domain.StatisticsFilter) ([]domain.UserStatistics, error) {
+	result := []domain.UserStatistics{}
+	r.statistics(filter, func(user domain.User) string { return user.UserID }, func(key, teamName string, stats domain.ReviewStatistics) {
+		result = append(result, domain.UserStatistics{UserID: key, TeamName: teamName, ReviewStatistics: stats})
+	})
+	return result, nil
+}
+
+func (r *StatisticsRepo) GetTeamStatistics(ctx context.Context, filter domain.StatisticsFilter) ([]domain.TeamStatistics, error) {
+	result := []domain.TeamStatistics{}
+	r.statistics(filter, func(user domain.User) string { return user.TeamName }, func(key, teamName string, stats domain.ReviewStatistics) {
+		result = append(result, domain.TeamStatistics{TeamName: teamName, ReviewStatistics: stats})
+	})
+	return result, nil
+}
+
+// statistics aggregates review metrics per key for the users selected by the
+// filter, windowing each metric by its own event time like the SQL version.
+func (r *StatisticsRepo) statistics(filter domain.StatisticsFilter, keyOf func(domain.User) string, add func(key, teamName string, stats domain.ReviewStatistics)) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	from, to := filter.From, filter.To
+	keys := make(map[string]string)
+	teams := make(map[string]string)
+	for userID, record := range r.s.users {
+		if filter.TeamName == "" || record.user.TeamName == filter.TeamName {
+			keys[userID] = keyOf(record.user)
+			teams[keys[userID]] = record.user.TeamName
+		}
+	}
+
+	stats := make(map[string]*domain.ReviewStatistics)
+	latencies := make(map[string][]float64)
+	for key := range teams {
+		stats[key] = &domain.ReviewStatistics{}
+	}
+
+	for _, pr := range r.s.pullRequests {
+		if key, ok := keys[pr.pr.AuthorID]; ok {
+			if inWindow(pr.createdAt, from, to) {
+				if pr.pr.Status == domain.PRStatusOpen {
+					stats[key].OpenPRs++
+				} else {
+					stats[key].MergedPRs++
+				}
+			}
+			if pr.mergedAt != nil && len(pr.reviewers) == 0 && inWindow(*pr.mergedAt, from, to) {
+				stats[key].MergedWithoutReviewers++
+			}
+		}
+
+		for userID, assignedAt := range pr.reviewers {
+			key, ok := keys[userID]
+			if !ok {
+				continue
+			}
+			if inWindow(assignedAt, from, to) {
+				stats[key].Assignments++
+			}
+			if pr.mergedAt != nil && inWindow(*pr.mergedAt, from, to) {
+				latencies[key] = append(latencies[key], pr.mergedAt.Sub(assignedAt).Seconds())
+			}
+		}
+
+		for userID, decline := range pr.declines {
+			if key, ok := keys[userID]; ok && inWindow(decline.declinedAt, from, to) {
+				stats[key].Declines++
+			}
+		}
+	}
+
+	for _, event := range r.s.history {
+		if event.Action != domain.AssignmentReassigned || r.s.declinedBefore(event) {
+			continue
+		}
+		if key, ok := keys[event.PreviousUserID]; ok && inWindow(*event.CreatedAt, from, to) {
+			stats[key].ReassignmentsAway++
+		}
+	}
+
+	for key, values := range latencies {
+		avg, p90 := mean(values), percentile(values, 0.9)
+		stats[key].AvgTimeToMergeSeconds = &avg
+		stats[key].P90TimeToMergeSeconds = &p90
+	}
+
+	sorted := make([]string, 0, len(stats))
+	for key := range stats {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+	for _, key := range sorted {
+		add(key, teams[key], *stats[key])
+	}
+}
+
+// GetMemberLoad returns every current member of the team with the seconds
+// they spent active inside [from, to), reconstructed from the activity log,
+// and the reviews assigned to them in that window.
+func (r *StatisticsRepo) GetMemberLoad(ctx context.Context, teamName string, from, to time.Time) ([]domain.MemberLoad, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	from, to = from.UTC(), to.UTC()
+
+	var loads []domain.MemberLoad
+	for _, user := range r.s.teamUsers(teamName) {
+		load := domain.MemberLoad{UserID: user.UserID, IsActive: user.IsActive}
+
+		var periods []activityRecord
+		for _, change := range r.s.activity {
+			if change.userID == user.UserID {
+				periods = append(periods, change)
+			}
+		}
+		// The log is appended in order, so a stable sort keeps ties by id.
+		sort.SliceStable(periods, func(i, j int) bool {
+			return periods[i].changedAt.Before(periods[j].changedAt)
+		})
+		for i, period := range periods {
+			if !period.isActive {
+				continue
+			}
+			start, end := period.changedAt, to
+			if i+1 < len(periods) && periods[i+1].changedAt.Before(to) {
+				end = periods[i+1].changedAt
+			}
+			if start.Before(from) {
+				start = from
+			}
+			if end.After(start) {
+				load.ActiveSeconds += end.Sub(start).Seconds()
+			}
+		}
+
+		for _, pr := range r.s.pullRequests {
+			if assignedAt, ok := pr.reviewers[user.UserID]; ok && inWindow(assignedAt, &from, &to) {
+				load.Assignments++
+			}
+		}
+
+		loads = append(loads, load)
+	}
+	return loads, nil
+}
+
+func mean(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// percentile interpolates linearly between the closest ranks, matching
+// PERCENTILE_CONT.
+func percentile(values []float64, p float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	rank := p * float64(len(sorted)-1)
+	lower := math.Floor(rank)
+	upper := math.Ceil(rank)
+	return sorted[int(lower)] + (rank-lower)*(sorted[int(upper)]-sorted[int(lower)])
+}
+
+// declinedBefore reports whether a reassignment event replaced a reviewer who
+// had declined the pull request. Those are counted as declines, not as
+// reassignments away.
+func (s *store) declinedBefore(event domain.AssignmentEvent) bool {
+	record, ok := s.pullRequests[event.PullRequestID]
+	if !ok {
+		return false
+	}
+	decline, ok := record.declines[event.PreviousUserID]
+	return ok && !event.CreatedAt.Before(decline.declinedAt)
+}
@@ -0,0 +1,40 @@
+package memory
+
+import (
+	"context"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+)
+
+type SyncRunRepo struct {
+	s *store
+}
+
+func (r *SyncRunRepo) CreateRun(ctx context.Context, run *domain.SyncRun) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	run.ID = int64(len(r.s.syncRuns)) + 1
+	r.s.syncRuns = append(r.s.syncRuns, *run)
+	return nil
+}
+
+func (r *SyncRunRepo) ListRuns(ctx context.Context, limit int) ([]domain.SyncRun, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	runs := []domain.SyncRun{}
+	for i := len(r.s.syncRuns) - 1; i >= 0 && len(runs) < limit; i-- {
+		runs = append(runs, r.s.syncRuns[i])
+	}
+	return runs, nil
+}
+
+// WithLock takes a mutex: the store lives in one process, so that is as far
+// as the lock needs to reach.
+func (r *SyncRunRepo) WithLock(ctx context.Context, fn func() error) (bool, error) {
+	if !r.s.syncLock.TryLock() {
+		return false, nil
+	}
+	defer r.s.syncLock.Unlock()
+	return true, fn()
+}
@@ -0,0 +1,113 @@
+package memory
+
+import (
+	"context"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"sort"
+)
+
+type TeamRepo struct {
+	s *store
+}
+
+func (r *TeamRepo) CreateTeam(ctx context.Context, teamName string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.teams[teamName]; ok {
+		return domain.ErrTeamExists
+	}
+	r.s.teams[teamName] = now()
+	return nil
+}
+
+// ImportTeams plans and applies the import under one lock. Every user's team
+// must exist or be created by the plan, which is checked before anything
+// changes.
+func (r *TeamRepo) ImportTeams(ctx context.Context, teams []domain.Team, plan func(domain.ImportSnapshot) (*domain.ImportPlan, error)) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	teamNames, userIDs := domain.ImportScope(teams)
+	current := domain.ImportSnapshot{Teams: make(map[string]bool), Users: make(map[string]domain.User)}
+	for _, teamName := range teamNames {
+		if _, ok := r.s.teams[teamName]; ok {
+			current.Teams[teamName] = true
+		}
+		for _, user := range r.s.teamUsers(teamName) {
+			current.Users[user.UserID] = user
+		}
+	}
+	for _, userID := range userIDs {
+		if record, ok := r.s.users[userID]; ok {
+			current.Users[userID] = record.user
+		}
+	}
+
+	changes, err := plan(current)
+	if err != nil || changes == nil {
+		return err
+	}
+
+	created := make(map[string]bool, len(changes.Teams))
+	for _, teamName := range changes.Teams {
+		created[teamName] = true
+	}
+	for _, user := range changes.Users {
+		if _, ok := r.s.teams[user.TeamName]; !ok && !created[user.TeamName] {
+			return domain.ErrTeamNotFound
+		}
+	}
+
+	for _, teamName := range changes.Teams {
+		if _, ok := r.s.teams[teamName]; !ok {
+			r.s.teams[teamName] = now()
+		}
+	}
+	for _, user := range changes.Users {
+		r.s.upsertUser(user)
+	}
+	return nil
+}
+
+func (r *TeamRepo) TeamExists(ctx context.Context, teamName string) (bool, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	_, ok := r.s.teams[teamName]
+	return ok, nil
+}
+
+func (r *TeamRepo) GetTeam(ctx context.Context, teamName string) (*domain.Team, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	if _, ok := r.s.teams[teamName]; !ok {
+		return nil, domain.ErrTeamNotFound
+	}
+
+	members := []domain.TeamMember{}
+	for _, user := range r.s.teamUsers(teamName) {
+		members = append(members, domain.TeamMember{UserID: user.UserID, Username: user.Username, IsActive: user.IsActive})
+	}
+
+	return &domain.Team{
+		TeamName: teamName,
+		Members:  members,
+	}, nil
+}
+
+// teamUsers returns the members of a team ordered by user_id. The caller
+// must hold the lock.
+func (s *store) teamUsers(teamName string) []domain.User {
+	var users []domain.User
+	for _, record := range s.users {
+		if record.user.TeamName == teamName {
+			users = append(users, record.user)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].UserID < users[j].UserID
+	})
+	return users
+}
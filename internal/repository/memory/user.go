@@ -0,0 +1,140 @@
+package memory
+
+import (
+	"context"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"sort"
+)
+
+type UserRepo struct {
+	s *store
+}
+
+func (r *UserRepo) CreateOrUpdateUser(ctx context.Context, user *domain.User) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.teams[user.TeamName]; !ok {
+		return domain.ErrTeamNotFound
+	}
+
+	r.s.upsertUser(*user)
+	return nil
+}
+
+// upsertUser stores the user and logs an activity transition when the user
+// is new or is_active changes. The caller must hold the write lock.
+func (s *store) upsertUser(user domain.User) {
+	record, ok := s.users[user.UserID]
+	changed := !ok || record.user.IsActive != user.IsActive
+	if !ok {
+		record = &userRecord{createdAt: now()}
+		s.users[user.UserID] = record
+	}
+	// Skills are only written by SetSkills, as with the user_skills table.
+	user.Skills = record.user.Skills
+	user.Version = record.user.Version + 1
+	record.user = user
+
+	if changed {
+		s.logActivity(user.UserID, user.IsActive)
+	}
+}
+
+func (r *UserRepo) GetUser(ctx context.Context, userID string) (*domain.User, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	record, ok := r.s.users[userID]
+	if !ok {
+		return nil, domain.ErrUserNotFound
+	}
+	user := record.user
+	return &user, nil
+}
+
+func (r *UserRepo) GetUsersByTeam(ctx context.Context, teamName string) ([]domain.User, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	return r.s.teamUsers(teamName), nil
+}
+
+func (r *UserRepo) SetIsActive(ctx context.Context, userID string, isActive bool) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	record, ok := r.s.users[userID]
+	if !ok {
+		return domain.ErrUserNotFound
+	}
+	if err := bumpVersion(ctx, &record.user.Version); err != nil {
+		return err
+	}
+
+	if record.user.IsActive != isActive {
+		record.user.IsActive = isActive
+		r.s.logActivity(userID, isActive)
+	}
+	return nil
+}
+
+// SetSkills replaces the user's skill set.
+func (r *UserRepo) SetSkills(ctx context.Context, userID string, skills []string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	record, ok := r.s.users[userID]
+	if !ok {
+		return domain.ErrUserNotFound
+	}
+	if err := bumpVersion(ctx, &record.user.Version); err != nil {
+		return err
+	}
+
+	record.user.Skills = nil
+	if len(skills) > 0 {
+		record.user.Skills = append([]string(nil), skills...)
+		sort.Strings(record.user.Skills)
+	}
+	return nil
+}
+
+func (r *UserRepo) GetActiveTeamMembers(ctx context.Context, teamName string, excludeUserID string) ([]domain.User, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var users []domain.User
+	for _, user := range r.s.teamUsers(teamName) {
+		if user.IsActive && user.UserID != excludeUserID {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+// logActivity records an is_active transition for the fairness report. The
+// caller must hold the write lock.
+func (s *store) logActivity(userID string, isActive bool) {
+	s.activity = append(s.activity, activityRecord{userID: userID, isActive: isActive, changedAt: now()})
+}
+
+// bumpVersion increments a user or PR version, as the version column does in
+// the SQL backends. When ctx carries an expected version the record must
+// still be at it, otherwise it fails with ErrPreconditionFailed. The caller
+// must hold the write lock.
+func bumpVersion(ctx context.Context, version *int64) error {
+	if err := checkVersion(ctx, *version); err != nil {
+		return err
+	}
+	*version++
+	return nil
+}
+
+// checkVersion is the precondition half of bumpVersion.
+func checkVersion(ctx context.Context, version int64) error {
+	if expected, ok := domain.ExpectedVersion(ctx); ok && expected != version {
+		return domain.ErrPreconditionFailed
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+)
+
+type ExclusionRepo struct {
+	db *sql.DB
+}
+
+func NewExclusionRepo(db *sql.DB) *ExclusionRepo {
+	return &ExclusionRepo{db: db}
+}
+
+func (r *ExclusionRepo) CreateRule(ctx context.Context, rule *domain.ExclusionRule) error {
+	query := `
+		INSERT INTO exclusion_rules (kind, user_id, other_user_id, reason)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT DO NOTHING
+		RETURNING rule_id, created_at
+	`
+	err := r.db.QueryRowContext(ctx, query, rule.Kind, rule.UserID, rule.OtherUserID, rule.Reason).Scan(&rule.RuleID, &rule.CreatedAt)
+	if err == sql.ErrNoRows {
+		return domain.ErrRuleExists
+	}
+	return err
+}
+
+func (r *ExclusionRepo) DeleteRule(ctx context.Context, ruleID int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM exclusion_rules WHERE rule_id = $1`, ruleID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrRuleNotFound
+	}
+	return nil
+}
+
+func (r *ExclusionRepo) ListRules(ctx context.Context, userID string) ([]domain.ExclusionRule, error) {
+	query := `
+		SELECT rule_id, kind, user_id, other_user_id, reason, created_at
+		FROM exclusion_rules
+		WHERE $1 = '' OR user_id = $1 OR other_user_id = $1
+		ORDER BY rule_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := []domain.ExclusionRule{}
+	for rows.Next() {
+		var rule domain.ExclusionRule
+		if err := rows.Scan(&rule.RuleID, &rule.Kind, &rule.UserID, &rule.OtherUserID, &rule.Reason, &rule.CreatedAt); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, rows.Err()
+}
@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/lib/pq"
+	"strings"
+	"time"
+)
+
+// StreamPRs reads one row per (PR, reviewer) pair ordered by PR and folds
+// consecutive rows together, so only the current PR is held in memory. The
+// PR's required tags ride along on every row as two parallel arrays.
+func (r *PullRequestRepo) StreamPRs(ctx context.Context, filter domain.PRFilter, fn func(domain.PullRequestDetails) error) error {
+	var args queryArgs
+	conditions := prFilterConditions(filter, &args)
+
+	query := `
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.created_at, pr.merged_at,
+		       u.team_name, prr.user_id, prr.assigned_at,
+		       ARRAY(SELECT t.tag FROM pr_required_tags t WHERE t.pull_request_id = pr.pull_request_id ORDER BY t.tag),
+		       ARRAY(SELECT COALESCE(t.reviewer_id, '') FROM pr_required_tags t WHERE t.pull_request_id = pr.pull_request_id ORDER BY t.tag)
+		FROM pull_requests pr
+		INNER JOIN users u ON u.user_id = pr.author_id
+		LEFT JOIN pr_reviewers prr ON prr.pull_request_id = pr.pull_request_id
+	`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY pr.created_at DESC, pr.pull_request_id DESC, prr.assigned_at, prr.user_id"
+
+	rows, err := r.db.QueryContext(ctx, query, args.values...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var current *domain.PullRequestDetails
+	var currentTags []domain.TagMatch
+	emit := func() error {
+		current.SetTagMatches(currentTags)
+		return fn(*current)
+	}
+
+	for rows.Next() {
+		var pr domain.PullRequestDetails
+		var createdAt time.Time
+		var mergedAt, assignedAt sql.NullTime
+		var reviewerID sql.NullString
+		var tags, tagReviewers []string
+		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &createdAt, &mergedAt,
+			&pr.AuthorTeam, &reviewerID, &assignedAt, pq.Array(&tags), pq.Array(&tagReviewers)); err != nil {
+			return err
+		}
+
+		if current == nil || current.PullRequestID != pr.PullRequestID {
+			if current != nil {
+				if err := emit(); err != nil {
+					return err
+				}
+			}
+			pr.CreatedAt = &createdAt
+			if mergedAt.Valid {
+				pr.MergedAt = &mergedAt.Time
+			}
+			pr.AssignedReviewers = []string{}
+			pr.Reviewers = []domain.ReviewerAssignment{}
+			current = &pr
+			currentTags = nil
+			for i, tag := range tags {
+				currentTags = append(currentTags, domain.TagMatch{Tag: tag, ReviewerID: tagReviewers[i]})
+			}
+		}
+
+		if reviewerID.Valid {
+			current.AssignedReviewers = append(current.AssignedReviewers, reviewerID.String)
+			current.Reviewers = append(current.Reviewers, domain.ReviewerAssignment{UserID: reviewerID.String, AssignedAt: assignedAt.Time})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if current != nil {
+		return emit()
+	}
+	return nil
+}
+
+func (r *PullRequestRepo) StreamAssignmentHistory(ctx context.Context, filter domain.PRFilter, fn func(domain.AssignmentEvent) error) error {
+	var args queryArgs
+	conditions := prFilterConditions(filter, &args)
+
+	query := `
+		SELECT h.pull_request_id, h.action, h.user_id, COALESCE(h.previous_user_id, ''), h.reason, h.created_at
+		FROM pr_reviewer_history h
+		INNER JOIN pull_requests pr ON pr.pull_request_id = h.pull_request_id
+		INNER JOIN users u ON u.user_id = pr.author_id
+	`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY h.id"
+
+	rows, err := r.db.QueryContext(ctx, query, args.values...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var event domain.AssignmentEvent
+		var createdAt time.Time
+		if err := rows.Scan(&event.PullRequestID, &event.Action, &event.UserID, &event.PreviousUserID, &event.Reason, &createdAt); err != nil {
+			return err
+		}
+		event.CreatedAt = &createdAt
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
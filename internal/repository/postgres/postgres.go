@@ -6,69 +6,74 @@ import (
 	"fmt"
 	"github.com/avito-test/pr-reviewer-service/internal/repository"
 	_ "github.com/lib/pq"
-	"os"
-	"path/filepath"
 	"time"
 )
 
+// Config describes the connection. DSN, when set, is used as is instead of
+// the individual fields; zero pool settings fall back to the defaults.
 type Config struct {
+	DSN      string
 	Host     string
 	Port     string
 	User     string
 	Password string
 	DBName   string
 	SSLMode  string
+
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnectTimeout  time.Duration
 }
 
 func NewPostgresDB(cfg Config) (*sql.DB, error) {
-	dsn := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.DBName, cfg.SSLMode,
-	)
+	dsn := cfg.DSN
+	if dsn == "" {
+		dsn = fmt.Sprintf(
+			"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+			cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.DBName, cfg.SSLMode,
+		)
+	}
 
 	db, err := sql.Open("postgres", dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	if cfg.ConnectTimeout == 0 {
+		cfg.ConnectTimeout = 10 * time.Second
+	}
+	if cfg.MaxOpenConns == 0 {
+		cfg.MaxOpenConns = 25
+	}
+	if cfg.MaxIdleConns == 0 {
+		cfg.MaxIdleConns = 5
+	}
+	if cfg.ConnMaxLifetime == 0 {
+		cfg.ConnMaxLifetime = 5 * time.Minute
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ConnectTimeout)
 	defer cancel()
 
 	if err := db.PingContext(ctx); err != nil {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(5)
-	db.SetConnMaxLifetime(5 * time.Minute)
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
 
 	return db, nil
 }
 
-func RunMigrations(db *sql.DB, migrationsPath string) error {
-	files, err := filepath.Glob(filepath.Join(migrationsPath, "*.sql"))
-	if err != nil {
-		return fmt.Errorf("failed to read migrations: %w", err)
-	}
-
-	for _, file := range files {
-		content, err := os.ReadFile(file)
-		if err != nil {
-			return fmt.Errorf("failed to read migration file %s: %w", file, err)
-		}
-
-		if _, err := db.Exec(string(content)); err != nil {
-			return fmt.Errorf("failed to execute migration %s: %w", file, err)
-		}
-	}
-
-	return nil
-}
-
 func NewRepository(db *sql.DB) *repository.Repository {
-	return &repository.Repository{
+	return repository.WithTracing(&repository.Repository{
 		Team:        NewTeamRepo(db),
 		User:        NewUserRepo(db),
 		PullRequest: NewPullRequestRepo(db),
-	}
+		Statistics:  NewStatisticsRepo(db),
+		Exclusion:   NewExclusionRepo(db),
+		SyncRun:     NewSyncRunRepo(db),
+	}, "postgresql")
 }
@@ -0,0 +1,209 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"time"
+)
+
+type StatisticsRepo struct {
+	db *sql.DB
+}
+
+func NewStatisticsRepo(db *sql.DB) *StatisticsRepo {
+	return &StatisticsRepo{db: db}
+}
+
+// statisticsQuery aggregates review metrics for the members selected by $1
+// (team name or empty), grouped by the given users column. $2 and $3 are the
+// optional window bounds.
+const statisticsQuery = `
+	WITH members AS (
+		SELECT user_id, team_name, %[1]s AS key
+		FROM users
+		WHERE $1 = '' OR team_name = $1
+	),
+	authored AS (
+		SELECT m.key,
+			COUNT(*) FILTER (WHERE pr.status = 'OPEN') AS open_prs,
+			COUNT(*) FILTER (WHERE pr.status = 'MERGED') AS merged_prs
+		FROM pull_requests pr
+		INNER JOIN members m ON m.user_id = pr.author_id
+		WHERE ($2::timestamp IS NULL OR pr.created_at >= $2) AND ($3::timestamp IS NULL OR pr.created_at < $3)
+		GROUP BY m.key
+	),
+	unreviewed AS (
+		SELECT m.key, COUNT(*) AS merged_without_reviewers
+		FROM pull_requests pr
+		INNER JOIN members m ON m.user_id = pr.author_id
+		WHERE pr.status = 'MERGED'
+			AND NOT EXISTS (SELECT 1 FROM pr_reviewers prr WHERE prr.pull_request_id = pr.pull_request_id)
+			AND ($2::timestamp IS NULL OR pr.merged_at >= $2) AND ($3::timestamp IS NULL OR pr.merged_at < $3)
+		GROUP BY m.key
+	),
+	assigned AS (
+		SELECT m.key, COUNT(*) AS assignments
+		FROM pr_reviewers prr
+		INNER JOIN members m ON m.user_id = prr.user_id
+		WHERE ($2::timestamp IS NULL OR prr.assigned_at >= $2) AND ($3::timestamp IS NULL OR prr.assigned_at < $3)
+		GROUP BY m.key
+	),
+	reassigned AS (
+		SELECT m.key, COUNT(*) AS reassignments_away
+		FROM pr_reviewer_history h
+		INNER JOIN members m ON m.user_id = h.previous_user_id
+		WHERE h.action = 'REASSIGNED'
+			AND ($2::timestamp IS NULL OR h.created_at >= $2) AND ($3::timestamp IS NULL OR h.created_at < $3)
+			AND NOT EXISTS (
+				SELECT 1 FROM pr_reviewer_declines d
+				WHERE d.pull_request_id = h.pull_request_id AND d.user_id = h.previous_user_id
+					AND d.declined_at <= h.created_at
+			)
+		GROUP BY m.key
+	),
+	declined AS (
+		SELECT m.key, COUNT(*) AS declines
+		FROM pr_reviewer_declines d
+		INNER JOIN members m ON m.user_id = d.user_id
+		WHERE ($2::timestamp IS NULL OR d.declined_at >= $2) AND ($3::timestamp IS NULL OR d.declined_at < $3)
+		GROUP BY m.key
+	),
+	latency AS (
+		SELECT m.key,
+			AVG(EXTRACT(EPOCH FROM pr.merged_at - prr.assigned_at)) AS avg_seconds,
+			PERCENTILE_CONT(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM pr.merged_at - prr.assigned_at)) AS p90_seconds
+		FROM pr_reviewers prr
+		INNER JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+		INNER JOIN members m ON m.user_id = prr.user_id
+		WHERE pr.status = 'MERGED'
+			AND ($2::timestamp IS NULL OR pr.merged_at >= $2) AND ($3::timestamp IS NULL OR pr.merged_at < $3)
+		GROUP BY m.key
+	)
+	SELECT k.key, k.team_name,
+		COALESCE(a.open_prs, 0), COALESCE(a.merged_prs, 0), COALESCE(u.merged_without_reviewers, 0),
+		COALESCE(s.assignments, 0), COALESCE(r.reassignments_away, 0), COALESCE(d.declines, 0),
+		l.avg_seconds, l.p90_seconds
+	FROM (SELECT DISTINCT key, team_name FROM members) k
+	LEFT JOIN authored a ON a.key = k.key
+	LEFT JOIN unreviewed u ON u.key = k.key
+	LEFT JOIN assigned s ON s.key = k.key
+	LEFT JOIN reassigned r ON r.key = k.key
+	LEFT JOIN declined d ON d.key = k.key
+	LEFT JOIN latency l ON l.key = k.key
+	ORDER BY k.key
+`
+
+func (r *StatisticsRepo) GetUserStatistics(ctx context.Context, filter domain.StatisticsFilter) ([]domain.UserStatistics, error) {
+	result := []domain.UserStatistics{}
+	err := r.queryStatistics(ctx, "user_id", filter, func(key, teamName string, stats domain.ReviewStatistics) {
+		result = append(result, domain.UserStatistics{UserID: key, TeamName: teamName, ReviewStatistics: stats})
+	})
+	return result, err
+}
+
+func (r *StatisticsRepo) GetTeamStatistics(ctx context.Context, filter domain.StatisticsFilter) ([]domain.TeamStatistics, error) {
+	result := []domain.TeamStatistics{}
+	err := r.queryStatistics(ctx, "team_name", filter, func(key, teamName string, stats domain.ReviewStatistics) {
+		result = append(result, domain.TeamStatistics{TeamName: teamName, ReviewStatistics: stats})
+	})
+	return result, err
+}
+
+func (r *StatisticsRepo) queryStatistics(ctx context.Context, keyColumn string, filter domain.StatisticsFilter, add func(key, teamName string, stats domain.ReviewStatistics)) error {
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(statisticsQuery, keyColumn), filter.TeamName, nullTime(filter.From), nullTime(filter.To))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key, teamName string
+		var stats domain.ReviewStatistics
+		var avg, p90 sql.NullFloat64
+		if err := rows.Scan(
+			&key,
+			&teamName,
+			&stats.OpenPRs,
+			&stats.MergedPRs,
+			&stats.MergedWithoutReviewers,
+			&stats.Assignments,
+			&stats.ReassignmentsAway,
+			&stats.Declines,
+			&avg,
+			&p90,
+		); err != nil {
+			return err
+		}
+
+		if avg.Valid {
+			stats.AvgTimeToMergeSeconds = &avg.Float64
+		}
+		if p90.Valid {
+			stats.P90TimeToMergeSeconds = &p90.Float64
+		}
+		add(key, teamName, stats)
+	}
+
+	return rows.Err()
+}
+
+// GetMemberLoad returns every current member of the team with the seconds
+// they spent active inside [from, to), reconstructed from user_activity_log,
+// and the reviews assigned to them in that window.
+func (r *StatisticsRepo) GetMemberLoad(ctx context.Context, teamName string, from, to time.Time) ([]domain.MemberLoad, error) {
+	query := `
+		WITH members AS (
+			SELECT user_id, is_active FROM users WHERE team_name = $1
+		),
+		periods AS (
+			SELECT l.user_id, l.is_active, l.changed_at AS start_at,
+				COALESCE(LEAD(l.changed_at) OVER (PARTITION BY l.user_id ORDER BY l.changed_at, l.id), 'infinity'::timestamp) AS end_at
+			FROM user_activity_log l
+			INNER JOIN members m ON m.user_id = l.user_id
+		),
+		active AS (
+			SELECT user_id, SUM(EXTRACT(EPOCH FROM LEAST(end_at, $3::timestamp) - GREATEST(start_at, $2::timestamp))) AS active_seconds
+			FROM periods
+			WHERE is_active AND start_at < $3::timestamp AND end_at > $2::timestamp
+			GROUP BY user_id
+		),
+		assigned AS (
+			SELECT prr.user_id, COUNT(*) AS assignments
+			FROM pr_reviewers prr
+			INNER JOIN members m ON m.user_id = prr.user_id
+			WHERE prr.assigned_at >= $2::timestamp AND prr.assigned_at < $3::timestamp
+			GROUP BY prr.user_id
+		)
+		SELECT m.user_id, m.is_active, COALESCE(a.active_seconds, 0), COALESCE(s.assignments, 0)
+		FROM members m
+		LEFT JOIN active a ON a.user_id = m.user_id
+		LEFT JOIN assigned s ON s.user_id = m.user_id
+		ORDER BY m.user_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, teamName, from.UTC(), to.UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var loads []domain.MemberLoad
+	for rows.Next() {
+		var load domain.MemberLoad
+		if err := rows.Scan(&load.UserID, &load.IsActive, &load.ActiveSeconds, &load.Assignments); err != nil {
+			return nil, err
+		}
+		loads = append(loads, load)
+	}
+
+	return loads, rows.Err()
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+)
+
+// syncLockKey identifies the advisory lock held during a directory sync so
+// that replicas sharing the database take turns instead of syncing at once.
+const syncLockKey int64 = 7_206_153_018_724_302
+
+type SyncRunRepo struct {
+	db *sql.DB
+}
+
+func NewSyncRunRepo(db *sql.DB) *SyncRunRepo {
+	return &SyncRunRepo{db: db}
+}
+
+func (r *SyncRunRepo) CreateRun(ctx context.Context, run *domain.SyncRun) error {
+	// lib/pq would send []byte as bytea, which jsonb does not accept.
+	var report interface{}
+	if run.Report != nil {
+		data, err := json.Marshal(run.Report)
+		if err != nil {
+			return err
+		}
+		report = string(data)
+	}
+
+	query := `
+		INSERT INTO sync_runs (source, triggered_by, started_at, finished_at, status, error, report)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+	return r.db.QueryRowContext(ctx, query, run.Source, run.Trigger, run.StartedAt, run.FinishedAt, run.Status, run.Error, report).Scan(&run.ID)
+}
+
+func (r *SyncRunRepo) ListRuns(ctx context.Context, limit int) ([]domain.SyncRun, error) {
+	query := `
+		SELECT id, source, triggered_by, started_at, finished_at, status, error, report
+		FROM sync_runs
+		ORDER BY id DESC
+		LIMIT $1
+	`
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	runs := []domain.SyncRun{}
+	for rows.Next() {
+		var run domain.SyncRun
+		var report []byte
+		if err := rows.Scan(&run.ID, &run.Source, &run.Trigger, &run.StartedAt, &run.FinishedAt, &run.Status, &run.Error, &report); err != nil {
+			return nil, err
+		}
+		if report != nil {
+			if err := json.Unmarshal(report, &run.Report); err != nil {
+				return nil, err
+			}
+		}
+		runs = append(runs, run)
+	}
+
+	return runs, rows.Err()
+}
+
+// WithLock holds a session advisory lock, so it keeps one connection of the
+// pool for the duration of fn.
+func (r *SyncRunRepo) WithLock(ctx context.Context, fn func() error) (bool, error) {
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", syncLockKey).Scan(&acquired); err != nil || !acquired {
+		return false, err
+	}
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", syncLockKey)
+
+	return true, fn()
+}
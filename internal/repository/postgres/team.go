@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/lib/pq"
 )
 
 type TeamRepo struct {
@@ -20,6 +21,87 @@ func (r *TeamRepo) CreateTeam(ctx context.Context, teamName string) error {
 	return err
 }
 
+// ImportTeams reads the import's teams and users with row locks, plans the
+// import against them and writes the plan in the same transaction, so that
+// concurrent changes are neither overwritten nor half applied.
+func (r *TeamRepo) ImportTeams(ctx context.Context, teams []domain.Team, plan func(domain.ImportSnapshot) (*domain.ImportPlan, error)) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	current, err := lockImport(ctx, tx, teams)
+	if err != nil {
+		return err
+	}
+	changes, err := plan(current)
+	if err != nil || changes == nil {
+		return err
+	}
+
+	for _, teamName := range changes.Teams {
+		query := `INSERT INTO teams (team_name) VALUES ($1) ON CONFLICT DO NOTHING`
+		if _, err := tx.ExecContext(ctx, query, teamName); err != nil {
+			return err
+		}
+	}
+
+	for i := range changes.Users {
+		if err := upsertUser(ctx, tx, &changes.Users[i]); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// lockImport reads the stored state of the listed teams and users. Locking
+// the team rows also holds off users joining those teams until the import
+// commits, as the foreign key check needs a share lock on them.
+func lockImport(ctx context.Context, tx *sql.Tx, teams []domain.Team) (domain.ImportSnapshot, error) {
+	teamNames, userIDs := domain.ImportScope(teams)
+	current := domain.ImportSnapshot{Teams: make(map[string]bool), Users: make(map[string]domain.User)}
+
+	rows, err := tx.QueryContext(ctx, `SELECT team_name FROM teams WHERE team_name = ANY($1) ORDER BY team_name FOR UPDATE`, pq.Array(teamNames))
+	if err != nil {
+		return current, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var teamName string
+		if err := rows.Scan(&teamName); err != nil {
+			return current, err
+		}
+		current.Teams[teamName] = true
+	}
+	if err := rows.Err(); err != nil {
+		return current, err
+	}
+
+	query := `
+		SELECT user_id, username, team_name, is_active
+		FROM users
+		WHERE user_id = ANY($1) OR team_name = ANY($2)
+		ORDER BY user_id
+		FOR UPDATE
+	`
+	userRows, err := tx.QueryContext(ctx, query, pq.Array(userIDs), pq.Array(teamNames))
+	if err != nil {
+		return current, err
+	}
+	defer userRows.Close()
+	for userRows.Next() {
+		var user domain.User
+		if err := userRows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive); err != nil {
+			return current, err
+		}
+		current.Users[user.UserID] = user
+	}
+
+	return current, userRows.Err()
+}
+
 func (r *TeamRepo) TeamExists(ctx context.Context, teamName string) (bool, error) {
 	query := `SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)`
 	var exists bool
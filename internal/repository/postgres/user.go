@@ -15,19 +15,22 @@ func NewUserRepo(db *sql.DB) *UserRepo {
 }
 
 func (r *UserRepo) CreateOrUpdateUser(ctx context.Context, user *domain.User) error {
-	query := `
-		INSERT INTO users (user_id, username, team_name, is_active, updated_at)
-		VALUES ($1, $2, $3, $4, NOW())
-		ON CONFLICT (user_id)
-		DO UPDATE SET username = $2, team_name = $3, is_active = $4, updated_at = NOW()
-	`
-	_, err := r.db.ExecContext(ctx, query, user.UserID, user.Username, user.TeamName, user.IsActive)
-	return err
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := upsertUser(ctx, tx, user); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (r *UserRepo) GetUser(ctx context.Context, userID string) (*domain.User, error) {
 	query := `
-		SELECT user_id, username, team_name, is_active
+		SELECT user_id, username, team_name, is_active, version
 		FROM users
 		WHERE user_id = $1
 	`
@@ -38,6 +41,7 @@ func (r *UserRepo) GetUser(ctx context.Context, userID string) (*domain.User, er
 		&user.Username,
 		&user.TeamName,
 		&user.IsActive,
+		&user.Version,
 	)
 
 	if err == sql.ErrNoRows {
@@ -47,12 +51,16 @@ func (r *UserRepo) GetUser(ctx context.Context, userID string) (*domain.User, er
 		return nil, err
 	}
 
-	return &user, nil
+	users := []domain.User{user}
+	if err := loadSkills(ctx, r.db, users); err != nil {
+		return nil, err
+	}
+	return &users[0], nil
 }
 
 func (r *UserRepo) GetUsersByTeam(ctx context.Context, teamName string) ([]domain.User, error) {
 	query := `
-		SELECT user_id, username, team_name, is_active
+		SELECT user_id, username, team_name, is_active, version
 		FROM users
 		WHERE team_name = $1
 		ORDER BY user_id
@@ -67,37 +75,100 @@ func (r *UserRepo) GetUsersByTeam(ctx context.Context, teamName string) ([]domai
 	var users []domain.User
 	for rows.Next() {
 		var user domain.User
-		if err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive); err != nil {
+		if err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.Version); err != nil {
 			return nil, err
 		}
 		users = append(users, user)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
-	return users, rows.Err()
+	if err := loadSkills(ctx, r.db, users); err != nil {
+		return nil, err
+	}
+	return users, nil
 }
 
 func (r *UserRepo) SetIsActive(ctx context.Context, userID string, isActive bool) error {
-	query := `UPDATE users SET is_active = $1, updated_at = NOW() WHERE user_id = $2`
-	result, err := r.db.ExecContext(ctx, query, isActive, userID)
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
-	rowsAffected, err := result.RowsAffected()
+	wasActive, err := lockActivity(ctx, tx, userID)
+	if err == sql.ErrNoRows {
+		return domain.ErrUserNotFound
+	}
 	if err != nil {
 		return err
 	}
 
-	if rowsAffected == 0 {
-		return domain.ErrUserNotFound
+	if err := bumpUserVersion(ctx, tx, userID); err != nil {
+		return err
+	}
+
+	query := `UPDATE users SET is_active = $1, updated_at = NOW() WHERE user_id = $2`
+	if _, err := tx.ExecContext(ctx, query, isActive, userID); err != nil {
+		return err
+	}
+
+	if wasActive != isActive {
+		if err := logActivity(ctx, tx, userID, isActive); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// upsertUser writes the user and logs an activity transition when the user
+// is new or is_active changes.
+func upsertUser(ctx context.Context, tx *sql.Tx, user *domain.User) error {
+	wasActive, err := lockActivity(ctx, tx, user.UserID)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	changed := err == sql.ErrNoRows || wasActive != user.IsActive
+
+	query := `
+		INSERT INTO users (user_id, username, team_name, is_active, updated_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (user_id)
+		DO UPDATE SET username = $2, team_name = $3, is_active = $4, updated_at = NOW(),
+			version = users.version + 1
+	`
+	if _, err := tx.ExecContext(ctx, query, user.UserID, user.Username, user.TeamName, user.IsActive); err != nil {
+		return err
+	}
+
+	if changed {
+		if err := logActivity(ctx, tx, user.UserID, user.IsActive); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+func lockActivity(ctx context.Context, tx *sql.Tx, userID string) (bool, error) {
+	var isActive bool
+	err := tx.QueryRowContext(ctx, `SELECT is_active FROM users WHERE user_id = $1 FOR UPDATE`, userID).Scan(&isActive)
+	return isActive, err
+}
+
+// logActivity records an is_active transition so that the fairness report
+// can tell how long each member was available for review.
+func logActivity(ctx context.Context, tx *sql.Tx, userID string, isActive bool) error {
+	query := `INSERT INTO user_activity_log (user_id, is_active) VALUES ($1, $2)`
+	_, err := tx.ExecContext(ctx, query, userID, isActive)
+	return err
+}
+
 func (r *UserRepo) GetActiveTeamMembers(ctx context.Context, teamName string, excludeUserID string) ([]domain.User, error) {
 	query := `
-		SELECT user_id, username, team_name, is_active
+		SELECT user_id, username, team_name, is_active, version
 		FROM users
 		WHERE team_name = $1 AND is_active = true AND user_id != $2
 		ORDER BY user_id
@@ -112,11 +183,17 @@ func (r *UserRepo) GetActiveTeamMembers(ctx context.Context, teamName string, ex
 	var users []domain.User
 	for rows.Next() {
 		var user domain.User
-		if err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive); err != nil {
+		if err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.Version); err != nil {
 			return nil, err
 		}
 		users = append(users, user)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
-	return users, rows.Err()
+	if err := loadSkills(ctx, r.db, users); err != nil {
+		return nil, err
+	}
+	return users, nil
 }
@@ -3,12 +3,17 @@ package repository
 import (
 	"context"
 	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"time"
 )
 
 type TeamRepository interface {
 	CreateTeam(ctx context.Context, teamName string) error
 	TeamExists(ctx context.Context, teamName string) (bool, error)
 	GetTeam(ctx context.Context, teamName string) (*domain.Team, error)
+	// ImportTeams plans and applies an import in one transaction. The stored
+	// state of the listed teams and users is locked and handed to plan, and
+	// the plan it returns is written unless it is nil.
+	ImportTeams(ctx context.Context, teams []domain.Team, plan func(domain.ImportSnapshot) (*domain.ImportPlan, error)) error
 }
 
 type UserRepository interface {
@@ -16,24 +21,65 @@ type UserRepository interface {
 	GetUser(ctx context.Context, userID string) (*domain.User, error)
 	GetUsersByTeam(ctx context.Context, teamName string) ([]domain.User, error)
 	SetIsActive(ctx context.Context, userID string, isActive bool) error
+	// SetSkills replaces the user's skill set.
+	SetSkills(ctx context.Context, userID string, skills []string) error
 	GetActiveTeamMembers(ctx context.Context, teamName string, excludeUserID string) ([]domain.User, error)
 }
 
 type PullRequestRepository interface {
 	CreatePR(ctx context.Context, pr *domain.PullRequest) error
 	GetPR(ctx context.Context, prID string) (*domain.PullRequest, error)
+	GetPRDetails(ctx context.Context, prIDs []string) ([]domain.PullRequestDetails, error)
 	PRExists(ctx context.Context, prID string) (bool, error)
 	MergePR(ctx context.Context, prID string) error
-	AssignReviewer(ctx context.Context, prID, userID string) error
-	UnassignReviewer(ctx context.Context, prID, userID string) error
+	// AssignReviewer adds a reviewer unless the PR already has maxReviewers
+	// others, in which case it fails with ErrTooManyReviewers.
+	AssignReviewer(ctx context.Context, prID, userID, reason string, maxReviewers int) error
+	UnassignReviewer(ctx context.Context, prID, userID, reason string) error
+	ReplaceReviewer(ctx context.Context, prID, oldUserID, newUserID, reason string) error
+	DeclineReviewer(ctx context.Context, prID, userID, newUserID, reason string) error
+	GetDeclinedReviewers(ctx context.Context, prID string) ([]string, error)
+	GetAssignmentHistory(ctx context.Context, prID string) ([]domain.AssignmentEvent, error)
 	IsReviewerAssigned(ctx context.Context, prID, userID string) (bool, error)
-	GetPRsByReviewer(ctx context.Context, userID string) ([]domain.PullRequestShort, error)
-	GetReviewersCount(ctx context.Context) (map[string]int, error)
-	GetPRsCount(ctx context.Context) (int, error)
+	ListPRs(ctx context.Context, filter domain.PRFilter, after *domain.PRCursor, limit int) ([]domain.PullRequestShort, *domain.PRCursor, error)
+	// StreamPRs and StreamAssignmentHistory call fn for every matching row
+	// as it is read, stopping at the first error fn returns.
+	StreamPRs(ctx context.Context, filter domain.PRFilter, fn func(domain.PullRequestDetails) error) error
+	StreamAssignmentHistory(ctx context.Context, filter domain.PRFilter, fn func(domain.AssignmentEvent) error) error
+}
+
+type StatisticsRepository interface {
+	GetUserStatistics(ctx context.Context, filter domain.StatisticsFilter) ([]domain.UserStatistics, error)
+	GetTeamStatistics(ctx context.Context, filter domain.StatisticsFilter) ([]domain.TeamStatistics, error)
+	GetMemberLoad(ctx context.Context, teamName string, from, to time.Time) ([]domain.MemberLoad, error)
+}
+
+type ExclusionRepository interface {
+	// CreateRule stores the rule and fills in its RuleID and CreatedAt.
+	CreateRule(ctx context.Context, rule *domain.ExclusionRule) error
+	DeleteRule(ctx context.Context, ruleID int64) error
+	// ListRules returns the rules involving userID, or all rules when it is
+	// empty, ordered by rule_id.
+	ListRules(ctx context.Context, userID string) ([]domain.ExclusionRule, error)
+}
+
+// SyncRunRepository records directory sync runs and holds the lock that keeps
+// replicas sharing a database from syncing at the same time.
+type SyncRunRepository interface {
+	// CreateRun stores the run and fills in its ID.
+	CreateRun(ctx context.Context, run *domain.SyncRun) error
+	// ListRuns returns up to limit runs, newest first.
+	ListRuns(ctx context.Context, limit int) ([]domain.SyncRun, error)
+	// WithLock calls fn while holding the sync lock. It reports false
+	// without calling fn when the lock is held elsewhere.
+	WithLock(ctx context.Context, fn func() error) (bool, error)
 }
 
 type Repository struct {
 	Team        TeamRepository
 	User        UserRepository
 	PullRequest PullRequestRepository
+	Statistics  StatisticsRepository
+	Exclusion   ExclusionRepository
+	SyncRun     SyncRunRepository
 }
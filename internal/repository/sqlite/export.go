@@ -0,0 +1,178 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"strings"
+	"time"
+)
+
+// exportPageSize is how many PRs or history events an export reads per
+// query. The pool has a single connection, so the exports read a page, close
+// the rows and only then hand the page to fn: a slow client holds up its own
+// export rather than every other request.
+const exportPageSize = 500
+
+// StreamPRs reads the matching PRs page by page, newest first, so at most one
+// page is held in memory.
+func (r *PullRequestRepo) StreamPRs(ctx context.Context, filter domain.PRFilter, fn func(domain.PullRequestDetails) error) error {
+	var after *domain.PRCursor
+	for {
+		page, next, err := r.exportPRPage(ctx, filter, after)
+		if err != nil {
+			return err
+		}
+		for _, pr := range page {
+			if err := fn(pr); err != nil {
+				return err
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		after = next
+	}
+}
+
+// exportPRPage reads up to exportPageSize PRs after the cursor, one row per
+// (PR, reviewer) pair, and folds consecutive rows together.
+func (r *PullRequestRepo) exportPRPage(ctx context.Context, filter domain.PRFilter, after *domain.PRCursor) ([]domain.PullRequestDetails, *domain.PRCursor, error) {
+	var args queryArgs
+	conditions := prFilterConditions(filter, &args)
+	if after != nil {
+		conditions = append(conditions, fmt.Sprintf("(pr.created_at, pr.pull_request_id) < (%s, %s)", args.add(formatTime(after.CreatedAt)), args.add(after.PullRequestID)))
+	}
+
+	page := `
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.created_at, pr.merged_at, u.team_name
+		FROM pull_requests pr
+		INNER JOIN users u ON u.user_id = pr.author_id
+	`
+	if len(conditions) > 0 {
+		page += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	page += " ORDER BY pr.created_at DESC, pr.pull_request_id DESC LIMIT " + args.add(exportPageSize)
+
+	query := `
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.created_at, pr.merged_at,
+		       pr.team_name, prr.user_id, prr.assigned_at
+		FROM (` + page + `) pr
+		LEFT JOIN pr_reviewers prr ON prr.pull_request_id = pr.pull_request_id
+		ORDER BY pr.created_at DESC, pr.pull_request_id DESC, prr.assigned_at, prr.user_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args.values...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var prs []domain.PullRequestDetails
+	for rows.Next() {
+		var pr domain.PullRequestDetails
+		var createdAt time.Time
+		var mergedAt, assignedAt sql.NullTime
+		var reviewerID sql.NullString
+		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &createdAt, &mergedAt,
+			&pr.AuthorTeam, &reviewerID, &assignedAt); err != nil {
+			return nil, nil, err
+		}
+
+		if len(prs) == 0 || prs[len(prs)-1].PullRequestID != pr.PullRequestID {
+			pr.CreatedAt = &createdAt
+			if mergedAt.Valid {
+				pr.MergedAt = &mergedAt.Time
+			}
+			pr.AssignedReviewers = []string{}
+			pr.Reviewers = []domain.ReviewerAssignment{}
+			prs = append(prs, pr)
+		}
+
+		if reviewerID.Valid {
+			current := &prs[len(prs)-1]
+			current.AssignedReviewers = append(current.AssignedReviewers, reviewerID.String)
+			current.Reviewers = append(current.Reviewers, domain.ReviewerAssignment{UserID: reviewerID.String, AssignedAt: assignedAt.Time})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	rows.Close()
+
+	prIDs := make([]string, len(prs))
+	for i := range prs {
+		prIDs[i] = prs[i].PullRequestID
+	}
+	matches, err := loadTagMatches(ctx, r.db, prIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range prs {
+		prs[i].SetTagMatches(matches[prs[i].PullRequestID])
+	}
+
+	if len(prs) < exportPageSize {
+		return prs, nil, nil
+	}
+	last := prs[len(prs)-1]
+	return prs, &domain.PRCursor{CreatedAt: *last.CreatedAt, PullRequestID: last.PullRequestID}, nil
+}
+
+// StreamAssignmentHistory reads the matching events page by page in the
+// order they happened.
+func (r *PullRequestRepo) StreamAssignmentHistory(ctx context.Context, filter domain.PRFilter, fn func(domain.AssignmentEvent) error) error {
+	var afterID int64
+	for {
+		page, lastID, err := r.exportHistoryPage(ctx, filter, afterID)
+		if err != nil {
+			return err
+		}
+		for _, event := range page {
+			if err := fn(event); err != nil {
+				return err
+			}
+		}
+		if len(page) < exportPageSize {
+			return nil
+		}
+		afterID = lastID
+	}
+}
+
+// exportHistoryPage reads up to exportPageSize events with IDs above afterID
+// and returns the ID of the last one.
+func (r *PullRequestRepo) exportHistoryPage(ctx context.Context, filter domain.PRFilter, afterID int64) ([]domain.AssignmentEvent, int64, error) {
+	var args queryArgs
+	conditions := append(prFilterConditions(filter, &args), "h.id > "+args.add(afterID))
+
+	query := `
+		SELECT h.id, h.pull_request_id, h.action, h.user_id, COALESCE(h.previous_user_id, ''), h.reason, h.created_at
+		FROM pr_reviewer_history h
+		INNER JOIN pull_requests pr ON pr.pull_request_id = h.pull_request_id
+		INNER JOIN users u ON u.user_id = pr.author_id
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY h.id
+		LIMIT ` + args.add(exportPageSize)
+
+	rows, err := r.db.QueryContext(ctx, query, args.values...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var events []domain.AssignmentEvent
+	lastID := afterID
+	for rows.Next() {
+		var event domain.AssignmentEvent
+		var createdAt time.Time
+		if err := rows.Scan(&lastID, &event.PullRequestID, &event.Action, &event.UserID, &event.PreviousUserID, &event.Reason, &createdAt); err != nil {
+			return nil, 0, err
+		}
+		event.CreatedAt = &createdAt
+		events = append(events, event)
+	}
+
+	return events, lastID, rows.Err()
+}
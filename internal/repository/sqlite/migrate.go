@@ -0,0 +1,129 @@
+package sqlite
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+type migration struct {
+	version  int
+	name     string
+	script   string
+	checksum string
+}
+
+func loadMigrations() ([]migration, error) {
+	entries, err := migrationFiles.ReadDir("migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	var migrations []migration
+	for _, entry := range entries {
+		prefix, name, _ := strings.Cut(strings.TrimSuffix(entry.Name(), ".sql"), "_")
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s has no numeric version prefix", entry.Name())
+		}
+
+		content, err := migrationFiles.ReadFile(path.Join("migrations", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(content)
+		migrations = append(migrations, migration{version: version, name: name, script: string(content), checksum: hex.EncodeToString(sum[:])})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].version < migrations[j].version
+	})
+	return migrations, nil
+}
+
+// LatestMigrationVersion returns the newest version embedded in the binary.
+func LatestMigrationVersion() int {
+	migrations, err := loadMigrations()
+	if err != nil || len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].version
+}
+
+// RunMigrations applies the embedded migrations that are not recorded in
+// schema_migrations yet, each in its own transaction. Migrations are only
+// ever added; a recorded migration whose script changed is an error.
+func RunMigrations(ctx context.Context, db *sql.DB) error {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			checksum TEXT NOT NULL,
+			applied_at TIMESTAMP NOT NULL DEFAULT (`+nowExpr+`)
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	for _, m := range migrations {
+		var checksum string
+		err := db.QueryRowContext(ctx, `SELECT checksum FROM schema_migrations WHERE version = ?`, m.version).Scan(&checksum)
+		if err == nil {
+			if checksum != m.checksum {
+				return fmt.Errorf("migration %03d_%s was modified after it was applied", m.version, m.name)
+			}
+			continue
+		}
+		if err != sql.ErrNoRows {
+			return fmt.Errorf("failed to read schema_migrations: %w", err)
+		}
+
+		if err := applyMigration(ctx, db, m); err != nil {
+			return fmt.Errorf("failed to apply migration %03d_%s: %w", m.version, m.name, err)
+		}
+	}
+
+	return nil
+}
+
+func applyMigration(ctx context.Context, db *sql.DB, m migration) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, m.script); err != nil {
+		return err
+	}
+	query := `INSERT INTO schema_migrations (version, name, checksum) VALUES (?, ?, ?)`
+	if _, err := tx.ExecContext(ctx, query, m.version, m.name, m.checksum); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// SchemaVersion returns the newest applied migration version.
+func SchemaVersion(ctx context.Context, db *sql.DB) (int, error) {
+	var version sql.NullInt64
+	if err := db.QueryRowContext(ctx, `SELECT MAX(version) FROM schema_migrations`).Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return int(version.Int64), nil
+}
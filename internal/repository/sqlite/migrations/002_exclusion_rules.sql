@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS exclusion_rules (
+    rule_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind TEXT NOT NULL CHECK (kind IN ('PAIR', 'AUTHOR')),
+    user_id TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    other_user_id TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    UNIQUE (kind, user_id, other_user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_exclusion_rules_other_user_id ON exclusion_rules(other_user_id);
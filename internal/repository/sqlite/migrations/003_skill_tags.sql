@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS user_skills (
+    user_id TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    skill TEXT NOT NULL,
+    PRIMARY KEY (user_id, skill)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_skills_skill ON user_skills(skill);
+
+CREATE TABLE IF NOT EXISTS pr_required_tags (
+    pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    tag TEXT NOT NULL,
+    reviewer_id TEXT REFERENCES users(user_id),
+    PRIMARY KEY (pull_request_id, tag)
+);
@@ -0,0 +1,647 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"strings"
+	"time"
+)
+
+type PullRequestRepo struct {
+	db *sql.DB
+}
+
+func NewPullRequestRepo(db *sql.DB) *PullRequestRepo {
+	return &PullRequestRepo{db: db}
+}
+
+func (r *PullRequestRepo) CreatePR(ctx context.Context, pr *domain.PullRequest) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status)
+		VALUES (?1, ?2, ?3, ?4)
+	`
+	_, err = tx.ExecContext(ctx, query, pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status)
+	if err != nil {
+		return err
+	}
+
+	for _, reviewerID := range pr.AssignedReviewers {
+		reviewerQuery := `INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES (?1, ?2)`
+		_, err = tx.ExecContext(ctx, reviewerQuery, pr.PullRequestID, reviewerID)
+		if err != nil {
+			return err
+		}
+
+		err = insertHistory(ctx, tx, domain.AssignmentEvent{
+			PullRequestID: pr.PullRequestID,
+			Action:        domain.AssignmentAssigned,
+			UserID:        reviewerID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := insertTagMatches(ctx, tx, pr.PullRequestID, pr.TagMatches()); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *PullRequestRepo) GetPR(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	query := `
+		SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at, version
+		FROM pull_requests
+		WHERE pull_request_id = ?1
+	`
+
+	var pr domain.PullRequest
+	var createdAt time.Time
+	var mergedAt sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, prID).Scan(
+		&pr.PullRequestID,
+		&pr.PullRequestName,
+		&pr.AuthorID,
+		&pr.Status,
+		&createdAt,
+		&mergedAt,
+		&pr.Version,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrPRNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pr.CreatedAt = &createdAt
+	if mergedAt.Valid {
+		pr.MergedAt = &mergedAt.Time
+	}
+
+	reviewersQuery := `SELECT user_id FROM pr_reviewers WHERE pull_request_id = ?1 ORDER BY user_id`
+	rows, err := r.db.QueryContext(ctx, reviewersQuery, prID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pr.AssignedReviewers = []string{}
+	for rows.Next() {
+		var reviewerID string
+		if err := rows.Scan(&reviewerID); err != nil {
+			return nil, err
+		}
+		pr.AssignedReviewers = append(pr.AssignedReviewers, reviewerID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	matches, err := loadTagMatches(ctx, r.db, []string{prID})
+	if err != nil {
+		return nil, err
+	}
+	pr.SetTagMatches(matches[prID])
+
+	return &pr, nil
+}
+
+// GetPRDetails loads the PRs with the given IDs along with the author's team
+// and reviewer assignment times. Missing IDs are skipped; the result is in no
+// particular order.
+func (r *PullRequestRepo) GetPRDetails(ctx context.Context, prIDs []string) ([]domain.PullRequestDetails, error) {
+	// One snapshot for all reads, so that reviewers and tags belong to the
+	// PRs as they were found.
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var args queryArgs
+	placeholders := make([]string, len(prIDs))
+	for i, prID := range prIDs {
+		placeholders[i] = args.add(prID)
+	}
+	in := strings.Join(placeholders, ", ")
+
+	query := `
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.created_at, pr.merged_at, u.team_name
+		FROM pull_requests pr
+		INNER JOIN users u ON u.user_id = pr.author_id
+		WHERE pr.pull_request_id IN (` + in + `)
+	`
+
+	rows, err := tx.QueryContext(ctx, query, args.values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var details []domain.PullRequestDetails
+	index := make(map[string]int)
+	for rows.Next() {
+		var d domain.PullRequestDetails
+		var createdAt time.Time
+		var mergedAt sql.NullTime
+		if err := rows.Scan(&d.PullRequestID, &d.PullRequestName, &d.AuthorID, &d.Status, &createdAt, &mergedAt, &d.AuthorTeam); err != nil {
+			return nil, err
+		}
+
+		d.CreatedAt = &createdAt
+		if mergedAt.Valid {
+			d.MergedAt = &mergedAt.Time
+		}
+		d.AssignedReviewers = []string{}
+		d.Reviewers = []domain.ReviewerAssignment{}
+
+		index[d.PullRequestID] = len(details)
+		details = append(details, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(details) == 0 {
+		return details, nil
+	}
+
+	found := make([]string, len(details))
+	var foundArgs queryArgs
+	for i, d := range details {
+		found[i] = d.PullRequestID
+		placeholders[i] = foundArgs.add(d.PullRequestID)
+	}
+	placeholders = placeholders[:len(details)]
+
+	reviewersQuery := `
+		SELECT pull_request_id, user_id, assigned_at
+		FROM pr_reviewers
+		WHERE pull_request_id IN (` + strings.Join(placeholders, ", ") + `)
+		ORDER BY user_id
+	`
+
+	reviewerRows, err := tx.QueryContext(ctx, reviewersQuery, foundArgs.values...)
+	if err != nil {
+		return nil, err
+	}
+	defer reviewerRows.Close()
+
+	for reviewerRows.Next() {
+		var prID string
+		var assignment domain.ReviewerAssignment
+		if err := reviewerRows.Scan(&prID, &assignment.UserID, &assignment.AssignedAt); err != nil {
+			return nil, err
+		}
+
+		i, ok := index[prID]
+		if !ok {
+			continue
+		}
+		d := &details[i]
+		d.AssignedReviewers = append(d.AssignedReviewers, assignment.UserID)
+		d.Reviewers = append(d.Reviewers, assignment)
+	}
+	if err := reviewerRows.Err(); err != nil {
+		return nil, err
+	}
+
+	matches, err := loadTagMatches(ctx, tx, found)
+	if err != nil {
+		return nil, err
+	}
+	for i := range details {
+		details[i].SetTagMatches(matches[details[i].PullRequestID])
+	}
+
+	return details, nil
+}
+
+func (r *PullRequestRepo) PRExists(ctx context.Context, prID string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM pull_requests WHERE pull_request_id = ?1)`
+	var exists bool
+	err := r.db.QueryRowContext(ctx, query, prID).Scan(&exists)
+	return exists, err
+}
+
+func (r *PullRequestRepo) MergePR(ctx context.Context, prID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := bumpPRVersion(ctx, tx, prID); err != nil {
+		return err
+	}
+
+	query := `UPDATE pull_requests SET status = ?1, merged_at = ` + nowExpr + ` WHERE pull_request_id = ?2 AND status != ?1`
+	if _, err := tx.ExecContext(ctx, query, domain.PRStatusMerged, prID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// AssignReviewer counts the other reviewers after bumping the PR version,
+// which locks the PR row, so concurrent additions cannot both fit under
+// maxReviewers.
+func (r *PullRequestRepo) AssignReviewer(ctx context.Context, prID, userID, reason string, maxReviewers int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := bumpPRVersion(ctx, tx, prID); err != nil {
+		return err
+	}
+
+	var others int
+	countQuery := `SELECT COUNT(*) FROM pr_reviewers WHERE pull_request_id = ?1 AND user_id != ?2`
+	if err := tx.QueryRowContext(ctx, countQuery, prID, userID).Scan(&others); err != nil {
+		return err
+	}
+	if others >= maxReviewers {
+		return domain.ErrTooManyReviewers
+	}
+
+	query := `INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES (?1, ?2) ON CONFLICT DO NOTHING`
+	result, err := tx.ExecContext(ctx, query, prID, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return tx.Commit()
+	}
+
+	err = insertHistory(ctx, tx, domain.AssignmentEvent{
+		PullRequestID: prID,
+		Action:        domain.AssignmentAssigned,
+		UserID:        userID,
+		Reason:        reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *PullRequestRepo) UnassignReviewer(ctx context.Context, prID, userID, reason string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := bumpPRVersion(ctx, tx, prID); err != nil {
+		return err
+	}
+
+	query := `DELETE FROM pr_reviewers WHERE pull_request_id = ?1 AND user_id = ?2`
+	result, err := tx.ExecContext(ctx, query, prID, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return tx.Commit()
+	}
+
+	err = insertHistory(ctx, tx, domain.AssignmentEvent{
+		PullRequestID: prID,
+		Action:        domain.AssignmentUnassigned,
+		UserID:        userID,
+		Reason:        reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ReplaceReviewer swaps reviewers atomically. It fails with ErrNotAssigned if
+// a concurrent request already removed the old reviewer.
+func (r *PullRequestRepo) ReplaceReviewer(ctx context.Context, prID, oldUserID, newUserID, reason string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := bumpPRVersion(ctx, tx, prID); err != nil {
+		return err
+	}
+
+	deleteQuery := `DELETE FROM pr_reviewers WHERE pull_request_id = ?1 AND user_id = ?2`
+	result, err := tx.ExecContext(ctx, deleteQuery, prID, oldUserID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrNotAssigned
+	}
+
+	insertQuery := `INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES (?1, ?2) ON CONFLICT DO NOTHING`
+	result, err = tx.ExecContext(ctx, insertQuery, prID, newUserID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err = result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrAlreadyAssigned
+	}
+
+	if err := moveTagMatches(ctx, tx, prID, oldUserID, newUserID); err != nil {
+		return err
+	}
+
+	err = insertHistory(ctx, tx, domain.AssignmentEvent{
+		PullRequestID:  prID,
+		Action:         domain.AssignmentReassigned,
+		UserID:         newUserID,
+		PreviousUserID: oldUserID,
+		Reason:         reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeclineReviewer removes a reviewer, remembers the decline and, when
+// newUserID is set, assigns the replacement in the same transaction.
+func (r *PullRequestRepo) DeclineReviewer(ctx context.Context, prID, userID, newUserID, reason string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := bumpPRVersion(ctx, tx, prID); err != nil {
+		return err
+	}
+
+	deleteQuery := `DELETE FROM pr_reviewers WHERE pull_request_id = ?1 AND user_id = ?2`
+	result, err := tx.ExecContext(ctx, deleteQuery, prID, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrNotAssigned
+	}
+
+	declineQuery := `
+		INSERT INTO pr_reviewer_declines (pull_request_id, user_id, reason)
+		VALUES (?1, ?2, ?3)
+		ON CONFLICT (pull_request_id, user_id)
+		DO UPDATE SET reason = ?3, declined_at = ` + nowExpr + `
+	`
+	if _, err := tx.ExecContext(ctx, declineQuery, prID, userID, reason); err != nil {
+		return err
+	}
+
+	if err := moveTagMatches(ctx, tx, prID, userID, newUserID); err != nil {
+		return err
+	}
+
+	err = insertHistory(ctx, tx, domain.AssignmentEvent{
+		PullRequestID: prID,
+		Action:        domain.AssignmentDeclined,
+		UserID:        userID,
+		Reason:        reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	if newUserID == "" {
+		return tx.Commit()
+	}
+
+	insertQuery := `INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES (?1, ?2) ON CONFLICT DO NOTHING`
+	result, err = tx.ExecContext(ctx, insertQuery, prID, newUserID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err = result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrAlreadyAssigned
+	}
+
+	err = insertHistory(ctx, tx, domain.AssignmentEvent{
+		PullRequestID:  prID,
+		Action:         domain.AssignmentReassigned,
+		UserID:         newUserID,
+		PreviousUserID: userID,
+		Reason:         reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *PullRequestRepo) GetDeclinedReviewers(ctx context.Context, prID string) ([]string, error) {
+	query := `SELECT user_id FROM pr_reviewer_declines WHERE pull_request_id = ?1 ORDER BY user_id`
+
+	rows, err := r.db.QueryContext(ctx, query, prID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userIDs []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, rows.Err()
+}
+
+func (r *PullRequestRepo) GetAssignmentHistory(ctx context.Context, prID string) ([]domain.AssignmentEvent, error) {
+	query := `
+		SELECT pull_request_id, action, user_id, COALESCE(previous_user_id, ''), reason, created_at
+		FROM pr_reviewer_history
+		WHERE pull_request_id = ?1
+		ORDER BY id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, prID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []domain.AssignmentEvent{}
+	for rows.Next() {
+		var event domain.AssignmentEvent
+		var createdAt time.Time
+		if err := rows.Scan(&event.PullRequestID, &event.Action, &event.UserID, &event.PreviousUserID, &event.Reason, &createdAt); err != nil {
+			return nil, err
+		}
+		event.CreatedAt = &createdAt
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+func insertHistory(ctx context.Context, tx *sql.Tx, event domain.AssignmentEvent) error {
+	query := `
+		INSERT INTO pr_reviewer_history (pull_request_id, action, user_id, previous_user_id, reason)
+		VALUES (?1, ?2, ?3, NULLIF(?4, ''), ?5)
+	`
+	_, err := tx.ExecContext(ctx, query, event.PullRequestID, event.Action, event.UserID, event.PreviousUserID, event.Reason)
+	return err
+}
+
+func (r *PullRequestRepo) IsReviewerAssigned(ctx context.Context, prID, userID string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM pr_reviewers WHERE pull_request_id = ?1 AND user_id = ?2)`
+	var exists bool
+	err := r.db.QueryRowContext(ctx, query, prID, userID).Scan(&exists)
+	return exists, err
+}
+
+// ListPRs returns up to limit pull requests matching filter, newest first,
+// starting after the given cursor. The returned cursor is nil on the last
+// page.
+func (r *PullRequestRepo) ListPRs(ctx context.Context, filter domain.PRFilter, after *domain.PRCursor, limit int) ([]domain.PullRequestShort, *domain.PRCursor, error) {
+	var args queryArgs
+	conditions := prFilterConditions(filter, &args)
+	if after != nil {
+		conditions = append(conditions, fmt.Sprintf("(pr.created_at, pr.pull_request_id) < (%s, %s)", args.add(formatTime(after.CreatedAt)), args.add(after.PullRequestID)))
+	}
+
+	query := `
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.created_at
+		FROM pull_requests pr
+		INNER JOIN users u ON u.user_id = pr.author_id
+	`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY pr.created_at DESC, pr.pull_request_id DESC LIMIT " + args.add(limit+1)
+
+	rows, err := r.db.QueryContext(ctx, query, args.values...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	prs := []domain.PullRequestShort{}
+	var last domain.PRCursor
+	var next *domain.PRCursor
+	for rows.Next() {
+		if len(prs) == limit {
+			next = &last
+			break
+		}
+
+		var pr domain.PullRequestShort
+		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &last.CreatedAt); err != nil {
+			return nil, nil, err
+		}
+		last.PullRequestID = pr.PullRequestID
+		prs = append(prs, pr)
+	}
+
+	return prs, next, rows.Err()
+}
+
+// queryArgs collects positional parameters for dynamically built queries.
+type queryArgs struct {
+	values []interface{}
+}
+
+func (a *queryArgs) add(value interface{}) string {
+	a.values = append(a.values, value)
+	return fmt.Sprintf("?%d", len(a.values))
+}
+
+// prFilterConditions translates a PRFilter into WHERE conditions over
+// pull_requests pr joined with its author as users u.
+func prFilterConditions(filter domain.PRFilter, args *queryArgs) []string {
+	var conditions []string
+	if filter.Status != "" {
+		conditions = append(conditions, "pr.status = "+args.add(filter.Status))
+	}
+	if filter.AuthorID != "" {
+		conditions = append(conditions, "pr.author_id = "+args.add(filter.AuthorID))
+	}
+	if filter.ReviewerID != "" {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM pr_reviewers prr WHERE prr.pull_request_id = pr.pull_request_id AND prr.user_id = "+args.add(filter.ReviewerID)+")")
+	}
+	if filter.TeamName != "" {
+		conditions = append(conditions, "u.team_name = "+args.add(filter.TeamName))
+	}
+	if filter.NameContains != "" {
+		conditions = append(conditions, "pr.pull_request_name LIKE "+args.add("%"+escapeLike(filter.NameContains)+"%")+` ESCAPE '\'`)
+	}
+	if filter.CreatedFrom != nil {
+		conditions = append(conditions, "pr.created_at >= "+args.add(formatTime(*filter.CreatedFrom)))
+	}
+	if filter.CreatedTo != nil {
+		conditions = append(conditions, "pr.created_at < "+args.add(formatTime(*filter.CreatedTo)))
+	}
+	if filter.MergedFrom != nil {
+		conditions = append(conditions, "pr.merged_at >= "+args.add(formatTime(*filter.MergedFrom)))
+	}
+	if filter.MergedTo != nil {
+		conditions = append(conditions, "pr.merged_at < "+args.add(formatTime(*filter.MergedTo)))
+	}
+	return conditions
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -0,0 +1,138 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"strings"
+)
+
+// SetSkills replaces the user's skill set.
+func (r *UserRepo) SetSkills(ctx context.Context, userID string, skills []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := lockActivity(ctx, tx, userID); err == sql.ErrNoRows {
+		return domain.ErrUserNotFound
+	} else if err != nil {
+		return err
+	}
+	if err := bumpUserVersion(ctx, tx, userID); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_skills WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+	for _, skill := range skills {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO user_skills (user_id, skill) VALUES (?, ?)`, userID, skill); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// loadSkills fills in Skills for every user, leaving it nil for users
+// without any.
+func loadSkills(ctx context.Context, db *sql.DB, users []domain.User) error {
+	if len(users) == 0 {
+		return nil
+	}
+
+	var args queryArgs
+	placeholders := make([]string, len(users))
+	index := make(map[string]int, len(users))
+	for i, user := range users {
+		placeholders[i] = args.add(user.UserID)
+		index[user.UserID] = i
+	}
+
+	query := `
+		SELECT user_id, skill
+		FROM user_skills
+		WHERE user_id IN (` + strings.Join(placeholders, ", ") + `)
+		ORDER BY user_id, skill
+	`
+	rows, err := db.QueryContext(ctx, query, args.values...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID, skill string
+		if err := rows.Scan(&userID, &skill); err != nil {
+			return err
+		}
+		user := &users[index[userID]]
+		user.Skills = append(user.Skills, skill)
+	}
+
+	return rows.Err()
+}
+
+// queryer is a *sql.DB or a *sql.Tx.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+func insertTagMatches(ctx context.Context, tx *sql.Tx, prID string, matches []domain.TagMatch) error {
+	query := `INSERT INTO pr_required_tags (pull_request_id, tag, reviewer_id) VALUES (?1, ?2, ?3)`
+	for _, match := range matches {
+		reviewerID := sql.NullString{String: match.ReviewerID, Valid: match.ReviewerID != ""}
+		if _, err := tx.ExecContext(ctx, query, prID, match.Tag, reviewerID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveTagMatches hands the tags oldUserID covered on the PR to newUserID
+// where they have the skill and leaves the rest uncovered.
+func moveTagMatches(ctx context.Context, tx *sql.Tx, prID, oldUserID, newUserID string) error {
+	query := `
+		UPDATE pr_required_tags
+		SET reviewer_id = CASE
+			WHEN EXISTS (SELECT 1 FROM user_skills s WHERE s.user_id = ?3 AND s.skill = pr_required_tags.tag) THEN ?3
+		END
+		WHERE pull_request_id = ?1 AND reviewer_id = ?2
+	`
+	_, err := tx.ExecContext(ctx, query, prID, oldUserID, newUserID)
+	return err
+}
+
+// loadTagMatches returns the required tags of the given PRs by PR ID.
+func loadTagMatches(ctx context.Context, db queryer, prIDs []string) (map[string][]domain.TagMatch, error) {
+	var args queryArgs
+	placeholders := make([]string, len(prIDs))
+	for i, prID := range prIDs {
+		placeholders[i] = args.add(prID)
+	}
+
+	query := `
+		SELECT pull_request_id, tag, COALESCE(reviewer_id, '')
+		FROM pr_required_tags
+		WHERE pull_request_id IN (` + strings.Join(placeholders, ", ") + `)
+	`
+	rows, err := db.QueryContext(ctx, query, args.values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	matches := make(map[string][]domain.TagMatch)
+	for rows.Next() {
+		var prID string
+		var match domain.TagMatch
+		if err := rows.Scan(&prID, &match.Tag, &match.ReviewerID); err != nil {
+			return nil, err
+		}
+		matches[prID] = append(matches[prID], match)
+	}
+
+	return matches, rows.Err()
+}
@@ -0,0 +1,73 @@
+// Package sqlite implements the repositories on an SQLite file for small
+// teams and demos that do not want to run Postgres.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/avito-test/pr-reviewer-service/internal/repository"
+	_ "modernc.org/sqlite"
+	"net/url"
+	"time"
+)
+
+// timeFormat is how timestamps are stored: fixed-width UTC text, so that
+// string comparison in SQL orders them chronologically.
+const timeFormat = "2006-01-02 15:04:05.000"
+
+// nowExpr is the SQL counterpart of formatTime(time.Now()).
+const nowExpr = "strftime('%Y-%m-%d %H:%M:%f', 'now')"
+
+// NewSQLiteDB opens the database file at path, creating it if needed.
+//
+// SQLite allows a single writer, so the pool is limited to one connection:
+// transactions run one after another, which gives reassignment the same
+// outcome under concurrency as row locks do in Postgres and avoids
+// SQLITE_BUSY errors. Nothing may therefore keep rows open while waiting on
+// a client; the exports read in pages for this reason.
+func NewSQLiteDB(path string) (*sql.DB, error) {
+	dsn := "file:" + path + "?" + url.Values{
+		"_pragma": {"foreign_keys(1)", "journal_mode(WAL)", "busy_timeout(5000)"},
+	}.Encode()
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	db.SetMaxOpenConns(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	return db, nil
+}
+
+func NewRepository(db *sql.DB) *repository.Repository {
+	return repository.WithTracing(&repository.Repository{
+		Team:        NewTeamRepo(db),
+		User:        NewUserRepo(db),
+		PullRequest: NewPullRequestRepo(db),
+		Statistics:  NewStatisticsRepo(db),
+		Exclusion:   NewExclusionRepo(db),
+		SyncRun:     NewSyncRunRepo(db),
+	}, "sqlite")
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeFormat)
+}
+
+// nullTime formats an optional bound, passing NULL when it is unset.
+func nullTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return formatTime(*t)
+}
@@ -0,0 +1,79 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"sync"
+)
+
+type SyncRunRepo struct {
+	db   *sql.DB
+	lock sync.Mutex
+}
+
+func NewSyncRunRepo(db *sql.DB) *SyncRunRepo {
+	return &SyncRunRepo{db: db}
+}
+
+func (r *SyncRunRepo) CreateRun(ctx context.Context, run *domain.SyncRun) error {
+	var report interface{}
+	if run.Report != nil {
+		data, err := json.Marshal(run.Report)
+		if err != nil {
+			return err
+		}
+		report = string(data)
+	}
+
+	query := `
+		INSERT INTO sync_runs (source, triggered_by, started_at, finished_at, status, error, report)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`
+	return r.db.QueryRowContext(ctx, query, run.Source, run.Trigger, formatTime(run.StartedAt), formatTime(run.FinishedAt),
+		run.Status, run.Error, report).Scan(&run.ID)
+}
+
+func (r *SyncRunRepo) ListRuns(ctx context.Context, limit int) ([]domain.SyncRun, error) {
+	query := `
+		SELECT id, source, triggered_by, started_at, finished_at, status, error, report
+		FROM sync_runs
+		ORDER BY id DESC
+		LIMIT ?
+	`
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	runs := []domain.SyncRun{}
+	for rows.Next() {
+		var run domain.SyncRun
+		var report sql.NullString
+		if err := rows.Scan(&run.ID, &run.Source, &run.Trigger, &run.StartedAt, &run.FinishedAt, &run.Status, &run.Error, &report); err != nil {
+			return nil, err
+		}
+		if report.Valid {
+			if err := json.Unmarshal([]byte(report.String), &run.Report); err != nil {
+				return nil, err
+			}
+		}
+		runs = append(runs, run)
+	}
+
+	return runs, rows.Err()
+}
+
+// WithLock takes a mutex. The database file belongs to a single process, the
+// way the one-connection pool already assumes, so there is nobody else to
+// coordinate with.
+func (r *SyncRunRepo) WithLock(ctx context.Context, fn func() error) (bool, error) {
+	if !r.lock.TryLock() {
+		return false, nil
+	}
+	defer r.lock.Unlock()
+	return true, fn()
+}
@@ -0,0 +1,163 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"strings"
+)
+
+type TeamRepo struct {
+	db *sql.DB
+}
+
+func NewTeamRepo(db *sql.DB) *TeamRepo {
+	return &TeamRepo{db: db}
+}
+
+func (r *TeamRepo) CreateTeam(ctx context.Context, teamName string) error {
+	query := `INSERT INTO teams (team_name) VALUES (?)`
+	_, err := r.db.ExecContext(ctx, query, teamName)
+	return err
+}
+
+// ImportTeams reads the import's teams and users, plans the import against
+// them and writes the plan in the same transaction. The single connection
+// keeps other writes out until it commits.
+func (r *TeamRepo) ImportTeams(ctx context.Context, teams []domain.Team, plan func(domain.ImportSnapshot) (*domain.ImportPlan, error)) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	current, err := readImport(ctx, tx, teams)
+	if err != nil {
+		return err
+	}
+	changes, err := plan(current)
+	if err != nil || changes == nil {
+		return err
+	}
+
+	for _, teamName := range changes.Teams {
+		query := `INSERT INTO teams (team_name) VALUES (?) ON CONFLICT DO NOTHING`
+		if _, err := tx.ExecContext(ctx, query, teamName); err != nil {
+			return err
+		}
+	}
+
+	for i := range changes.Users {
+		if err := upsertUser(ctx, tx, &changes.Users[i]); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// readImport reads the stored state of the listed teams and users.
+func readImport(ctx context.Context, tx *sql.Tx, teams []domain.Team) (domain.ImportSnapshot, error) {
+	teamNames, userIDs := domain.ImportScope(teams)
+	current := domain.ImportSnapshot{Teams: make(map[string]bool), Users: make(map[string]domain.User)}
+
+	var args queryArgs
+	teamPlaceholders := make([]string, len(teamNames))
+	for i, teamName := range teamNames {
+		teamPlaceholders[i] = args.add(teamName)
+	}
+	userPlaceholders := make([]string, len(userIDs))
+	for i, userID := range userIDs {
+		userPlaceholders[i] = args.add(userID)
+	}
+	inTeams := strings.Join(teamPlaceholders, ", ")
+	inUsers := strings.Join(userPlaceholders, ", ")
+
+	rows, err := tx.QueryContext(ctx, `SELECT team_name FROM teams WHERE team_name IN (`+inTeams+`)`, args.values...)
+	if err != nil {
+		return current, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var teamName string
+		if err := rows.Scan(&teamName); err != nil {
+			return current, err
+		}
+		current.Teams[teamName] = true
+	}
+	if err := rows.Err(); err != nil {
+		return current, err
+	}
+
+	query := `
+		SELECT user_id, username, team_name, is_active
+		FROM users
+		WHERE user_id IN (` + inUsers + `) OR team_name IN (` + inTeams + `)
+	`
+	userRows, err := tx.QueryContext(ctx, query, args.values...)
+	if err != nil {
+		return current, err
+	}
+	defer userRows.Close()
+	for userRows.Next() {
+		var user domain.User
+		if err := userRows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive); err != nil {
+			return current, err
+		}
+		current.Users[user.UserID] = user
+	}
+
+	return current, userRows.Err()
+}
+
+func (r *TeamRepo) TeamExists(ctx context.Context, teamName string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = ?)`
+	var exists bool
+	err := r.db.QueryRowContext(ctx, query, teamName).Scan(&exists)
+	return exists, err
+}
+
+func (r *TeamRepo) GetTeam(ctx context.Context, teamName string) (*domain.Team, error) {
+	exists, err := r.TeamExists(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, domain.ErrTeamNotFound
+	}
+
+	query := `
+		SELECT u.user_id, u.username, u.is_active
+		FROM users u
+		WHERE u.team_name = ?
+		ORDER BY u.user_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []domain.TeamMember
+	for rows.Next() {
+		var member domain.TeamMember
+		if err := rows.Scan(&member.UserID, &member.Username, &member.IsActive); err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if members == nil {
+		members = []domain.TeamMember{}
+	}
+
+	return &domain.Team{
+		TeamName: teamName,
+		Members:  members,
+	}, nil
+}
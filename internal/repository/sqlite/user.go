@@ -0,0 +1,202 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+)
+
+type UserRepo struct {
+	db *sql.DB
+}
+
+func NewUserRepo(db *sql.DB) *UserRepo {
+	return &UserRepo{db: db}
+}
+
+func (r *UserRepo) CreateOrUpdateUser(ctx context.Context, user *domain.User) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := upsertUser(ctx, tx, user); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *UserRepo) GetUser(ctx context.Context, userID string) (*domain.User, error) {
+	query := `
+		SELECT user_id, username, team_name, is_active, version
+		FROM users
+		WHERE user_id = ?
+	`
+
+	var user domain.User
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&user.UserID,
+		&user.Username,
+		&user.TeamName,
+		&user.IsActive,
+		&user.Version,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	users := []domain.User{user}
+	if err := loadSkills(ctx, r.db, users); err != nil {
+		return nil, err
+	}
+	return &users[0], nil
+}
+
+func (r *UserRepo) GetUsersByTeam(ctx context.Context, teamName string) ([]domain.User, error) {
+	query := `
+		SELECT user_id, username, team_name, is_active, version
+		FROM users
+		WHERE team_name = ?
+		ORDER BY user_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []domain.User
+	for rows.Next() {
+		var user domain.User
+		if err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.Version); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := loadSkills(ctx, r.db, users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *UserRepo) SetIsActive(ctx context.Context, userID string, isActive bool) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	wasActive, err := lockActivity(ctx, tx, userID)
+	if err == sql.ErrNoRows {
+		return domain.ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := bumpUserVersion(ctx, tx, userID); err != nil {
+		return err
+	}
+
+	query := `UPDATE users SET is_active = ?, updated_at = ` + nowExpr + ` WHERE user_id = ?`
+	if _, err := tx.ExecContext(ctx, query, isActive, userID); err != nil {
+		return err
+	}
+
+	if wasActive != isActive {
+		if err := logActivity(ctx, tx, userID, isActive); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// lockActivity reads the current is_active flag. SQLite has no row locks;
+// the single connection already serializes the surrounding transaction.
+// upsertUser writes the user and logs an activity transition when the user
+// is new or is_active changes.
+func upsertUser(ctx context.Context, tx *sql.Tx, user *domain.User) error {
+	wasActive, err := lockActivity(ctx, tx, user.UserID)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	changed := err == sql.ErrNoRows || wasActive != user.IsActive
+
+	query := `
+		INSERT INTO users (user_id, username, team_name, is_active, updated_at)
+		VALUES (?, ?, ?, ?, ` + nowExpr + `)
+		ON CONFLICT (user_id)
+		DO UPDATE SET username = excluded.username, team_name = excluded.team_name,
+			is_active = excluded.is_active, updated_at = excluded.updated_at,
+			version = users.version + 1
+	`
+	if _, err := tx.ExecContext(ctx, query, user.UserID, user.Username, user.TeamName, user.IsActive); err != nil {
+		return err
+	}
+
+	if changed {
+		if err := logActivity(ctx, tx, user.UserID, user.IsActive); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func lockActivity(ctx context.Context, tx *sql.Tx, userID string) (bool, error) {
+	var isActive bool
+	err := tx.QueryRowContext(ctx, `SELECT is_active FROM users WHERE user_id = ?`, userID).Scan(&isActive)
+	return isActive, err
+}
+
+// logActivity records an is_active transition so that the fairness report
+// can tell how long each member was available for review.
+func logActivity(ctx context.Context, tx *sql.Tx, userID string, isActive bool) error {
+	query := `INSERT INTO user_activity_log (user_id, is_active) VALUES (?, ?)`
+	_, err := tx.ExecContext(ctx, query, userID, isActive)
+	return err
+}
+
+func (r *UserRepo) GetActiveTeamMembers(ctx context.Context, teamName string, excludeUserID string) ([]domain.User, error) {
+	query := `
+		SELECT user_id, username, team_name, is_active, version
+		FROM users
+		WHERE team_name = ? AND is_active = 1 AND user_id != ?
+		ORDER BY user_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, teamName, excludeUserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []domain.User
+	for rows.Next() {
+		var user domain.User
+		if err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.Version); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := loadSkills(ctx, r.db, users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
@@ -0,0 +1,40 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+)
+
+func bumpUserVersion(ctx context.Context, tx *sql.Tx, userID string) error {
+	return bumpVersion(ctx, tx, `UPDATE users SET version = version + 1 WHERE user_id = ?`, userID)
+}
+
+func bumpPRVersion(ctx context.Context, tx *sql.Tx, prID string) error {
+	return bumpVersion(ctx, tx, `UPDATE pull_requests SET version = version + 1 WHERE pull_request_id = ?`, prID)
+}
+
+// bumpVersion increments the row's version. When ctx carries an expected
+// version the row must still be at it, otherwise the write fails with
+// ErrPreconditionFailed.
+func bumpVersion(ctx context.Context, tx *sql.Tx, query, id string) error {
+	expected, guarded := domain.ExpectedVersion(ctx)
+	if !guarded {
+		_, err := tx.ExecContext(ctx, query, id)
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, query+` AND version = ?`, id, expected)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrPreconditionFailed
+	}
+	return nil
+}
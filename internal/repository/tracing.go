@@ -0,0 +1,292 @@
+package repository
+
+import (
+	"context"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/avito-test/pr-reviewer-service/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"time"
+)
+
+// WithTracing wraps every repository method in a span named
+// "<system>.<Repo>.<Method>", tagged with db.system and the PR, user and team
+// the call is about.
+func WithTracing(repo *Repository, system string) *Repository {
+	t := tracer{system: system}
+	return &Repository{
+		Team:        &tracedTeamRepo{next: repo.Team, t: t},
+		User:        &tracedUserRepo{next: repo.User, t: t},
+		PullRequest: &tracedPullRequestRepo{next: repo.PullRequest, t: t},
+		Statistics:  &tracedStatisticsRepo{next: repo.Statistics, t: t},
+		Exclusion:   &tracedExclusionRepo{next: repo.Exclusion, t: t},
+		SyncRun:     &tracedSyncRunRepo{next: repo.SyncRun, t: t},
+	}
+}
+
+type tracer struct {
+	system string
+}
+
+func (t tracer) start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs, attribute.String("db.system", t.system))
+	return tracing.Start(ctx, t.system+"."+name, attrs...)
+}
+
+type tracedTeamRepo struct {
+	next TeamRepository
+	t    tracer
+}
+
+func (r *tracedTeamRepo) CreateTeam(ctx context.Context, teamName string) (err error) {
+	ctx, span := r.t.start(ctx, "TeamRepo.CreateTeam", tracing.TeamName(teamName))
+	defer func() { tracing.End(span, err) }()
+	return r.next.CreateTeam(ctx, teamName)
+}
+
+func (r *tracedTeamRepo) TeamExists(ctx context.Context, teamName string) (_ bool, err error) {
+	ctx, span := r.t.start(ctx, "TeamRepo.TeamExists", tracing.TeamName(teamName))
+	defer func() { tracing.End(span, err) }()
+	return r.next.TeamExists(ctx, teamName)
+}
+
+func (r *tracedTeamRepo) GetTeam(ctx context.Context, teamName string) (_ *domain.Team, err error) {
+	ctx, span := r.t.start(ctx, "TeamRepo.GetTeam", tracing.TeamName(teamName))
+	defer func() { tracing.End(span, err) }()
+	return r.next.GetTeam(ctx, teamName)
+}
+
+func (r *tracedTeamRepo) ImportTeams(ctx context.Context, teams []domain.Team, plan func(domain.ImportSnapshot) (*domain.ImportPlan, error)) (err error) {
+	ctx, span := r.t.start(ctx, "TeamRepo.ImportTeams", attribute.Int("import.teams", len(teams)))
+	defer func() { tracing.End(span, err) }()
+	return r.next.ImportTeams(ctx, teams, func(current domain.ImportSnapshot) (*domain.ImportPlan, error) {
+		p, err := plan(current)
+		if p != nil {
+			span.SetAttributes(attribute.Int("import.created_teams", len(p.Teams)), attribute.Int("import.users", len(p.Users)))
+		}
+		return p, err
+	})
+}
+
+type tracedUserRepo struct {
+	next UserRepository
+	t    tracer
+}
+
+func (r *tracedUserRepo) CreateOrUpdateUser(ctx context.Context, user *domain.User) (err error) {
+	ctx, span := r.t.start(ctx, "UserRepo.CreateOrUpdateUser", tracing.UserID(user.UserID), tracing.TeamName(user.TeamName))
+	defer func() { tracing.End(span, err) }()
+	return r.next.CreateOrUpdateUser(ctx, user)
+}
+
+func (r *tracedUserRepo) GetUser(ctx context.Context, userID string) (_ *domain.User, err error) {
+	ctx, span := r.t.start(ctx, "UserRepo.GetUser", tracing.UserID(userID))
+	defer func() { tracing.End(span, err) }()
+	return r.next.GetUser(ctx, userID)
+}
+
+func (r *tracedUserRepo) GetUsersByTeam(ctx context.Context, teamName string) (_ []domain.User, err error) {
+	ctx, span := r.t.start(ctx, "UserRepo.GetUsersByTeam", tracing.TeamName(teamName))
+	defer func() { tracing.End(span, err) }()
+	return r.next.GetUsersByTeam(ctx, teamName)
+}
+
+func (r *tracedUserRepo) SetIsActive(ctx context.Context, userID string, isActive bool) (err error) {
+	ctx, span := r.t.start(ctx, "UserRepo.SetIsActive", tracing.UserID(userID), attribute.Bool("user.is_active", isActive))
+	defer func() { tracing.End(span, err) }()
+	return r.next.SetIsActive(ctx, userID, isActive)
+}
+
+func (r *tracedUserRepo) SetSkills(ctx context.Context, userID string, skills []string) (err error) {
+	ctx, span := r.t.start(ctx, "UserRepo.SetSkills", tracing.UserID(userID), attribute.StringSlice("user.skills", skills))
+	defer func() { tracing.End(span, err) }()
+	return r.next.SetSkills(ctx, userID, skills)
+}
+
+func (r *tracedUserRepo) GetActiveTeamMembers(ctx context.Context, teamName string, excludeUserID string) (_ []domain.User, err error) {
+	ctx, span := r.t.start(ctx, "UserRepo.GetActiveTeamMembers", tracing.TeamName(teamName), tracing.UserID(excludeUserID))
+	defer func() { tracing.End(span, err) }()
+	return r.next.GetActiveTeamMembers(ctx, teamName, excludeUserID)
+}
+
+type tracedPullRequestRepo struct {
+	next PullRequestRepository
+	t    tracer
+}
+
+func (r *tracedPullRequestRepo) CreatePR(ctx context.Context, pr *domain.PullRequest) (err error) {
+	ctx, span := r.t.start(ctx, "PullRequestRepo.CreatePR", tracing.PRID(pr.PullRequestID), tracing.UserID(pr.AuthorID))
+	defer func() { tracing.End(span, err) }()
+	return r.next.CreatePR(ctx, pr)
+}
+
+func (r *tracedPullRequestRepo) GetPR(ctx context.Context, prID string) (_ *domain.PullRequest, err error) {
+	ctx, span := r.t.start(ctx, "PullRequestRepo.GetPR", tracing.PRID(prID))
+	defer func() { tracing.End(span, err) }()
+	return r.next.GetPR(ctx, prID)
+}
+
+func (r *tracedPullRequestRepo) GetPRDetails(ctx context.Context, prIDs []string) (_ []domain.PullRequestDetails, err error) {
+	ctx, span := r.t.start(ctx, "PullRequestRepo.GetPRDetails", attribute.StringSlice("pr.ids", prIDs))
+	defer func() { tracing.End(span, err) }()
+	return r.next.GetPRDetails(ctx, prIDs)
+}
+
+func (r *tracedPullRequestRepo) PRExists(ctx context.Context, prID string) (_ bool, err error) {
+	ctx, span := r.t.start(ctx, "PullRequestRepo.PRExists", tracing.PRID(prID))
+	defer func() { tracing.End(span, err) }()
+	return r.next.PRExists(ctx, prID)
+}
+
+func (r *tracedPullRequestRepo) MergePR(ctx context.Context, prID string) (err error) {
+	ctx, span := r.t.start(ctx, "PullRequestRepo.MergePR", tracing.PRID(prID))
+	defer func() { tracing.End(span, err) }()
+	return r.next.MergePR(ctx, prID)
+}
+
+func (r *tracedPullRequestRepo) AssignReviewer(ctx context.Context, prID, userID, reason string, maxReviewers int) (err error) {
+	ctx, span := r.t.start(ctx, "PullRequestRepo.AssignReviewer", tracing.PRID(prID), tracing.UserID(userID))
+	defer func() { tracing.End(span, err) }()
+	return r.next.AssignReviewer(ctx, prID, userID, reason, maxReviewers)
+}
+
+func (r *tracedPullRequestRepo) UnassignReviewer(ctx context.Context, prID, userID, reason string) (err error) {
+	ctx, span := r.t.start(ctx, "PullRequestRepo.UnassignReviewer", tracing.PRID(prID), tracing.UserID(userID))
+	defer func() { tracing.End(span, err) }()
+	return r.next.UnassignReviewer(ctx, prID, userID, reason)
+}
+
+func (r *tracedPullRequestRepo) ReplaceReviewer(ctx context.Context, prID, oldUserID, newUserID, reason string) (err error) {
+	ctx, span := r.t.start(ctx, "PullRequestRepo.ReplaceReviewer", tracing.PRID(prID), tracing.UserID(oldUserID), attribute.String("user.new_id", newUserID))
+	defer func() { tracing.End(span, err) }()
+	return r.next.ReplaceReviewer(ctx, prID, oldUserID, newUserID, reason)
+}
+
+func (r *tracedPullRequestRepo) DeclineReviewer(ctx context.Context, prID, userID, newUserID, reason string) (err error) {
+	ctx, span := r.t.start(ctx, "PullRequestRepo.DeclineReviewer", tracing.PRID(prID), tracing.UserID(userID), attribute.String("user.new_id", newUserID))
+	defer func() { tracing.End(span, err) }()
+	return r.next.DeclineReviewer(ctx, prID, userID, newUserID, reason)
+}
+
+func (r *tracedPullRequestRepo) GetDeclinedReviewers(ctx context.Context, prID string) (_ []string, err error) {
+	ctx, span := r.t.start(ctx, "PullRequestRepo.GetDeclinedReviewers", tracing.PRID(prID))
+	defer func() { tracing.End(span, err) }()
+	return r.next.GetDeclinedReviewers(ctx, prID)
+}
+
+func (r *tracedPullRequestRepo) GetAssignmentHistory(ctx context.Context, prID string) (_ []domain.AssignmentEvent, err error) {
+	ctx, span := r.t.start(ctx, "PullRequestRepo.GetAssignmentHistory", tracing.PRID(prID))
+	defer func() { tracing.End(span, err) }()
+	return r.next.GetAssignmentHistory(ctx, prID)
+}
+
+func (r *tracedPullRequestRepo) IsReviewerAssigned(ctx context.Context, prID, userID string) (_ bool, err error) {
+	ctx, span := r.t.start(ctx, "PullRequestRepo.IsReviewerAssigned", tracing.PRID(prID), tracing.UserID(userID))
+	defer func() { tracing.End(span, err) }()
+	return r.next.IsReviewerAssigned(ctx, prID, userID)
+}
+
+func (r *tracedPullRequestRepo) ListPRs(ctx context.Context, filter domain.PRFilter, after *domain.PRCursor, limit int) (_ []domain.PullRequestShort, _ *domain.PRCursor, err error) {
+	ctx, span := r.t.start(ctx, "PullRequestRepo.ListPRs", append(filterAttributes(filter), attribute.Int("page.limit", limit))...)
+	defer func() { tracing.End(span, err) }()
+	return r.next.ListPRs(ctx, filter, after, limit)
+}
+
+func (r *tracedPullRequestRepo) StreamPRs(ctx context.Context, filter domain.PRFilter, fn func(domain.PullRequestDetails) error) (err error) {
+	ctx, span := r.t.start(ctx, "PullRequestRepo.StreamPRs", filterAttributes(filter)...)
+	defer func() { tracing.End(span, err) }()
+	return r.next.StreamPRs(ctx, filter, fn)
+}
+
+func (r *tracedPullRequestRepo) StreamAssignmentHistory(ctx context.Context, filter domain.PRFilter, fn func(domain.AssignmentEvent) error) (err error) {
+	ctx, span := r.t.start(ctx, "PullRequestRepo.StreamAssignmentHistory", filterAttributes(filter)...)
+	defer func() { tracing.End(span, err) }()
+	return r.next.StreamAssignmentHistory(ctx, filter, fn)
+}
+
+// filterAttributes records the filter fields that name a user or team.
+func filterAttributes(filter domain.PRFilter) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	if filter.AuthorID != "" {
+		attrs = append(attrs, attribute.String("filter.author_id", filter.AuthorID))
+	}
+	if filter.ReviewerID != "" {
+		attrs = append(attrs, attribute.String("filter.reviewer_id", filter.ReviewerID))
+	}
+	if filter.TeamName != "" {
+		attrs = append(attrs, tracing.TeamName(filter.TeamName))
+	}
+	return attrs
+}
+
+type tracedStatisticsRepo struct {
+	next StatisticsRepository
+	t    tracer
+}
+
+func (r *tracedStatisticsRepo) GetUserStatistics(ctx context.Context, filter domain.StatisticsFilter) (_ []domain.UserStatistics, err error) {
+	ctx, span := r.t.start(ctx, "StatisticsRepo.GetUserStatistics", tracing.TeamName(filter.TeamName))
+	defer func() { tracing.End(span, err) }()
+	return r.next.GetUserStatistics(ctx, filter)
+}
+
+func (r *tracedStatisticsRepo) GetTeamStatistics(ctx context.Context, filter domain.StatisticsFilter) (_ []domain.TeamStatistics, err error) {
+	ctx, span := r.t.start(ctx, "StatisticsRepo.GetTeamStatistics", tracing.TeamName(filter.TeamName))
+	defer func() { tracing.End(span, err) }()
+	return r.next.GetTeamStatistics(ctx, filter)
+}
+
+func (r *tracedStatisticsRepo) GetMemberLoad(ctx context.Context, teamName string, from, to time.Time) (_ []domain.MemberLoad, err error) {
+	ctx, span := r.t.start(ctx, "StatisticsRepo.GetMemberLoad", tracing.TeamName(teamName))
+	defer func() { tracing.End(span, err) }()
+	return r.next.GetMemberLoad(ctx, teamName, from, to)
+}
+
+type tracedExclusionRepo struct {
+	next ExclusionRepository
+	t    tracer
+}
+
+func (r *tracedExclusionRepo) CreateRule(ctx context.Context, rule *domain.ExclusionRule) (err error) {
+	ctx, span := r.t.start(ctx, "ExclusionRepo.CreateRule", tracing.UserID(rule.UserID), attribute.String("exclusion.kind", string(rule.Kind)))
+	defer func() { tracing.End(span, err) }()
+	return r.next.CreateRule(ctx, rule)
+}
+
+func (r *tracedExclusionRepo) DeleteRule(ctx context.Context, ruleID int64) (err error) {
+	ctx, span := r.t.start(ctx, "ExclusionRepo.DeleteRule", attribute.Int64("exclusion.rule_id", ruleID))
+	defer func() { tracing.End(span, err) }()
+	return r.next.DeleteRule(ctx, ruleID)
+}
+
+func (r *tracedExclusionRepo) ListRules(ctx context.Context, userID string) (_ []domain.ExclusionRule, err error) {
+	ctx, span := r.t.start(ctx, "ExclusionRepo.ListRules", tracing.UserID(userID))
+	defer func() { tracing.End(span, err) }()
+	return r.next.ListRules(ctx, userID)
+}
+
+type tracedSyncRunRepo struct {
+	next SyncRunRepository
+	t    tracer
+}
+
+func (r *tracedSyncRunRepo) CreateRun(ctx context.Context, run *domain.SyncRun) (err error) {
+	ctx, span := r.t.start(ctx, "SyncRunRepo.CreateRun", attribute.String("sync.status", string(run.Status)))
+	defer func() { tracing.End(span, err) }()
+	return r.next.CreateRun(ctx, run)
+}
+
+func (r *tracedSyncRunRepo) ListRuns(ctx context.Context, limit int) (_ []domain.SyncRun, err error) {
+	ctx, span := r.t.start(ctx, "SyncRunRepo.ListRuns")
+	defer func() { tracing.End(span, err) }()
+	return r.next.ListRuns(ctx, limit)
+}
+
+func (r *tracedSyncRunRepo) WithLock(ctx context.Context, fn func() error) (acquired bool, err error) {
+	ctx, span := r.t.start(ctx, "SyncRunRepo.WithLock")
+	defer func() {
+		span.SetAttributes(attribute.Bool("sync.lock_acquired", acquired))
+		tracing.End(span, err)
+	}()
+	return r.next.WithLock(ctx, fn)
+}
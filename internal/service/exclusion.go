@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/avito-test/pr-reviewer-service/internal/repository"
+)
+
+type exclusionService struct {
+	repo *repository.Repository
+}
+
+func NewExclusionService(repo *repository.Repository) ExclusionService {
+	return &exclusionService{repo: repo}
+}
+
+// CreateRule stores a rule between two existing users. PAIR rules are
+// symmetric, so their users are stored in sorted order and a pair can only
+// be added once whichever way round it is given.
+func (s *exclusionService) CreateRule(ctx context.Context, rule domain.ExclusionRule) (*domain.ExclusionRule, error) {
+	switch rule.Kind {
+	case domain.ExclusionPair:
+		if rule.UserID > rule.OtherUserID {
+			rule.UserID, rule.OtherUserID = rule.OtherUserID, rule.UserID
+		}
+	case domain.ExclusionAuthor:
+	default:
+		return nil, domain.ErrInvalidRuleKind
+	}
+
+	if rule.UserID == "" || rule.OtherUserID == "" || rule.UserID == rule.OtherUserID {
+		return nil, domain.ErrRuleUsersInvalid
+	}
+	for _, userID := range []string{rule.UserID, rule.OtherUserID} {
+		if _, err := s.repo.User.GetUser(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.Exclusion.CreateRule(ctx, &rule); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (s *exclusionService) ListRules(ctx context.Context, userID string) ([]domain.ExclusionRule, error) {
+	return s.repo.Exclusion.ListRules(ctx, userID)
+}
+
+func (s *exclusionService) DeleteRule(ctx context.Context, ruleID int64) error {
+	if ruleID <= 0 {
+		return domain.ErrRuleIDRequired
+	}
+	return s.repo.Exclusion.DeleteRule(ctx, ruleID)
+}
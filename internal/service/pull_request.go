@@ -3,24 +3,38 @@ package service
 import (
 	"context"
 	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/avito-test/pr-reviewer-service/internal/policy"
 	"github.com/avito-test/pr-reviewer-service/internal/repository"
 	"math/rand"
+	"sort"
+	"time"
+)
+
+const (
+	maxBatchSize = 100
+	// loadWindow is how far back the least_loaded strategy counts review
+	// assignments.
+	loadWindow = 14 * 24 * time.Hour
 )
 
 type pullRequestService struct {
-	repo *repository.Repository
-	rng  *rand.Rand
+	repo     *repository.Repository
+	rng      *rand.Rand
+	observer Observer
+	policies PolicySource
 }
 
-func NewPullRequestService(repo *repository.Repository, rng *rand.Rand) PullRequestService {
+func NewPullRequestService(repo *repository.Repository, rng *rand.Rand, observer Observer, policies PolicySource) PullRequestService {
 	return &pullRequestService{
-		repo: repo,
-		rng:  rng,
+		repo:     repo,
+		rng:      rng,
+		observer: observer,
+		policies: policies,
 	}
 }
 
-func (s *pullRequestService) CreatePR(ctx context.Context, prID, prName, authorID string) (*domain.PullRequest, error) {
-	exists, err := s.repo.PullRequest.PRExists(ctx, prID)
+func (s *pullRequestService) CreatePR(ctx context.Context, input CreatePRInput) (*domain.PullRequest, error) {
+	exists, err := s.repo.PullRequest.PRExists(ctx, input.PullRequestID)
 	if err != nil {
 		return nil, err
 	}
@@ -28,33 +42,199 @@ func (s *pullRequestService) CreatePR(ctx context.Context, prID, prName, authorI
 		return nil, domain.ErrPRExists
 	}
 
-	author, err := s.repo.User.GetUser(ctx, authorID)
+	author, err := s.repo.User.GetUser(ctx, input.AuthorID)
 	if err != nil {
 		return nil, domain.ErrAuthorNotFound
 	}
 
-	activeMembers, err := s.repo.User.GetActiveTeamMembers(ctx, author.TeamName, authorID)
+	pol := s.policies.Current().For(author.TeamName)
+	if len(input.RequestedReviewers) > pol.ReviewerCount {
+		return nil, domain.ErrTooManyRequested
+	}
+
+	tags, err := domain.NormalizeTags(input.RequiredTags)
+	if err != nil {
+		return nil, err
+	}
+
+	barred, err := s.barredReviewers(ctx, author.UserID)
 	if err != nil {
 		return nil, err
 	}
 
-	reviewers := s.selectReviewers(activeMembers, 2)
+	// Requested reviewers cover whatever required tags they have; the
+	// remaining slots go first to candidates for the still uncovered tags.
+	reviewerTags := make(map[string][]string)
+	uncovered := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		uncovered[tag] = true
+	}
+
+	requested := make(map[string]bool)
+	for _, reviewerID := range input.RequestedReviewers {
+		if requested[reviewerID] {
+			return nil, domain.ErrDuplicateReviewer
+		}
+		reviewer, err := s.validateReviewer(ctx, author, reviewerID, pol, barred)
+		if err != nil {
+			return nil, err
+		}
+		requested[reviewerID] = true
+		claimTags(reviewerTags, uncovered, tags, *reviewer)
+	}
+
+	count := pol.ReviewerCount - len(input.RequestedReviewers)
+	matched, err := s.pickForTags(ctx, author, pol, requested, barred, tags, reviewerTags, uncovered, count)
+	if err != nil {
+		return nil, err
+	}
+	picked, err := s.pickReviewers(ctx, author, pol, requested, barred, count-len(matched))
+	if err != nil {
+		return nil, err
+	}
+	reviewers := append([]string{}, input.RequestedReviewers...)
+	reviewers = append(reviewers, matched...)
+	reviewers = append(reviewers, picked...)
 
 	pr := &domain.PullRequest{
-		PullRequestID:     prID,
-		PullRequestName:   prName,
-		AuthorID:          authorID,
+		PullRequestID:     input.PullRequestID,
+		PullRequestName:   input.PullRequestName,
+		AuthorID:          input.AuthorID,
 		Status:            domain.PRStatusOpen,
 		AssignedReviewers: reviewers,
+		RequiredTags:      tags,
+		ReviewerTags:      reviewerTags,
 	}
 
 	if err := s.repo.PullRequest.CreatePR(ctx, pr); err != nil {
 		return nil, err
 	}
+	s.observer.PRCreated()
+	s.observer.ReviewersAssigned(len(reviewers))
 
+	return s.repo.PullRequest.GetPR(ctx, input.PullRequestID)
+}
+
+func (s *pullRequestService) GetPR(ctx context.Context, prID string) (*domain.PullRequest, error) {
 	return s.repo.PullRequest.GetPR(ctx, prID)
 }
 
+func (s *pullRequestService) GetPRDetails(ctx context.Context, prID string) (*domain.PullRequestDetails, error) {
+	details, err := s.repo.PullRequest.GetPRDetails(ctx, []string{prID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(details) == 0 {
+		return nil, domain.ErrPRNotFound
+	}
+	return &details[0], nil
+}
+
+// GetPRDetailsBatch returns the found PRs in request order, skipping
+// duplicates, together with the IDs that do not exist.
+func (s *pullRequestService) GetPRDetailsBatch(ctx context.Context, prIDs []string) ([]domain.PullRequestDetails, []string, error) {
+	if len(prIDs) == 0 {
+		return nil, nil, domain.ErrEmptyBatch
+	}
+
+	seen := make(map[string]bool, len(prIDs))
+	unique := make([]string, 0, len(prIDs))
+	for _, prID := range prIDs {
+		if !seen[prID] {
+			seen[prID] = true
+			unique = append(unique, prID)
+		}
+	}
+
+	if len(unique) > maxBatchSize {
+		return nil, nil, domain.ErrBatchTooLarge
+	}
+
+	details, err := s.repo.PullRequest.GetPRDetails(ctx, unique)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byID := make(map[string]domain.PullRequestDetails, len(details))
+	for _, d := range details {
+		byID[d.PullRequestID] = d
+	}
+
+	found := make([]domain.PullRequestDetails, 0, len(details))
+	notFound := []string{}
+	for _, prID := range unique {
+		if d, ok := byID[prID]; ok {
+			found = append(found, d)
+		} else {
+			notFound = append(notFound, prID)
+		}
+	}
+
+	return found, notFound, nil
+}
+
+func (s *pullRequestService) ListPRs(ctx context.Context, input ListPRsInput) (*domain.PRPage, error) {
+	return listPRs(ctx, s.repo.PullRequest, input)
+}
+
+func listPRs(ctx context.Context, repo repository.PullRequestRepository, input ListPRsInput) (*domain.PRPage, error) {
+	limit := input.Limit
+	if limit == 0 {
+		limit = domain.DefaultPageSize
+	}
+	if limit < 0 || limit > domain.MaxPageSize {
+		return nil, domain.ErrInvalidPageSize
+	}
+
+	if err := validateFilter(input.Filter); err != nil {
+		return nil, err
+	}
+
+	var after *domain.PRCursor
+	if input.Cursor != "" {
+		cursor, err := domain.DecodePRCursor(input.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		after = cursor
+	}
+
+	prs, next, err := repo.ListPRs(ctx, input.Filter, after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.PRPage{PullRequests: prs}
+	if next != nil {
+		page.NextCursor = next.Encode()
+	}
+	return page, nil
+}
+
+func validateFilter(filter domain.PRFilter) error {
+	switch filter.Status {
+	case "", domain.PRStatusOpen, domain.PRStatusMerged:
+		return nil
+	default:
+		return domain.ErrInvalidStatus
+	}
+}
+
+func (s *pullRequestService) ExportPRs(ctx context.Context, filter domain.PRFilter, fn func(domain.PullRequestDetails) error) error {
+	if err := validateFilter(filter); err != nil {
+		return err
+	}
+	return s.repo.PullRequest.StreamPRs(ctx, filter, fn)
+}
+
+func (s *pullRequestService) ExportAssignmentHistory(ctx context.Context, filter domain.PRFilter, fn func(domain.AssignmentEvent) error) error {
+	if err := validateFilter(filter); err != nil {
+		return err
+	}
+	return s.repo.PullRequest.StreamAssignmentHistory(ctx, filter, fn)
+}
+
 func (s *pullRequestService) MergePR(ctx context.Context, prID string) (*domain.PullRequest, error) {
 	pr, err := s.repo.PullRequest.GetPR(ctx, prID)
 	if err != nil {
@@ -68,12 +248,13 @@ func (s *pullRequestService) MergePR(ctx context.Context, prID string) (*domain.
 	if err := s.repo.PullRequest.MergePR(ctx, prID); err != nil {
 		return nil, err
 	}
+	s.observer.PRMerged()
 
 	return s.repo.PullRequest.GetPR(ctx, prID)
 }
 
-func (s *pullRequestService) ReassignReviewer(ctx context.Context, prID, oldUserID string) (*domain.PullRequest, string, error) {
-	pr, err := s.repo.PullRequest.GetPR(ctx, prID)
+func (s *pullRequestService) ReassignReviewer(ctx context.Context, input ReassignInput) (*domain.PullRequest, string, error) {
+	pr, err := s.repo.PullRequest.GetPR(ctx, input.PullRequestID)
 	if err != nil {
 		return nil, "", err
 	}
@@ -82,7 +263,100 @@ func (s *pullRequestService) ReassignReviewer(ctx context.Context, prID, oldUser
 		return nil, "", domain.ErrPRMerged
 	}
 
-	isAssigned, err := s.repo.PullRequest.IsReviewerAssigned(ctx, prID, oldUserID)
+	isAssigned, err := s.repo.PullRequest.IsReviewerAssigned(ctx, input.PullRequestID, input.OldUserID)
+	if err != nil {
+		return nil, "", err
+	}
+	if !isAssigned {
+		return nil, "", domain.ErrNotAssigned
+	}
+
+	oldReviewer, err := s.repo.User.GetUser(ctx, input.OldUserID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	pol, err := s.policyFor(ctx, pr)
+	if err != nil {
+		return nil, "", err
+	}
+
+	declined, err := s.declinedReviewers(ctx, input.PullRequestID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	barred, err := s.barredReviewers(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	excluded := make(map[string]bool)
+	for _, userID := range input.ExcludeUserIDs {
+		excluded[userID] = true
+	}
+
+	var newReviewerID string
+	if input.NewUserID != "" {
+		if declined[input.NewUserID] {
+			return nil, "", domain.ErrReviewerDeclined
+		}
+		if err := s.validateReplacement(ctx, pr, oldReviewer, input.NewUserID, excluded, barred); err != nil {
+			return nil, "", err
+		}
+		newReviewerID = input.NewUserID
+	} else {
+		for userID := range declined {
+			excluded[userID] = true
+		}
+
+		candidates, eliminatedBy, err := s.replacementCandidates(ctx, pr, oldReviewer, pol, excluded, barred)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if len(candidates) == 0 {
+			s.observer.NoCandidate()
+			return nil, "", domain.NewNoCandidateError(eliminatedBy)
+		}
+
+		candidates = preferTagged(candidates, pr.ReviewerTags[oldReviewer.UserID])
+		chosen, err := s.selectReviewers(ctx, pol.Strategy, oldReviewer.TeamName, candidates, 1)
+		if err != nil {
+			return nil, "", err
+		}
+		newReviewerID = chosen[0]
+	}
+
+	if err := s.repo.PullRequest.ReplaceReviewer(ctx, input.PullRequestID, input.OldUserID, newReviewerID, input.Reason); err != nil {
+		return nil, "", err
+	}
+	s.observer.ReviewerReassigned()
+	s.observer.ReviewersAssigned(1)
+
+	updatedPR, err := s.repo.PullRequest.GetPR(ctx, input.PullRequestID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return updatedPR, newReviewerID, nil
+}
+
+// DeclineReview lets an assigned reviewer step down. The decline is
+// remembered so the reviewer is not picked again for this PR, and a
+// replacement is chosen with the reassignment rules. If nobody is left the
+// reviewer is still released and the returned replacement is empty.
+func (s *pullRequestService) DeclineReview(ctx context.Context, prID, userID, reason string) (*domain.PullRequest, string, error) {
+	pr, err := s.repo.PullRequest.GetPR(ctx, prID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if pr.Status == domain.PRStatusMerged {
+		return nil, "", domain.ErrPRMergedReviewers
+	}
+
+	isAssigned, err := s.repo.PullRequest.IsReviewerAssigned(ctx, prID, userID)
 	if err != nil {
 		return nil, "", err
 	}
@@ -90,11 +364,105 @@ func (s *pullRequestService) ReassignReviewer(ctx context.Context, prID, oldUser
 		return nil, "", domain.ErrNotAssigned
 	}
 
-	oldReviewer, err := s.repo.User.GetUser(ctx, oldUserID)
+	reviewer, err := s.repo.User.GetUser(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	pol, err := s.policyFor(ctx, pr)
+	if err != nil {
+		return nil, "", err
+	}
+
+	excluded, err := s.declinedReviewers(ctx, prID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	barred, err := s.barredReviewers(ctx, pr.AuthorID)
 	if err != nil {
 		return nil, "", err
 	}
 
+	candidates, _, err := s.replacementCandidates(ctx, pr, reviewer, pol, excluded, barred)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var newReviewerID string
+	if len(candidates) > 0 {
+		candidates = preferTagged(candidates, pr.ReviewerTags[reviewer.UserID])
+		chosen, err := s.selectReviewers(ctx, pol.Strategy, reviewer.TeamName, candidates, 1)
+		if err != nil {
+			return nil, "", err
+		}
+		newReviewerID = chosen[0]
+	}
+
+	if err := s.repo.PullRequest.DeclineReviewer(ctx, prID, userID, newReviewerID, reason); err != nil {
+		return nil, "", err
+	}
+	if newReviewerID != "" {
+		s.observer.ReviewerReassigned()
+		s.observer.ReviewersAssigned(1)
+	}
+
+	updatedPR, err := s.repo.PullRequest.GetPR(ctx, prID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return updatedPR, newReviewerID, nil
+}
+
+func (s *pullRequestService) declinedReviewers(ctx context.Context, prID string) (map[string]bool, error) {
+	userIDs, err := s.repo.PullRequest.GetDeclinedReviewers(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	declined := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		declined[userID] = true
+	}
+	return declined, nil
+}
+
+// barredReviewers maps every user an exclusion rule keeps off authorID's PRs
+// to the first such rule.
+func (s *pullRequestService) barredReviewers(ctx context.Context, authorID string) (map[string]domain.ExclusionRule, error) {
+	rules, err := s.repo.Exclusion.ListRules(ctx, authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	barred := make(map[string]domain.ExclusionRule)
+	for _, rule := range rules {
+		reviewerID := rule.UserID
+		if reviewerID == authorID {
+			reviewerID = rule.OtherUserID
+		}
+		if _, ok := barred[reviewerID]; !ok && rule.Forbids(reviewerID, authorID) {
+			barred[reviewerID] = rule
+		}
+	}
+	return barred, nil
+}
+
+// policyFor returns the policy of the PR author's team.
+func (s *pullRequestService) policyFor(ctx context.Context, pr *domain.PullRequest) (policy.Policy, error) {
+	author, err := s.repo.User.GetUser(ctx, pr.AuthorID)
+	if err != nil {
+		return policy.Policy{}, domain.ErrAuthorNotFound
+	}
+	return s.policies.Current().For(author.TeamName), nil
+}
+
+// replacementCandidates returns active members of the old reviewer's team who
+// are neither the author, already assigned, excluded by the policy, excluded
+// by the caller nor barred by an exclusion rule. It also returns the rules
+// that eliminated otherwise eligible members.
+func (s *pullRequestService) replacementCandidates(ctx context.Context, pr *domain.PullRequest, oldReviewer *domain.User, pol policy.Policy, excluded map[string]bool, barred map[string]domain.ExclusionRule) ([]domain.User, []domain.ExclusionRule, error) {
 	currentReviewers := make(map[string]bool)
 	for _, reviewerID := range pr.AssignedReviewers {
 		currentReviewers[reviewerID] = true
@@ -102,41 +470,332 @@ func (s *pullRequestService) ReassignReviewer(ctx context.Context, prID, oldUser
 
 	activeMembers, err := s.repo.User.GetActiveTeamMembers(ctx, oldReviewer.TeamName, "")
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 
 	var candidates []domain.User
+	var eliminatedBy []domain.ExclusionRule
 	for _, member := range activeMembers {
-		if !currentReviewers[member.UserID] && member.UserID != pr.AuthorID {
-			candidates = append(candidates, member)
+		if currentReviewers[member.UserID] || member.UserID == pr.AuthorID || excluded[member.UserID] || pol.Excludes(member.UserID) {
+			continue
+		}
+		if rule, ok := barred[member.UserID]; ok {
+			eliminatedBy = append(eliminatedBy, rule)
+			continue
 		}
+		candidates = append(candidates, member)
 	}
 
-	if len(candidates) == 0 {
-		return nil, "", domain.ErrNoCandidate
+	return candidates, eliminatedBy, nil
+}
+
+// preferTagged narrows candidates to those having the most of tags, the tags
+// the replaced reviewer covered, so that the replacement can take them over.
+// If nobody has any of them all candidates are kept.
+func preferTagged(candidates []domain.User, tags []string) []domain.User {
+	var best []domain.User
+	bestCovers := 0
+	for _, candidate := range candidates {
+		covers := 0
+		for _, tag := range tags {
+			if candidate.HasSkill(tag) {
+				covers++
+			}
+		}
+		if covers > bestCovers {
+			best, bestCovers = nil, covers
+		}
+		if covers == bestCovers && covers > 0 {
+			best = append(best, candidate)
+		}
+	}
+	if len(best) == 0 {
+		return candidates
 	}
+	return best
+}
 
-	newReviewer := candidates[s.rng.Intn(len(candidates))]
+// validateReplacement applies the replacementCandidates rules to an explicitly
+// requested user and reports which rule rejected them.
+func (s *pullRequestService) validateReplacement(ctx context.Context, pr *domain.PullRequest, oldReviewer *domain.User, userID string, excluded map[string]bool, barred map[string]domain.ExclusionRule) error {
+	if userID == pr.AuthorID {
+		return domain.ErrSelfReview
+	}
 
-	if err := s.repo.PullRequest.UnassignReviewer(ctx, prID, oldUserID); err != nil {
-		return nil, "", err
+	for _, reviewerID := range pr.AssignedReviewers {
+		if reviewerID == userID {
+			return domain.ErrAlreadyAssigned
+		}
 	}
 
-	if err := s.repo.PullRequest.AssignReviewer(ctx, prID, newReviewer.UserID); err != nil {
-		return nil, "", err
+	if excluded[userID] {
+		return domain.ErrReplacementExcluded
 	}
 
-	updatedPR, err := s.repo.PullRequest.GetPR(ctx, prID)
+	user, err := s.repo.User.GetUser(ctx, userID)
 	if err != nil {
-		return nil, "", err
+		return err
+	}
+
+	if user.TeamName != oldReviewer.TeamName {
+		return domain.ErrReplacementNotInTeam
+	}
+
+	if !user.IsActive {
+		return domain.ErrReviewerInactive
+	}
+
+	if rule, ok := barred[userID]; ok {
+		return domain.NewReviewerExcludedError(rule)
+	}
+
+	return nil
+}
+
+func (s *pullRequestService) AddReviewer(ctx context.Context, prID, userID string) (*domain.PullRequest, error) {
+	pr, err := s.repo.PullRequest.GetPR(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	if pr.Status == domain.PRStatusMerged {
+		return nil, domain.ErrPRMergedReviewers
+	}
+
+	for _, reviewerID := range pr.AssignedReviewers {
+		if reviewerID == userID {
+			return pr, nil
+		}
+	}
+
+	author, err := s.repo.User.GetUser(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, domain.ErrAuthorNotFound
+	}
+
+	// A cheap early answer; AssignReviewer enforces the ceiling atomically.
+	pol := s.policies.Current().For(author.TeamName)
+	if len(pr.AssignedReviewers) >= pol.ReviewerCount {
+		return nil, domain.ErrTooManyReviewers
+	}
+
+	barred, err := s.barredReviewers(ctx, author.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.validateReviewer(ctx, author, userID, pol, barred); err != nil {
+		return nil, err
+	}
+
+	declined, err := s.declinedReviewers(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if declined[userID] {
+		return nil, domain.ErrReviewerDeclined
+	}
+
+	if err := s.repo.PullRequest.AssignReviewer(ctx, prID, userID, "", pol.ReviewerCount); err != nil {
+		return nil, err
+	}
+	s.observer.ReviewersAssigned(1)
+
+	return s.repo.PullRequest.GetPR(ctx, prID)
+}
+
+func (s *pullRequestService) RemoveReviewer(ctx context.Context, prID, userID string) (*domain.PullRequest, error) {
+	pr, err := s.repo.PullRequest.GetPR(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	if pr.Status == domain.PRStatusMerged {
+		return nil, domain.ErrPRMergedReviewers
+	}
+
+	isAssigned, err := s.repo.PullRequest.IsReviewerAssigned(ctx, prID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isAssigned {
+		return nil, domain.ErrNotAssigned
+	}
+
+	if err := s.repo.PullRequest.UnassignReviewer(ctx, prID, userID, ""); err != nil {
+		return nil, err
+	}
+
+	return s.repo.PullRequest.GetPR(ctx, prID)
+}
+
+func (s *pullRequestService) GetAssignmentHistory(ctx context.Context, prID string) ([]domain.AssignmentEvent, error) {
+	if _, err := s.repo.PullRequest.GetPR(ctx, prID); err != nil {
+		return nil, err
 	}
 
-	return updatedPR, newReviewer.UserID, nil
+	return s.repo.PullRequest.GetAssignmentHistory(ctx, prID)
 }
 
-func (s *pullRequestService) selectReviewers(candidates []domain.User, maxCount int) []string {
-	if len(candidates) == 0 {
-		return []string{}
+// validateReviewer checks that a manually chosen reviewer comes from where
+// automatic picks would: an active member of the author's team or one of
+// the policy's fallback teams, other than the author. Policy exclusions only
+// apply to automatic picks; exclusion rules apply to both.
+func (s *pullRequestService) validateReviewer(ctx context.Context, author *domain.User, userID string, pol policy.Policy, barred map[string]domain.ExclusionRule) (*domain.User, error) {
+	if userID == author.UserID {
+		return nil, domain.ErrSelfReview
+	}
+
+	reviewer, err := s.repo.User.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if reviewer.TeamName != author.TeamName && !containsTeam(pol.FallbackTeams, reviewer.TeamName) {
+		return nil, domain.ErrReviewerNotInTeam
+	}
+
+	if !reviewer.IsActive {
+		return nil, domain.ErrReviewerInactive
+	}
+
+	if rule, ok := barred[userID]; ok {
+		return nil, domain.NewReviewerExcludedError(rule)
+	}
+
+	return reviewer, nil
+}
+
+// pickReviewers chooses up to count reviewers from the author's team and
+// then from the policy's fallback teams in order, skipping taken users, the
+// policy's exclusions and users barred by exclusion rules. Picked users are
+// added to taken.
+func (s *pullRequestService) pickReviewers(ctx context.Context, author *domain.User, pol policy.Policy, taken map[string]bool, barred map[string]domain.ExclusionRule, count int) ([]string, error) {
+	picked := []string{}
+	teams := append([]string{author.TeamName}, pol.FallbackTeams...)
+	for _, team := range teams {
+		if len(picked) >= count {
+			break
+		}
+
+		candidates, err := s.eligibleMembers(ctx, author, pol, team, taken, barred)
+		if err != nil {
+			return nil, err
+		}
+
+		chosen, err := s.selectReviewers(ctx, pol.Strategy, team, candidates, count-len(picked))
+		if err != nil {
+			return nil, err
+		}
+		for _, userID := range chosen {
+			taken[userID] = true
+		}
+		picked = append(picked, chosen...)
+	}
+
+	return picked, nil
+}
+
+// pickForTags picks up to count reviewers so that each uncovered required tag
+// gets one who has it. Like pickReviewers it searches the author's team
+// before the fallback teams; within a team the candidates covering the most
+// uncovered tags are preferred. Tags nobody eligible has stay uncovered.
+func (s *pullRequestService) pickForTags(ctx context.Context, author *domain.User, pol policy.Policy, taken map[string]bool, barred map[string]domain.ExclusionRule, tags []string, reviewerTags map[string][]string, uncovered map[string]bool, count int) ([]string, error) {
+	picked := []string{}
+	teams := append([]string{author.TeamName}, pol.FallbackTeams...)
+	members := make(map[string][]domain.User)
+	for _, tag := range tags {
+		if len(picked) >= count {
+			break
+		}
+		if !uncovered[tag] {
+			continue
+		}
+
+		for _, team := range teams {
+			candidates, ok := members[team]
+			if !ok {
+				var err error
+				candidates, err = s.eligibleMembers(ctx, author, pol, team, taken, barred)
+				if err != nil {
+					return nil, err
+				}
+				members[team] = candidates
+			}
+
+			var best []domain.User
+			bestCovers := 0
+			for _, candidate := range candidates {
+				if taken[candidate.UserID] || !candidate.HasSkill(tag) {
+					continue
+				}
+				covers := 0
+				for _, skill := range candidate.Skills {
+					if uncovered[skill] {
+						covers++
+					}
+				}
+				if covers > bestCovers {
+					best, bestCovers = nil, covers
+				}
+				if covers == bestCovers {
+					best = append(best, candidate)
+				}
+			}
+			if len(best) == 0 {
+				continue
+			}
+
+			chosen, err := s.selectReviewers(ctx, pol.Strategy, team, best, 1)
+			if err != nil {
+				return nil, err
+			}
+			for _, candidate := range best {
+				if candidate.UserID == chosen[0] {
+					claimTags(reviewerTags, uncovered, tags, candidate)
+				}
+			}
+			taken[chosen[0]] = true
+			picked = append(picked, chosen[0])
+			break
+		}
+	}
+
+	return picked, nil
+}
+
+// claimTags records the uncovered tags the reviewer has as covered by them.
+func claimTags(reviewerTags map[string][]string, uncovered map[string]bool, tags []string, reviewer domain.User) {
+	for _, tag := range tags {
+		if uncovered[tag] && reviewer.HasSkill(tag) {
+			reviewerTags[reviewer.UserID] = append(reviewerTags[reviewer.UserID], tag)
+			delete(uncovered, tag)
+		}
+	}
+}
+
+// eligibleMembers returns the active members of team that may be picked
+// automatically for a PR by author.
+func (s *pullRequestService) eligibleMembers(ctx context.Context, author *domain.User, pol policy.Policy, team string, taken map[string]bool, barred map[string]domain.ExclusionRule) ([]domain.User, error) {
+	members, err := s.repo.User.GetActiveTeamMembers(ctx, team, author.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []domain.User
+	for _, member := range members {
+		if _, ok := barred[member.UserID]; !ok && !taken[member.UserID] && !pol.Excludes(member.UserID) {
+			candidates = append(candidates, member)
+		}
+	}
+	return candidates, nil
+}
+
+// selectReviewers picks up to maxCount of the candidates, all members of
+// team. Candidates are shuffled first so that ties are broken randomly.
+func (s *pullRequestService) selectReviewers(ctx context.Context, strategy policy.Strategy, team string, candidates []domain.User, maxCount int) ([]string, error) {
+	if len(candidates) == 0 || maxCount <= 0 {
+		return []string{}, nil
 	}
 
 	count := maxCount
@@ -152,10 +811,37 @@ func (s *pullRequestService) selectReviewers(candidates []domain.User, maxCount
 		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
 	}
 
+	if strategy == policy.StrategyLeastLoaded && count < len(shuffled) {
+		// The window end is exclusive and stores may round timestamps, so it
+		// is pushed a little into the future to count assignments made just now.
+		to := time.Now().Add(time.Second)
+		loads, err := s.repo.Statistics.GetMemberLoad(ctx, team, to.Add(-loadWindow), to)
+		if err != nil {
+			return nil, err
+		}
+
+		assignments := make(map[string]int, len(loads))
+		for _, load := range loads {
+			assignments[load.UserID] = load.Assignments
+		}
+		sort.SliceStable(shuffled, func(i, j int) bool {
+			return assignments[shuffled[i].UserID] < assignments[shuffled[j].UserID]
+		})
+	}
+
 	reviewers := make([]string, count)
 	for i := 0; i < count; i++ {
 		reviewers[i] = shuffled[i].UserID
 	}
 
-	return reviewers
+	return reviewers, nil
+}
+
+func containsTeam(teams []string, teamName string) bool {
+	for _, team := range teams {
+		if team == teamName {
+			return true
+		}
+	}
+	return false
 }
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/avito-test/pr-reviewer-service/internal/policy"
 	"github.com/avito-test/pr-reviewer-service/internal/repository"
 	"math/rand"
 	"time"
@@ -11,21 +12,98 @@ import (
 type TeamService interface {
 	CreateTeam(ctx context.Context, team *domain.Team) (*domain.Team, error)
 	GetTeam(ctx context.Context, teamName string) (*domain.Team, error)
+	AddMember(ctx context.Context, teamName string, member domain.TeamMember) (*domain.Team, error)
+	ImportTeams(ctx context.Context, teams []domain.Team, dryRun bool) (*domain.ImportReport, error)
+	// ImportTeamsChecked is ImportTeams with a check that sees the diff, and
+	// the stored state it was computed from, inside the import transaction.
+	// An error from check aborts the import; otherwise exactly the checked
+	// diff is applied.
+	ImportTeamsChecked(ctx context.Context, teams []domain.Team, dryRun bool, check ImportCheck) (*domain.ImportReport, error)
 }
 
+// ImportCheck vets an import diff before it is written.
+type ImportCheck func(report *domain.ImportReport, current domain.ImportSnapshot) error
+
 type UserService interface {
+	GetUser(ctx context.Context, userID string) (*domain.User, error)
 	SetIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error)
-	GetReviewPRs(ctx context.Context, userID string) ([]domain.PullRequestShort, error)
+	SetSkills(ctx context.Context, userID string, skills []string) (*domain.User, error)
+	GetReviewPRs(ctx context.Context, userID string, input ListPRsInput) (*domain.PRPage, error)
 }
 
 type PullRequestService interface {
-	CreatePR(ctx context.Context, prID, prName, authorID string) (*domain.PullRequest, error)
+	CreatePR(ctx context.Context, input CreatePRInput) (*domain.PullRequest, error)
+	GetPR(ctx context.Context, prID string) (*domain.PullRequest, error)
+	GetPRDetails(ctx context.Context, prID string) (*domain.PullRequestDetails, error)
+	GetPRDetailsBatch(ctx context.Context, prIDs []string) ([]domain.PullRequestDetails, []string, error)
+	ListPRs(ctx context.Context, input ListPRsInput) (*domain.PRPage, error)
 	MergePR(ctx context.Context, prID string) (*domain.PullRequest, error)
-	ReassignReviewer(ctx context.Context, prID, oldUserID string) (*domain.PullRequest, string, error)
+	ReassignReviewer(ctx context.Context, input ReassignInput) (*domain.PullRequest, string, error)
+	DeclineReview(ctx context.Context, prID, userID, reason string) (*domain.PullRequest, string, error)
+	AddReviewer(ctx context.Context, prID, userID string) (*domain.PullRequest, error)
+	RemoveReviewer(ctx context.Context, prID, userID string) (*domain.PullRequest, error)
+	GetAssignmentHistory(ctx context.Context, prID string) ([]domain.AssignmentEvent, error)
+	ExportPRs(ctx context.Context, filter domain.PRFilter, fn func(domain.PullRequestDetails) error) error
+	ExportAssignmentHistory(ctx context.Context, filter domain.PRFilter, fn func(domain.AssignmentEvent) error) error
+}
+
+type ExclusionService interface {
+	CreateRule(ctx context.Context, rule domain.ExclusionRule) (*domain.ExclusionRule, error)
+	ListRules(ctx context.Context, userID string) ([]domain.ExclusionRule, error)
+	DeleteRule(ctx context.Context, ruleID int64) error
+}
+
+type CreatePRInput struct {
+	PullRequestID      string
+	PullRequestName    string
+	AuthorID           string
+	RequestedReviewers []string
+	RequiredTags       []string
+}
+
+type ReassignInput struct {
+	PullRequestID  string
+	OldUserID      string
+	NewUserID      string
+	ExcludeUserIDs []string
+	Reason         string
+}
+
+// ListPRsInput describes one page of a pull request listing. Cursor is the
+// opaque next_cursor of the previous page; a zero Limit means the default.
+type ListPRsInput struct {
+	Filter domain.PRFilter
+	Cursor string
+	Limit  int
 }
 
 type StatisticsService interface {
-	GetStatistics(ctx context.Context) (*Statistics, error)
+	GetStatistics(ctx context.Context, filter domain.StatisticsFilter) (*Statistics, error)
+	GetFairnessReport(ctx context.Context, filter domain.FairnessFilter) (*domain.FairnessReport, error)
+}
+
+// Observer is notified of domain events after they are stored, for example
+// to count them in metrics. Implementations must be safe for concurrent use.
+type Observer interface {
+	PRCreated()
+	ReviewersAssigned(n int)
+	ReviewerReassigned()
+	NoCandidate()
+	PRMerged()
+}
+
+type nopObserver struct{}
+
+func (nopObserver) PRCreated()            {}
+func (nopObserver) ReviewersAssigned(int) {}
+func (nopObserver) ReviewerReassigned()   {}
+func (nopObserver) NoCandidate()          {}
+func (nopObserver) PRMerged()             {}
+
+// PolicySource provides the assignment policies in effect. Current is called
+// once per request, so a reload never applies halfway through one.
+type PolicySource interface {
+	Current() *policy.Snapshot
 }
 
 type Service struct {
@@ -33,15 +111,42 @@ type Service struct {
 	User        UserService
 	PullRequest PullRequestService
 	Statistics  StatisticsService
+	Exclusion   ExclusionService
+	Policies    PolicySource
 }
 
 func NewService(repo *repository.Repository) *Service {
+	return NewServiceWithObserver(repo, nopObserver{})
+}
+
+func NewServiceWithObserver(repo *repository.Repository, observer Observer) *Service {
+	return NewServiceWithOptions(repo, Options{Observer: observer})
+}
+
+// Options tunes the services; zero values select the defaults. Without
+// Policies every team gets the default policy with ReviewerCount reviewers.
+type Options struct {
+	Observer      Observer
+	ReviewerCount int
+	Policies      PolicySource
+}
+
+func NewServiceWithOptions(repo *repository.Repository, opts Options) *Service {
 	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	if opts.Observer == nil {
+		opts.Observer = nopObserver{}
+	}
+	if opts.Policies == nil {
+		// Without a path NewStore cannot fail.
+		opts.Policies, _ = policy.NewStore("", policy.Policy{ReviewerCount: opts.ReviewerCount})
+	}
 
-	return &Service{
+	return WithTracing(&Service{
 		Team:        NewTeamService(repo),
 		User:        NewUserService(repo),
-		PullRequest: NewPullRequestService(repo, rng),
+		PullRequest: NewPullRequestService(repo, rng, opts.Observer, opts.Policies),
 		Statistics:  NewStatisticsService(repo),
-	}
+		Exclusion:   NewExclusionService(repo),
+		Policies:    opts.Policies,
+	})
 }
@@ -2,12 +2,20 @@ package service
 
 import (
 	"context"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
 	"github.com/avito-test/pr-reviewer-service/internal/repository"
+	"math"
+	"time"
 )
 
+const defaultFairnessWindow = 30 * 24 * time.Hour
+
 type Statistics struct {
-	TotalPRs          int            `json:"total_prs"`
-	AssignmentsByUser map[string]int `json:"assignments_by_user"`
+	TotalPRs          int                     `json:"total_prs"`
+	AssignmentsByUser map[string]int          `json:"assignments_by_user"`
+	DeclinesByUser    map[string]int          `json:"declines_by_user"`
+	Users             []domain.UserStatistics `json:"users"`
+	Teams             []domain.TeamStatistics `json:"teams"`
 }
 
 type statisticsService struct {
@@ -18,23 +26,173 @@ func NewStatisticsService(repo *repository.Repository) StatisticsService {
 	return &statisticsService{repo: repo}
 }
 
-func (s *statisticsService) GetStatistics(ctx context.Context) (*Statistics, error) {
-	totalPRs, err := s.repo.PullRequest.GetPRsCount(ctx)
+func (s *statisticsService) GetStatistics(ctx context.Context, filter domain.StatisticsFilter) (*Statistics, error) {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, domain.ErrInvalidWindow
+	}
+
+	if filter.TeamName != "" {
+		exists, err := s.repo.Team.TeamExists(ctx, filter.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, domain.ErrTeamNotFound
+		}
+	}
+
+	users, err := s.repo.Statistics.GetUserStatistics(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	assignmentsByUser, err := s.repo.PullRequest.GetReviewersCount(ctx)
+	teams, err := s.repo.Statistics.GetTeamStatistics(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	if assignmentsByUser == nil {
-		assignmentsByUser = make(map[string]int)
+	stats := &Statistics{
+		AssignmentsByUser: make(map[string]int),
+		DeclinesByUser:    make(map[string]int),
+		Users:             users,
+		Teams:             teams,
+	}
+
+	for _, team := range teams {
+		stats.TotalPRs += team.OpenPRs + team.MergedPRs
+	}
+
+	for _, user := range users {
+		if user.Assignments > 0 {
+			stats.AssignmentsByUser[user.UserID] = user.Assignments
+		}
+		if user.Declines > 0 {
+			stats.DeclinesByUser[user.UserID] = user.Declines
+		}
+	}
+
+	return stats, nil
+}
+
+// GetFairnessReport compares each member's share of assignments with the
+// share they should have had given how long they were active in the window.
+// Members who were never active in the window are left out.
+func (s *statisticsService) GetFairnessReport(ctx context.Context, filter domain.FairnessFilter) (*domain.FairnessReport, error) {
+	if filter.TeamName == "" {
+		return nil, domain.ErrTeamRequired
+	}
+
+	if filter.To.IsZero() {
+		filter.To = time.Now().UTC()
+	}
+	if filter.From.IsZero() {
+		filter.From = filter.To.Add(-defaultFairnessWindow)
+	}
+	if !filter.From.Before(filter.To) {
+		return nil, domain.ErrInvalidWindow
+	}
+
+	threshold := domain.DefaultFairnessThreshold
+	if filter.Threshold != nil {
+		threshold = *filter.Threshold
+	}
+	if threshold < 0 || math.IsNaN(threshold) || math.IsInf(threshold, 0) {
+		return nil, domain.ErrInvalidThreshold
 	}
 
-	return &Statistics{
-		TotalPRs:          totalPRs,
-		AssignmentsByUser: assignmentsByUser,
-	}, nil
+	exists, err := s.repo.Team.TeamExists(ctx, filter.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, domain.ErrTeamNotFound
+	}
+
+	loads, err := s.repo.Statistics.GetMemberLoad(ctx, filter.TeamName, filter.From, filter.To)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildFairnessReport(filter, threshold, loads), nil
+}
+
+func buildFairnessReport(filter domain.FairnessFilter, threshold float64, loads []domain.MemberLoad) *domain.FairnessReport {
+	report := &domain.FairnessReport{
+		TeamName:  filter.TeamName,
+		From:      filter.From,
+		To:        filter.To,
+		Threshold: threshold,
+		Members:   []domain.FairnessMember{},
+	}
+
+	window := filter.To.Sub(filter.From).Seconds()
+	var totalActive float64
+	for _, load := range loads {
+		if load.ActiveSeconds > 0 {
+			totalActive += load.ActiveSeconds
+			report.TotalAssignments += load.Assignments
+		}
+	}
+
+	// Each member's load relative to their fair share: 1 is exactly fair.
+	var ratios []float64
+	for _, load := range loads {
+		if load.ActiveSeconds <= 0 {
+			continue
+		}
+
+		member := domain.FairnessMember{
+			UserID:         load.UserID,
+			IsActive:       load.IsActive,
+			ActiveFraction: load.ActiveSeconds / window,
+			Assignments:    load.Assignments,
+			ExpectedShare:  load.ActiveSeconds / totalActive,
+		}
+
+		if report.TotalAssignments > 0 {
+			member.ActualShare = float64(load.Assignments) / float64(report.TotalAssignments)
+			ratio := member.ActualShare / member.ExpectedShare
+			member.Deviation = ratio - 1
+			member.Flagged = math.Abs(member.Deviation) > threshold
+			ratios = append(ratios, ratio)
+		}
+
+		report.Members = append(report.Members, member)
+	}
+
+	if len(ratios) == 0 {
+		return report
+	}
+
+	report.Gini = gini(ratios)
+
+	minRatio, maxRatio := ratios[0], ratios[0]
+	for _, ratio := range ratios[1:] {
+		minRatio = math.Min(minRatio, ratio)
+		maxRatio = math.Max(maxRatio, ratio)
+	}
+	if minRatio > 0 {
+		maxMin := maxRatio / minRatio
+		report.MaxMinRatio = &maxMin
+	}
+
+	return report
+}
+
+// gini returns the Gini coefficient of values: 0 when all are equal,
+// approaching 1 when one value holds everything.
+func gini(values []float64) float64 {
+	var sum, diffs float64
+	for _, a := range values {
+		sum += a
+		for _, b := range values {
+			diffs += math.Abs(a - b)
+		}
+	}
+
+	if sum == 0 {
+		return 0
+	}
+	n := float64(len(values))
+	return diffs / (2 * n * sum)
 }
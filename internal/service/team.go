@@ -2,8 +2,11 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"github.com/avito-test/pr-reviewer-service/internal/domain"
 	"github.com/avito-test/pr-reviewer-service/internal/repository"
+	"sort"
+	"strings"
 )
 
 type teamService struct {
@@ -42,6 +45,200 @@ func (s *teamService) CreateTeam(ctx context.Context, team *domain.Team) (*domai
 	return s.repo.Team.GetTeam(ctx, team.TeamName)
 }
 
+// AddMember adds a user to an existing team, or moves them there and updates
+// their name and activity if they already exist.
+func (s *teamService) AddMember(ctx context.Context, teamName string, member domain.TeamMember) (*domain.Team, error) {
+	if member.UserID == "" {
+		return nil, domain.ErrUserIDRequired
+	}
+
+	exists, err := s.repo.Team.TeamExists(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, domain.ErrTeamNotFound
+	}
+
+	user := &domain.User{
+		UserID:   member.UserID,
+		Username: member.Username,
+		TeamName: teamName,
+		IsActive: member.IsActive,
+	}
+	if err := s.repo.User.CreateOrUpdateUser(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return s.repo.Team.GetTeam(ctx, teamName)
+}
+
 func (s *teamService) GetTeam(ctx context.Context, teamName string) (*domain.Team, error) {
 	return s.repo.Team.GetTeam(ctx, teamName)
 }
+
+// ImportTeams reconciles the stored teams with an org export. The export is
+// authoritative for the teams it lists: missing teams are created, users are
+// created, moved or updated to match, and active members of a listed team
+// who are absent from it are deactivated. Teams not in the export are left
+// alone. The diff is computed and applied in one repository transaction;
+// nothing is written on a dry run.
+func (s *teamService) ImportTeams(ctx context.Context, teams []domain.Team, dryRun bool) (*domain.ImportReport, error) {
+	return s.ImportTeamsChecked(ctx, teams, dryRun, nil)
+}
+
+func (s *teamService) ImportTeamsChecked(ctx context.Context, teams []domain.Team, dryRun bool, check ImportCheck) (*domain.ImportReport, error) {
+	if err := validateImport(teams); err != nil {
+		return nil, err
+	}
+
+	var report *domain.ImportReport
+	err := s.repo.Team.ImportTeams(ctx, teams, func(current domain.ImportSnapshot) (*domain.ImportPlan, error) {
+		var plan *domain.ImportPlan
+		report, plan = planImport(teams, current)
+		report.DryRun = dryRun
+		if check != nil {
+			if err := check(report, current); err != nil {
+				return nil, err
+			}
+		}
+		if dryRun || (len(plan.Teams) == 0 && len(plan.Users) == 0) {
+			return nil, nil
+		}
+		return plan, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// planImport diffs the export against the stored state. A user who is both
+// moved and deactivated is reported under both.
+func planImport(teams []domain.Team, current domain.ImportSnapshot) (*domain.ImportReport, *domain.ImportPlan) {
+	report := &domain.ImportReport{
+		TeamsCreated:     []string{},
+		UsersCreated:     []domain.User{},
+		UsersMoved:       []domain.UserMove{},
+		UsersUpdated:     []domain.User{},
+		UsersDeactivated: []string{},
+	}
+	plan := &domain.ImportPlan{}
+
+	imported := make(map[string]bool)
+	for _, team := range teams {
+		for _, member := range team.Members {
+			imported[member.UserID] = true
+		}
+	}
+
+	for _, team := range teams {
+		exists := current.Teams[team.TeamName]
+		if !exists {
+			report.TeamsCreated = append(report.TeamsCreated, team.TeamName)
+			plan.Teams = append(plan.Teams, team.TeamName)
+		}
+
+		for _, member := range team.Members {
+			user := domain.User{
+				UserID:   member.UserID,
+				Username: member.Username,
+				TeamName: team.TeamName,
+				IsActive: member.IsActive,
+			}
+
+			stored, ok := current.Users[member.UserID]
+			if !ok {
+				report.UsersCreated = append(report.UsersCreated, user)
+				plan.Users = append(plan.Users, user)
+				continue
+			}
+
+			moved := stored.TeamName != user.TeamName
+			deactivated := stored.IsActive && !user.IsActive
+			if moved {
+				report.UsersMoved = append(report.UsersMoved, domain.UserMove{
+					UserID:   user.UserID,
+					FromTeam: stored.TeamName,
+					ToTeam:   user.TeamName,
+				})
+			}
+			if deactivated {
+				report.UsersDeactivated = append(report.UsersDeactivated, user.UserID)
+			}
+			switch {
+			case moved || deactivated:
+			case stored.Username != user.Username || stored.IsActive != user.IsActive:
+				report.UsersUpdated = append(report.UsersUpdated, user)
+			default:
+				report.Unchanged++
+				continue
+			}
+			plan.Users = append(plan.Users, user)
+		}
+
+		if !exists {
+			continue
+		}
+		var absent []string
+		for userID, stored := range current.Users {
+			if stored.TeamName == team.TeamName && stored.IsActive && !imported[userID] {
+				absent = append(absent, userID)
+			}
+		}
+		sort.Strings(absent)
+		for _, userID := range absent {
+			member := current.Users[userID]
+			member.IsActive = false
+			report.UsersDeactivated = append(report.UsersDeactivated, userID)
+			plan.Users = append(plan.Users, member)
+		}
+	}
+
+	return report, plan
+}
+
+// validateImport reports every malformed entry at once, including users
+// listed in more than one team, which would otherwise silently end up in
+// whichever team came last.
+func validateImport(teams []domain.Team) error {
+	if len(teams) == 0 {
+		return domain.ErrEmptyImport
+	}
+
+	var problems []string
+	seenTeams := make(map[string]bool)
+	seenUsers := make(map[string]string)
+	for i, team := range teams {
+		if team.TeamName == "" {
+			problems = append(problems, fmt.Sprintf("teams[%d]: team_name is required", i))
+			continue
+		}
+		if seenTeams[team.TeamName] {
+			problems = append(problems, fmt.Sprintf("team %s is listed twice", team.TeamName))
+		}
+		seenTeams[team.TeamName] = true
+
+		for _, member := range team.Members {
+			switch {
+			case member.UserID == "":
+				problems = append(problems, fmt.Sprintf("team %s: user_id is required", team.TeamName))
+			case member.Username == "":
+				problems = append(problems, fmt.Sprintf("user %s: username is required", member.UserID))
+			}
+			if member.UserID == "" {
+				continue
+			}
+			if other, ok := seenUsers[member.UserID]; ok {
+				problems = append(problems, fmt.Sprintf("user %s is listed in both %s and %s", member.UserID, other, team.TeamName))
+				continue
+			}
+			seenUsers[member.UserID] = team.TeamName
+		}
+	}
+
+	if len(problems) > 0 {
+		return domain.NewAppError(domain.ErrCodeInvalidRequest, "invalid import: "+strings.Join(problems, "; "))
+	}
+	return nil
+}
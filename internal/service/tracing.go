@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/avito-test/pr-reviewer-service/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// WithTracing wraps every service method in a span named
+// "<Service>.<Method>" carrying the PR, user and team it acts on.
+func WithTracing(s *Service) *Service {
+	return &Service{
+		Team:        &tracedTeamService{next: s.Team},
+		User:        &tracedUserService{next: s.User},
+		PullRequest: &tracedPullRequestService{next: s.PullRequest},
+		Statistics:  &tracedStatisticsService{next: s.Statistics},
+		Exclusion:   &tracedExclusionService{next: s.Exclusion},
+		Policies:    s.Policies,
+	}
+}
+
+type tracedTeamService struct {
+	next TeamService
+}
+
+func (s *tracedTeamService) CreateTeam(ctx context.Context, team *domain.Team) (_ *domain.Team, err error) {
+	ctx, span := tracing.Start(ctx, "TeamService.CreateTeam", tracing.TeamName(team.TeamName), attribute.Int("team.members", len(team.Members)))
+	defer func() { tracing.End(span, err) }()
+	return s.next.CreateTeam(ctx, team)
+}
+
+func (s *tracedTeamService) GetTeam(ctx context.Context, teamName string) (_ *domain.Team, err error) {
+	ctx, span := tracing.Start(ctx, "TeamService.GetTeam", tracing.TeamName(teamName))
+	defer func() { tracing.End(span, err) }()
+	return s.next.GetTeam(ctx, teamName)
+}
+
+func (s *tracedTeamService) AddMember(ctx context.Context, teamName string, member domain.TeamMember) (_ *domain.Team, err error) {
+	ctx, span := tracing.Start(ctx, "TeamService.AddMember", tracing.TeamName(teamName), tracing.UserID(member.UserID))
+	defer func() { tracing.End(span, err) }()
+	return s.next.AddMember(ctx, teamName, member)
+}
+
+func (s *tracedTeamService) ImportTeams(ctx context.Context, teams []domain.Team, dryRun bool) (_ *domain.ImportReport, err error) {
+	ctx, span := tracing.Start(ctx, "TeamService.ImportTeams", attribute.Int("import.teams", len(teams)), attribute.Bool("import.dry_run", dryRun))
+	defer func() { tracing.End(span, err) }()
+	return s.next.ImportTeams(ctx, teams, dryRun)
+}
+
+func (s *tracedTeamService) ImportTeamsChecked(ctx context.Context, teams []domain.Team, dryRun bool, check ImportCheck) (_ *domain.ImportReport, err error) {
+	ctx, span := tracing.Start(ctx, "TeamService.ImportTeamsChecked", attribute.Int("import.teams", len(teams)), attribute.Bool("import.dry_run", dryRun))
+	defer func() { tracing.End(span, err) }()
+	return s.next.ImportTeamsChecked(ctx, teams, dryRun, check)
+}
+
+type tracedUserService struct {
+	next UserService
+}
+
+func (s *tracedUserService) GetUser(ctx context.Context, userID string) (_ *domain.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUser", tracing.UserID(userID))
+	defer func() { tracing.End(span, err) }()
+	return s.next.GetUser(ctx, userID)
+}
+
+func (s *tracedUserService) SetIsActive(ctx context.Context, userID string, isActive bool) (_ *domain.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.SetIsActive", tracing.UserID(userID), attribute.Bool("user.is_active", isActive))
+	defer func() { tracing.End(span, err) }()
+	return s.next.SetIsActive(ctx, userID, isActive)
+}
+
+func (s *tracedUserService) SetSkills(ctx context.Context, userID string, skills []string) (_ *domain.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.SetSkills", tracing.UserID(userID), attribute.StringSlice("user.skills", skills))
+	defer func() { tracing.End(span, err) }()
+	return s.next.SetSkills(ctx, userID, skills)
+}
+
+func (s *tracedUserService) GetReviewPRs(ctx context.Context, userID string, input ListPRsInput) (_ *domain.PRPage, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetReviewPRs", tracing.UserID(userID))
+	defer func() { tracing.End(span, err) }()
+	return s.next.GetReviewPRs(ctx, userID, input)
+}
+
+type tracedPullRequestService struct {
+	next PullRequestService
+}
+
+func (s *tracedPullRequestService) CreatePR(ctx context.Context, input CreatePRInput) (pr *domain.PullRequest, err error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.CreatePR", tracing.PRID(input.PullRequestID), tracing.UserID(input.AuthorID))
+	defer func() {
+		if pr != nil {
+			span.SetAttributes(attribute.StringSlice("pr.reviewers", pr.AssignedReviewers))
+		}
+		tracing.End(span, err)
+	}()
+	return s.next.CreatePR(ctx, input)
+}
+
+func (s *tracedPullRequestService) GetPR(ctx context.Context, prID string) (_ *domain.PullRequest, err error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.GetPR", tracing.PRID(prID))
+	defer func() { tracing.End(span, err) }()
+	return s.next.GetPR(ctx, prID)
+}
+
+func (s *tracedPullRequestService) GetPRDetails(ctx context.Context, prID string) (_ *domain.PullRequestDetails, err error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.GetPRDetails", tracing.PRID(prID))
+	defer func() { tracing.End(span, err) }()
+	return s.next.GetPRDetails(ctx, prID)
+}
+
+func (s *tracedPullRequestService) GetPRDetailsBatch(ctx context.Context, prIDs []string) (_ []domain.PullRequestDetails, _ []string, err error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.GetPRDetailsBatch", attribute.Int("pr.count", len(prIDs)))
+	defer func() { tracing.End(span, err) }()
+	return s.next.GetPRDetailsBatch(ctx, prIDs)
+}
+
+func (s *tracedPullRequestService) ListPRs(ctx context.Context, input ListPRsInput) (_ *domain.PRPage, err error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.ListPRs", tracing.TeamName(input.Filter.TeamName))
+	defer func() { tracing.End(span, err) }()
+	return s.next.ListPRs(ctx, input)
+}
+
+func (s *tracedPullRequestService) MergePR(ctx context.Context, prID string) (_ *domain.PullRequest, err error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.MergePR", tracing.PRID(prID))
+	defer func() { tracing.End(span, err) }()
+	return s.next.MergePR(ctx, prID)
+}
+
+func (s *tracedPullRequestService) ReassignReviewer(ctx context.Context, input ReassignInput) (_ *domain.PullRequest, replacedBy string, err error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.ReassignReviewer", tracing.PRID(input.PullRequestID), tracing.UserID(input.OldUserID))
+	defer func() {
+		span.SetAttributes(attribute.String("user.new_id", replacedBy))
+		tracing.End(span, err)
+	}()
+	return s.next.ReassignReviewer(ctx, input)
+}
+
+func (s *tracedPullRequestService) DeclineReview(ctx context.Context, prID, userID, reason string) (_ *domain.PullRequest, replacedBy string, err error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.DeclineReview", tracing.PRID(prID), tracing.UserID(userID))
+	defer func() {
+		span.SetAttributes(attribute.String("user.new_id", replacedBy))
+		tracing.End(span, err)
+	}()
+	return s.next.DeclineReview(ctx, prID, userID, reason)
+}
+
+func (s *tracedPullRequestService) AddReviewer(ctx context.Context, prID, userID string) (_ *domain.PullRequest, err error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.AddReviewer", tracing.PRID(prID), tracing.UserID(userID))
+	defer func() { tracing.End(span, err) }()
+	return s.next.AddReviewer(ctx, prID, userID)
+}
+
+func (s *tracedPullRequestService) RemoveReviewer(ctx context.Context, prID, userID string) (_ *domain.PullRequest, err error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.RemoveReviewer", tracing.PRID(prID), tracing.UserID(userID))
+	defer func() { tracing.End(span, err) }()
+	return s.next.RemoveReviewer(ctx, prID, userID)
+}
+
+func (s *tracedPullRequestService) GetAssignmentHistory(ctx context.Context, prID string) (_ []domain.AssignmentEvent, err error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.GetAssignmentHistory", tracing.PRID(prID))
+	defer func() { tracing.End(span, err) }()
+	return s.next.GetAssignmentHistory(ctx, prID)
+}
+
+func (s *tracedPullRequestService) ExportPRs(ctx context.Context, filter domain.PRFilter, fn func(domain.PullRequestDetails) error) (err error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.ExportPRs", tracing.TeamName(filter.TeamName))
+	defer func() { tracing.End(span, err) }()
+	return s.next.ExportPRs(ctx, filter, fn)
+}
+
+func (s *tracedPullRequestService) ExportAssignmentHistory(ctx context.Context, filter domain.PRFilter, fn func(domain.AssignmentEvent) error) (err error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.ExportAssignmentHistory", tracing.TeamName(filter.TeamName))
+	defer func() { tracing.End(span, err) }()
+	return s.next.ExportAssignmentHistory(ctx, filter, fn)
+}
+
+type tracedStatisticsService struct {
+	next StatisticsService
+}
+
+func (s *tracedStatisticsService) GetStatistics(ctx context.Context, filter domain.StatisticsFilter) (_ *Statistics, err error) {
+	ctx, span := tracing.Start(ctx, "StatisticsService.GetStatistics", tracing.TeamName(filter.TeamName))
+	defer func() { tracing.End(span, err) }()
+	return s.next.GetStatistics(ctx, filter)
+}
+
+func (s *tracedStatisticsService) GetFairnessReport(ctx context.Context, filter domain.FairnessFilter) (_ *domain.FairnessReport, err error) {
+	ctx, span := tracing.Start(ctx, "StatisticsService.GetFairnessReport", tracing.TeamName(filter.TeamName))
+	defer func() { tracing.End(span, err) }()
+	return s.next.GetFairnessReport(ctx, filter)
+}
+
+type tracedExclusionService struct {
+	next ExclusionService
+}
+
+func (s *tracedExclusionService) CreateRule(ctx context.Context, rule domain.ExclusionRule) (_ *domain.ExclusionRule, err error) {
+	ctx, span := tracing.Start(ctx, "ExclusionService.CreateRule", tracing.UserID(rule.UserID), attribute.String("exclusion.kind", string(rule.Kind)))
+	defer func() { tracing.End(span, err) }()
+	return s.next.CreateRule(ctx, rule)
+}
+
+func (s *tracedExclusionService) ListRules(ctx context.Context, userID string) (_ []domain.ExclusionRule, err error) {
+	ctx, span := tracing.Start(ctx, "ExclusionService.ListRules", tracing.UserID(userID))
+	defer func() { tracing.End(span, err) }()
+	return s.next.ListRules(ctx, userID)
+}
+
+func (s *tracedExclusionService) DeleteRule(ctx context.Context, ruleID int64) (err error) {
+	ctx, span := tracing.Start(ctx, "ExclusionService.DeleteRule", attribute.Int64("exclusion.rule_id", ruleID))
+	defer func() { tracing.End(span, err) }()
+	return s.next.DeleteRule(ctx, ruleID)
+}
@@ -14,6 +14,10 @@ func NewUserService(repo *repository.Repository) UserService {
 	return &userService{repo: repo}
 }
 
+func (s *userService) GetUser(ctx context.Context, userID string) (*domain.User, error) {
+	return s.repo.User.GetUser(ctx, userID)
+}
+
 func (s *userService) SetIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error) {
 	if err := s.repo.User.SetIsActive(ctx, userID, isActive); err != nil {
 		return nil, err
@@ -22,11 +26,24 @@ func (s *userService) SetIsActive(ctx context.Context, userID string, isActive b
 	return s.repo.User.GetUser(ctx, userID)
 }
 
-func (s *userService) GetReviewPRs(ctx context.Context, userID string) ([]domain.PullRequestShort, error) {
+func (s *userService) SetSkills(ctx context.Context, userID string, skills []string) (*domain.User, error) {
+	skills, err := domain.NormalizeTags(skills)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.User.SetSkills(ctx, userID, skills); err != nil {
+		return nil, err
+	}
+
+	return s.repo.User.GetUser(ctx, userID)
+}
+
+func (s *userService) GetReviewPRs(ctx context.Context, userID string, input ListPRsInput) (*domain.PRPage, error) {
 	_, err := s.repo.User.GetUser(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.repo.PullRequest.GetPRsByReviewer(ctx, userID)
+	input.Filter.ReviewerID = userID
+	return listPRs(ctx, s.repo.PullRequest, input)
 }
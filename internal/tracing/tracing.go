@@ -0,0 +1,88 @@
+// Package tracing configures OpenTelemetry and provides the span helpers
+// used by the handler, service and repository layers.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"os"
+)
+
+const (
+	instrumentationName = "github.com/avito-test/pr-reviewer-service"
+	serviceName         = "pr-reviewer-service"
+)
+
+// Setup installs the global tracer provider and the W3C trace context
+// propagator. exporter is "otlp" (configured by the standard
+// OTEL_EXPORTER_OTLP_* variables), "stdout" or "none". The returned
+// function flushes pending spans.
+func Setup(ctx context.Context, exporter string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var spanExporter sdktrace.SpanExporter
+	var err error
+	switch exporter {
+	case "", "none":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+		spanExporter, err = otlptracegrpc.New(ctx)
+	case "stdout", "console":
+		spanExporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	default:
+		return nil, fmt.Errorf("unknown traces exporter %q", exporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Start starts a span using the global tracer provider.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End finishes span. Domain errors are expected outcomes and are only
+// recorded as the error.code attribute; other errors mark the span failed.
+func End(span trace.Span, err error) {
+	if err != nil {
+		if appErr, ok := domain.IsAppError(err); ok {
+			span.SetAttributes(attribute.String("error.code", string(appErr.Code)))
+		} else {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+	span.End()
+}
+
+func PRID(id string) attribute.KeyValue {
+	return attribute.String("pr.id", id)
+}
+
+func UserID(id string) attribute.KeyValue {
+	return attribute.String("user.id", id)
+}
+
+func TeamName(name string) attribute.KeyValue {
+	return attribute.String("team.name", name)
+}
@@ -12,8 +12,8 @@ CREATE TABLE IF NOT EXISTS users (
     updated_at TIMESTAMP NOT NULL DEFAULT NOW()
 );
 
-CREATE INDEX idx_users_team_name ON users(team_name);
-CREATE INDEX idx_users_is_active ON users(is_active);
+CREATE INDEX IF NOT EXISTS idx_users_team_name ON users(team_name);
+CREATE INDEX IF NOT EXISTS idx_users_is_active ON users(is_active);
 
 CREATE TABLE IF NOT EXISTS pull_requests (
     pull_request_id VARCHAR(255) PRIMARY KEY,
@@ -24,8 +24,8 @@ CREATE TABLE IF NOT EXISTS pull_requests (
     merged_at TIMESTAMP
 );
 
-CREATE INDEX idx_pull_requests_author_id ON pull_requests(author_id);
-CREATE INDEX idx_pull_requests_status ON pull_requests(status);
+CREATE INDEX IF NOT EXISTS idx_pull_requests_author_id ON pull_requests(author_id);
+CREATE INDEX IF NOT EXISTS idx_pull_requests_status ON pull_requests(status);
 
 CREATE TABLE IF NOT EXISTS pr_reviewers (
     pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
@@ -34,4 +34,4 @@ CREATE TABLE IF NOT EXISTS pr_reviewers (
     PRIMARY KEY (pull_request_id, user_id)
 );
 
-CREATE INDEX idx_pr_reviewers_user_id ON pr_reviewers(user_id);
+CREATE INDEX IF NOT EXISTS idx_pr_reviewers_user_id ON pr_reviewers(user_id);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS pr_reviewer_history (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id),
+    previous_user_id VARCHAR(255) REFERENCES users(user_id),
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_pr_reviewer_history_pull_request_id ON pr_reviewer_history(pull_request_id);
+CREATE INDEX IF NOT EXISTS idx_pr_reviewer_history_previous_user_id ON pr_reviewer_history(previous_user_id);
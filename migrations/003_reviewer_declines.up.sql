@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS pr_reviewer_declines (
+    pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id),
+    reason TEXT NOT NULL,
+    declined_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (pull_request_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pr_reviewer_declines_user_id ON pr_reviewer_declines(user_id);
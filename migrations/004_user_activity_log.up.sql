@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS user_activity_log (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    is_active BOOLEAN NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_activity_log_user_id ON user_activity_log(user_id, changed_at);
+
+-- Users created before the log existed start with their current state.
+INSERT INTO user_activity_log (user_id, is_active, changed_at)
+SELECT u.user_id, u.is_active, u.created_at
+FROM users u
+WHERE NOT EXISTS (SELECT 1 FROM user_activity_log l WHERE l.user_id = u.user_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS pr_required_tags;
+DROP TABLE IF EXISTS user_skills;
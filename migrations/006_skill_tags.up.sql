@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS user_skills (
+    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    skill VARCHAR(64) NOT NULL,
+    PRIMARY KEY (user_id, skill)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_skills_skill ON user_skills(skill);
+
+CREATE TABLE IF NOT EXISTS pr_required_tags (
+    pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    tag VARCHAR(64) NOT NULL,
+    reviewer_id VARCHAR(255) REFERENCES users(user_id),
+    PRIMARY KEY (pull_request_id, tag)
+);
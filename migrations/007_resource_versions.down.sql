@@ -0,0 +1,2 @@
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS version;
+ALTER TABLE users DROP COLUMN IF EXISTS version;
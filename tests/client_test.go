@@ -0,0 +1,142 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"github.com/avito-test/pr-reviewer-service/internal/client"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/avito-test/pr-reviewer-service/internal/handler"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIntegrationClient(t *testing.T) {
+	server := httptest.NewServer(newTestRouter(t))
+	defer server.Close()
+
+	ctx := context.Background()
+	c := client.New(server.URL+"/", 5*time.Second)
+
+	expectCode := func(t *testing.T, err error, code domain.ErrorCode, status int) {
+		t.Helper()
+		var apiErr *client.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected *client.APIError, got %v", err)
+		}
+		if apiErr.Code != code || apiErr.StatusCode != status {
+			t.Fatalf("Expected %s/%d, got %s/%d", code, status, apiErr.Code, apiErr.StatusCode)
+		}
+	}
+
+	t.Run("Teams and members", func(t *testing.T) {
+		team, err := c.CreateTeam(ctx, domain.Team{
+			TeamName: "backend",
+			Members: []domain.TeamMember{
+				{UserID: "u1", Username: "Alice", IsActive: true},
+				{UserID: "u2", Username: "Bob", IsActive: true},
+			},
+		})
+		if err != nil || len(team.Members) != 2 {
+			t.Fatalf("Failed to create team: %v %+v", err, team)
+		}
+
+		_, err = c.CreateTeam(ctx, domain.Team{TeamName: "backend", Members: []domain.TeamMember{}})
+		expectCode(t, err, domain.ErrCodeTeamExists, http.StatusConflict)
+
+		team, err = c.AddMember(ctx, "backend", domain.TeamMember{UserID: "u3", Username: "Carl", IsActive: true})
+		if err != nil || len(team.Members) != 3 {
+			t.Fatalf("Failed to add member: %v %+v", err, team)
+		}
+
+		_, err = c.AddMember(ctx, "missing", domain.TeamMember{UserID: "u9", Username: "Zed", IsActive: true})
+		expectCode(t, err, domain.ErrCodeNotFound, http.StatusNotFound)
+
+		if team, err := c.GetTeam(ctx, "backend"); err != nil || team.TeamName != "backend" || len(team.Members) != 3 {
+			t.Fatalf("Failed to get team: %v %+v", err, team)
+		}
+
+		user, err := c.SetIsActive(ctx, "u3", false)
+		if err != nil || user.IsActive {
+			t.Fatalf("Failed to deactivate user: %v %+v", err, user)
+		}
+
+		if user, err := c.SetSkills(ctx, "u2", []string{"Go", "db"}); err != nil || len(user.Skills) != 2 || user.Skills[0] != "db" {
+			t.Fatalf("Failed to set skills: %v %+v", err, user)
+		}
+		_, err = c.SetSkills(ctx, "u2", []string{"bad tag"})
+		expectCode(t, err, domain.ErrCodeInvalidRequest, http.StatusBadRequest)
+	})
+
+	t.Run("Import", func(t *testing.T) {
+		export := "team_name,user_id,username\nbackend,u1,Alice\nbackend,u2,Bob\nmobile,m1,Max\n"
+		report, err := c.ImportTeams(ctx, strings.NewReader(export), "text/csv", true)
+		if err != nil || !report.DryRun || len(report.TeamsCreated) != 1 || len(report.UsersDeactivated) != 0 {
+			t.Fatalf("Unexpected dry run: %v %+v", err, report)
+		}
+
+		_, err = c.ImportTeams(ctx, strings.NewReader(`{"teams": []}`), "application/json", true)
+		expectCode(t, err, domain.ErrCodeInvalidRequest, http.StatusBadRequest)
+	})
+
+	t.Run("Exclusions", func(t *testing.T) {
+		rule, err := c.AddExclusion(ctx, handler.AddExclusionRequest{Kind: domain.ExclusionAuthor, UserID: "u3", OtherUserID: "u1"})
+		if err != nil || rule.RuleID == 0 {
+			t.Fatalf("Failed to add exclusion: %v %+v", err, rule)
+		}
+
+		if rules, err := c.ListExclusions(ctx, "u1"); err != nil || len(rules) != 1 {
+			t.Fatalf("Expected one rule, got %v %+v", err, rules)
+		}
+
+		if err := c.DeleteExclusion(ctx, rule.RuleID); err != nil {
+			t.Fatalf("Failed to delete exclusion: %v", err)
+		}
+		expectCode(t, c.DeleteExclusion(ctx, rule.RuleID), domain.ErrCodeNotFound, http.StatusNotFound)
+	})
+
+	t.Run("Pull request lifecycle", func(t *testing.T) {
+		pr, err := c.CreatePR(ctx, handler.CreatePRRequest{PullRequestID: "pr-1", PullRequestName: "Fix", AuthorID: "u1"})
+		if err != nil || len(pr.AssignedReviewers) != 1 || pr.AssignedReviewers[0] != "u2" {
+			t.Fatalf("Expected u2 as the only active reviewer, got %v %+v", err, pr)
+		}
+
+		_, _, err = c.Reassign(ctx, handler.ReassignRequest{PullRequestID: "pr-1", OldUserID: "u2"})
+		expectCode(t, err, domain.ErrCodeNoCandidate, http.StatusConflict)
+
+		details, err := c.GetPR(ctx, "pr-1")
+		if err != nil || details.AuthorTeam != "backend" || len(details.Reviewers) != 1 {
+			t.Fatalf("Failed to get PR: %v %+v", err, details)
+		}
+
+		if pr, err := c.MergePR(ctx, "pr-1"); err != nil || pr.Status != domain.PRStatusMerged {
+			t.Fatalf("Failed to merge PR: %v %+v", err, pr)
+		}
+
+		page, err := c.ListPRs(ctx, url.Values{"status": {"MERGED"}})
+		if err != nil || len(page.PullRequests) != 1 {
+			t.Fatalf("Expected one merged PR, got %v %+v", err, page)
+		}
+
+		stats, err := c.Statistics(ctx, url.Values{"team": {"backend"}})
+		if err != nil || len(stats.Teams) != 1 || stats.Teams[0].MergedPRs != 1 {
+			t.Fatalf("Unexpected statistics: %v %+v", err, stats)
+		}
+	})
+
+	t.Run("Export streams the body", func(t *testing.T) {
+		var out strings.Builder
+		if err := c.Export(ctx, "pullRequests", url.Values{"format": {"csv"}}, &out); err != nil {
+			t.Fatalf("Failed to export: %v", err)
+		}
+		if !strings.Contains(out.String(), "pr-1,Fix,u1,backend,MERGED") {
+			t.Fatalf("Unexpected export:\n%s", out.String())
+		}
+
+		err := c.Export(ctx, "pullRequests", url.Values{"format": {"xml"}}, &out)
+		expectCode(t, err, domain.ErrCodeInvalidRequest, http.StatusBadRequest)
+	})
+}
@@ -0,0 +1,225 @@
+package tests
+
+import (
+	"bytes"
+	"github.com/avito-test/pr-reviewer-service/internal/config"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/avito-test/pr-reviewer-service/internal/handler"
+	"github.com/avito-test/pr-reviewer-service/internal/service"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func envMap(values map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := values[key]
+		return value, ok
+	}
+}
+
+func TestConfig(t *testing.T) {
+	noEnv := envMap(nil)
+
+	t.Run("Defaults are valid", func(t *testing.T) {
+		cfg, err := config.Load("", noEnv)
+		if err != nil {
+			t.Fatalf("Expected defaults to load, got %v", err)
+		}
+		if cfg.Storage != "postgres" || cfg.HTTP.Port != "8080" || cfg.Reviewers.Count != 2 {
+			t.Fatalf("Unexpected defaults: %+v", cfg)
+		}
+	})
+
+	t.Run("YAML and TOML files are equivalent", func(t *testing.T) {
+		yamlPath := writeConfig(t, "config.yaml", `
+storage: sqlite
+sqlite:
+  path: /tmp/reviewer.db
+http:
+  read_timeout: 3s
+reviewers:
+  count: 3
+features:
+  grpc: false
+`)
+		tomlPath := writeConfig(t, "config.toml", `
+storage = "sqlite"
+
+[sqlite]
+path = "/tmp/reviewer.db"
+
+[http]
+read_timeout = "3s"
+
+[reviewers]
+count = 3
+
+[features]
+grpc = false
+`)
+
+		for _, path := range []string{yamlPath, tomlPath} {
+			cfg, err := config.Load(path, noEnv)
+			if err != nil {
+				t.Fatalf("Failed to load %s: %v", filepath.Base(path), err)
+			}
+			if cfg.Storage != "sqlite" || cfg.SQLite.Path != "/tmp/reviewer.db" {
+				t.Fatalf("Expected sqlite storage from %s, got %+v", filepath.Base(path), cfg)
+			}
+			if cfg.HTTP.ReadTimeout != 3*time.Second || cfg.HTTP.WriteTimeout != 10*time.Second {
+				t.Fatalf("Expected read timeout from file and default write timeout, got %+v", cfg.HTTP)
+			}
+			if cfg.Reviewers.Count != 3 || cfg.Features.GRPC || !cfg.Features.Export {
+				t.Fatalf("Unexpected values from %s: %+v", filepath.Base(path), cfg)
+			}
+		}
+	})
+
+	t.Run("Environment overrides the file", func(t *testing.T) {
+		path := writeConfig(t, "config.yaml", "http:\n  port: \"8081\"\nreviewers:\n  count: 3\n")
+
+		cfg, err := config.Load(path, envMap(map[string]string{
+			"PORT":                 "9000",
+			"SHUTDOWN_DRAIN_DELAY": "0s",
+			"FEATURE_METRICS":      "false",
+			"REVIEWER_COUNT":       "",
+		}))
+		if err != nil {
+			t.Fatalf("Failed to load config: %v", err)
+		}
+		if cfg.HTTP.Port != "9000" || cfg.Shutdown.DrainDelay != 0 || cfg.Features.Metrics {
+			t.Fatalf("Expected env overrides, got %+v", cfg)
+		}
+		if cfg.Reviewers.Count != 3 {
+			t.Fatalf("Expected empty env to keep the file value, got %d", cfg.Reviewers.Count)
+		}
+	})
+
+	t.Run("Unknown keys are rejected", func(t *testing.T) {
+		for name, content := range map[string]string{
+			"config.yaml": "http:\n  prot: \"8081\"\n",
+			"config.toml": "[http]\nprot = \"8081\"\n",
+			"config.json": "{}",
+		} {
+			if _, err := config.Load(writeConfig(t, name, content), noEnv); err == nil {
+				t.Fatalf("Expected %s to be rejected", name)
+			}
+		}
+	})
+
+	t.Run("Invalid env values name the variable", func(t *testing.T) {
+		_, err := config.Load("", envMap(map[string]string{"HTTP_IDLE_TIMEOUT": "soon"}))
+		if err == nil || !strings.Contains(err.Error(), "HTTP_IDLE_TIMEOUT") {
+			t.Fatalf("Expected error naming HTTP_IDLE_TIMEOUT, got %v", err)
+		}
+	})
+
+	t.Run("Validation reports every problem", func(t *testing.T) {
+		path := writeConfig(t, "config.yaml", `
+storage: mongo
+http:
+  port: "80a"
+grpc:
+  port: "70000"
+reviewers:
+  count: 0
+log:
+  level: loud
+sync:
+  source: https://directory.example.com/teams.json
+  max_deactivate_percent: 150
+`)
+
+		_, err := config.Load(path, noEnv)
+		if err == nil {
+			t.Fatal("Expected validation error")
+		}
+		for _, key := range []string{"storage", "http.port", "grpc.port", "reviewers.count", "log.level", "sync.max_deactivate_percent"} {
+			if !strings.Contains(err.Error(), key) {
+				t.Fatalf("Expected error to mention %s, got:\n%v", key, err)
+			}
+		}
+	})
+
+	t.Run("Print redacts secrets", func(t *testing.T) {
+		cfg, err := config.Load("", envMap(map[string]string{
+			"DB_PASSWORD": "hunter2",
+			"DB_DSN":      "postgres://app:hunter2@db/reviewer",
+			"SYNC_TOKEN":  "hunter2",
+		}))
+		if err != nil {
+			t.Fatalf("Failed to load config: %v", err)
+		}
+
+		var buf bytes.Buffer
+		if err := cfg.Print(&buf); err != nil {
+			t.Fatalf("Failed to print config: %v", err)
+		}
+		if strings.Contains(buf.String(), "hunter2") {
+			t.Fatalf("Expected secrets to be redacted, got:\n%s", buf.String())
+		}
+		if !strings.Contains(buf.String(), "password: '[REDACTED]'") {
+			t.Fatalf("Expected redacted password, got:\n%s", buf.String())
+		}
+		if cfg.Database.Password != "hunter2" {
+			t.Fatal("Expected Print to leave the config untouched")
+		}
+	})
+}
+
+func TestIntegrationReviewerCount(t *testing.T) {
+	svc := service.NewServiceWithOptions(newTestRepository(t), service.Options{ReviewerCount: 3})
+	router := handler.NewHandler(svc).InitRoutes()
+
+	createTeam(t, router, domain.Team{
+		TeamName: "platform",
+		Members: []domain.TeamMember{
+			{UserID: "p1", Username: "Pat", IsActive: true},
+			{UserID: "p2", Username: "Pia", IsActive: true},
+			{UserID: "p3", Username: "Pol", IsActive: true},
+			{UserID: "p4", Username: "Pam", IsActive: true},
+			{UserID: "p5", Username: "Pip", IsActive: true},
+		},
+	})
+
+	w := doJSON(t, router, http.MethodPost, "/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-three",
+		"pull_request_name": "Three reviewers",
+		"author_id":         "p1",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	pr := decodePR(t, w)
+	if len(pr.AssignedReviewers) != 3 {
+		t.Fatalf("Expected 3 reviewers, got %v", pr.AssignedReviewers)
+	}
+
+	var spare string
+	for _, id := range []string{"p2", "p3", "p4", "p5"} {
+		if !containsString(pr.AssignedReviewers, id) {
+			spare = id
+		}
+	}
+	w = doJSON(t, router, http.MethodPost, "/pullRequest/addReviewer", map[string]string{
+		"pull_request_id": "pr-three",
+		"user_id":         spare,
+	})
+	if w.Code != http.StatusConflict {
+		t.Fatalf("Expected status 409 for full PR, got %d", w.Code)
+	}
+}
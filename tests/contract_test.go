@@ -0,0 +1,239 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type contractCase struct {
+	name       string
+	method     string
+	path       string
+	specPath   string
+	body       interface{}
+	err        error
+	wantStatus int
+}
+
+func runContractCase(t *testing.T, spec *openAPISpec, tc contractCase) {
+	t.Helper()
+
+	router := newStubRouter(&stubService{err: tc.err})
+
+	var body bytes.Buffer
+	if s, ok := tc.body.(string); ok {
+		body.WriteString(s)
+	} else if tc.body != nil {
+		json.NewEncoder(&body).Encode(tc.body)
+	}
+
+	req := httptest.NewRequest(tc.method, tc.path, &body)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != tc.wantStatus {
+		t.Fatalf("Expected status %d, got %d. Body: %s", tc.wantStatus, w.Code, w.Body.String())
+	}
+
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		t.Fatalf("Expected JSON content type, got %q", ct)
+	}
+
+	path := tc.specPath
+	if path == "" {
+		path = strings.SplitN(tc.path, "?", 2)[0]
+	}
+	schema, err := spec.responseSchema(path, tc.method, w.Code)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var payload interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &payload); err != nil {
+		t.Fatalf("Response is not valid JSON: %v", err)
+	}
+
+	if errs := spec.validate(schema, payload, "$"); len(errs) > 0 {
+		t.Fatalf("Response does not match the spec:\n%s\nBody: %s", strings.Join(errs, "\n"), w.Body.String())
+	}
+}
+
+func TestContractResponsesMatchSpec(t *testing.T) {
+	spec := loadOpenAPISpec(t, newStubRouter(&stubService{}))
+
+	team := domain.Team{
+		TeamName: "backend",
+		Members: []domain.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+		},
+	}
+
+	cases := []contractCase{
+		{name: "create team", method: http.MethodPost, path: "/team/add", body: team, wantStatus: http.StatusCreated},
+		{name: "create team exists", method: http.MethodPost, path: "/team/add", body: team, err: domain.ErrTeamExists, wantStatus: http.StatusConflict},
+		{name: "create team invalid body", method: http.MethodPost, path: "/team/add", body: "{", wantStatus: http.StatusBadRequest},
+		{name: "get team", method: http.MethodGet, path: "/team/get?team_name=backend", wantStatus: http.StatusOK},
+		{name: "get team missing name", method: http.MethodGet, path: "/team/get", wantStatus: http.StatusBadRequest},
+		{name: "get team not found", method: http.MethodGet, path: "/team/get?team_name=none", err: domain.ErrTeamNotFound, wantStatus: http.StatusNotFound},
+		{name: "add member", method: http.MethodPost, path: "/team/addMember", body: map[string]interface{}{"team_name": "backend", "user_id": "u4", "username": "Dana", "is_active": true}, wantStatus: http.StatusOK},
+		{name: "add member team not found", method: http.MethodPost, path: "/team/addMember", body: map[string]interface{}{"team_name": "none", "user_id": "u4"}, err: domain.ErrTeamNotFound, wantStatus: http.StatusNotFound},
+		{name: "import teams", method: http.MethodPost, path: "/team/import?dry_run=true", body: map[string]interface{}{"teams": []map[string]interface{}{{"team_name": "mobile", "members": []map[string]interface{}{{"user_id": "u4", "username": "Dana", "is_active": true}}}}}, wantStatus: http.StatusOK},
+		{name: "import teams invalid", method: http.MethodPost, path: "/team/import", body: map[string]interface{}{"teams": []interface{}{}}, err: domain.ErrEmptyImport, wantStatus: http.StatusBadRequest},
+		{name: "import teams invalid dry run", method: http.MethodPost, path: "/team/import?dry_run=maybe", body: map[string]interface{}{"teams": []interface{}{}}, wantStatus: http.StatusBadRequest},
+		{name: "add exclusion", method: http.MethodPost, path: "/exclusions/add", body: map[string]string{"kind": "PAIR", "user_id": "u1", "other_user_id": "u2", "reason": "manager"}, wantStatus: http.StatusCreated},
+		{name: "add exclusion exists", method: http.MethodPost, path: "/exclusions/add", body: map[string]string{"kind": "PAIR", "user_id": "u1", "other_user_id": "u2"}, err: domain.ErrRuleExists, wantStatus: http.StatusConflict},
+		{name: "add exclusion invalid kind", method: http.MethodPost, path: "/exclusions/add", body: map[string]string{"kind": "TEAM", "user_id": "u1", "other_user_id": "u2"}, err: domain.ErrInvalidRuleKind, wantStatus: http.StatusBadRequest},
+		{name: "list exclusions", method: http.MethodGet, path: "/exclusions/list?user_id=u1", wantStatus: http.StatusOK},
+		{name: "delete exclusion", method: http.MethodPost, path: "/exclusions/delete", body: map[string]int{"rule_id": 1}, wantStatus: http.StatusOK},
+		{name: "delete exclusion not found", method: http.MethodPost, path: "/exclusions/delete", body: map[string]int{"rule_id": 9}, err: domain.ErrRuleNotFound, wantStatus: http.StatusNotFound},
+		{name: "sync runs", method: http.MethodGet, path: "/sync/runs", wantStatus: http.StatusOK},
+		{name: "sync run", method: http.MethodPost, path: "/sync/run", wantStatus: http.StatusOK},
+		{name: "set is active", method: http.MethodPost, path: "/users/setIsActive", body: map[string]interface{}{"user_id": "u1", "is_active": false}, wantStatus: http.StatusOK},
+		{name: "set is active not found", method: http.MethodPost, path: "/users/setIsActive", body: map[string]interface{}{"user_id": "x", "is_active": false}, err: domain.ErrUserNotFound, wantStatus: http.StatusNotFound},
+		{name: "set skills", method: http.MethodPost, path: "/users/setSkills", body: map[string]interface{}{"user_id": "u1", "skills": []string{"db", "go"}}, wantStatus: http.StatusOK},
+		{name: "set skills too many", method: http.MethodPost, path: "/users/setSkills", body: map[string]interface{}{"user_id": "u1", "skills": []string{"db"}}, err: domain.ErrTooManyTags, wantStatus: http.StatusBadRequest},
+		{name: "set skills not found", method: http.MethodPost, path: "/users/setSkills", body: map[string]interface{}{"user_id": "x", "skills": []string{}}, err: domain.ErrUserNotFound, wantStatus: http.StatusNotFound},
+		{name: "get review", method: http.MethodGet, path: "/users/getReview?user_id=u2", wantStatus: http.StatusOK},
+		{name: "get review missing user", method: http.MethodGet, path: "/users/getReview", wantStatus: http.StatusBadRequest},
+		{name: "create PR", method: http.MethodPost, path: "/pullRequest/create", body: map[string]string{"pull_request_id": "pr-1", "pull_request_name": "Feature", "author_id": "u1"}, wantStatus: http.StatusCreated},
+		{name: "create PR with tags", method: http.MethodPost, path: "/pullRequest/create", body: map[string]interface{}{"pull_request_id": "pr-1", "pull_request_name": "Feature", "author_id": "u1", "required_tags": []string{"db"}}, wantStatus: http.StatusCreated},
+		{name: "create PR exists", method: http.MethodPost, path: "/pullRequest/create", body: map[string]string{"pull_request_id": "pr-1"}, err: domain.ErrPRExists, wantStatus: http.StatusConflict},
+		{name: "create PR author not found", method: http.MethodPost, path: "/pullRequest/create", body: map[string]string{"pull_request_id": "pr-1"}, err: domain.ErrAuthorNotFound, wantStatus: http.StatusNotFound},
+		{name: "merge PR", method: http.MethodPost, path: "/pullRequest/merge", body: map[string]string{"pull_request_id": "pr-1"}, wantStatus: http.StatusOK},
+		{name: "merge PR not found", method: http.MethodPost, path: "/pullRequest/merge", body: map[string]string{"pull_request_id": "x"}, err: domain.ErrPRNotFound, wantStatus: http.StatusNotFound},
+		{name: "reassign", method: http.MethodPost, path: "/pullRequest/reassign", body: map[string]string{"pull_request_id": "pr-1", "old_user_id": "u2"}, wantStatus: http.StatusOK},
+		{name: "reassign merged", method: http.MethodPost, path: "/pullRequest/reassign", body: map[string]string{"pull_request_id": "pr-1", "old_user_id": "u2"}, err: domain.ErrPRMerged, wantStatus: http.StatusConflict},
+		{name: "reassign not assigned", method: http.MethodPost, path: "/pullRequest/reassign", body: map[string]string{"pull_request_id": "pr-1", "old_user_id": "u9"}, err: domain.ErrNotAssigned, wantStatus: http.StatusConflict},
+		{name: "reassign no candidate by rule", method: http.MethodPost, path: "/pullRequest/reassign", body: map[string]string{"pull_request_id": "pr-1", "old_user_id": "u2"}, err: domain.NewNoCandidateError([]domain.ExclusionRule{*stubRule()}), wantStatus: http.StatusConflict},
+		{name: "reassign no candidate", method: http.MethodPost, path: "/pullRequest/reassign", body: map[string]string{"pull_request_id": "pr-1", "old_user_id": "u2"}, err: domain.ErrNoCandidate, wantStatus: http.StatusConflict},
+		{name: "create PR with requested reviewers", method: http.MethodPost, path: "/pullRequest/create", body: map[string]interface{}{"pull_request_id": "pr-1", "pull_request_name": "Feature", "author_id": "u1", "requested_reviewers": []string{"u3"}}, wantStatus: http.StatusCreated},
+		{name: "create PR self review", method: http.MethodPost, path: "/pullRequest/create", body: map[string]interface{}{"pull_request_id": "pr-1", "author_id": "u1", "requested_reviewers": []string{"u1"}}, err: domain.ErrSelfReview, wantStatus: http.StatusBadRequest},
+		{name: "add reviewer", method: http.MethodPost, path: "/pullRequest/addReviewer", body: map[string]string{"pull_request_id": "pr-1", "user_id": "u3"}, wantStatus: http.StatusOK},
+		{name: "add reviewer limit", method: http.MethodPost, path: "/pullRequest/addReviewer", body: map[string]string{"pull_request_id": "pr-1", "user_id": "u3"}, err: domain.ErrTooManyReviewers, wantStatus: http.StatusConflict},
+		{name: "add reviewer inactive", method: http.MethodPost, path: "/pullRequest/addReviewer", body: map[string]string{"pull_request_id": "pr-1", "user_id": "u3"}, err: domain.ErrReviewerInactive, wantStatus: http.StatusBadRequest},
+		{name: "remove reviewer", method: http.MethodPost, path: "/pullRequest/removeReviewer", body: map[string]string{"pull_request_id": "pr-1", "user_id": "u2"}, wantStatus: http.StatusOK},
+		{name: "remove reviewer not assigned", method: http.MethodPost, path: "/pullRequest/removeReviewer", body: map[string]string{"pull_request_id": "pr-1", "user_id": "u9"}, err: domain.ErrNotAssigned, wantStatus: http.StatusConflict},
+		{name: "reassign to specific user", method: http.MethodPost, path: "/pullRequest/reassign", body: map[string]interface{}{"pull_request_id": "pr-1", "old_user_id": "u2", "new_user_id": "u5", "exclude_user_ids": []string{"u6"}, "reason": "vacation"}, wantStatus: http.StatusOK},
+		{name: "reassign to excluded user", method: http.MethodPost, path: "/pullRequest/reassign", body: map[string]interface{}{"pull_request_id": "pr-1", "old_user_id": "u2", "new_user_id": "u5"}, err: domain.ErrReplacementExcluded, wantStatus: http.StatusBadRequest},
+		{name: "decline", method: http.MethodPost, path: "/pullRequest/decline", body: map[string]string{"pull_request_id": "pr-1", "user_id": "u2", "reason": "conflict of interest"}, wantStatus: http.StatusOK},
+		{name: "decline missing reason", method: http.MethodPost, path: "/pullRequest/decline", body: map[string]string{"pull_request_id": "pr-1", "user_id": "u2"}, wantStatus: http.StatusBadRequest},
+		{name: "decline not assigned", method: http.MethodPost, path: "/pullRequest/decline", body: map[string]string{"pull_request_id": "pr-1", "user_id": "u9", "reason": "busy"}, err: domain.ErrNotAssigned, wantStatus: http.StatusConflict},
+		{name: "add declined reviewer", method: http.MethodPost, path: "/pullRequest/addReviewer", body: map[string]string{"pull_request_id": "pr-1", "user_id": "u2"}, err: domain.ErrReviewerDeclined, wantStatus: http.StatusBadRequest},
+		{name: "get PR", method: http.MethodGet, path: "/pullRequest/get?pull_request_id=pr-1", wantStatus: http.StatusOK},
+		{name: "get PR missing id", method: http.MethodGet, path: "/pullRequest/get", wantStatus: http.StatusBadRequest},
+		{name: "get PR not found", method: http.MethodGet, path: "/pullRequest/get?pull_request_id=x", err: domain.ErrPRNotFound, wantStatus: http.StatusNotFound},
+		{name: "get PR batch", method: http.MethodPost, path: "/pullRequest/getBatch", body: map[string][]string{"pull_request_ids": {"pr-1", "pr-404"}}, wantStatus: http.StatusOK},
+		{name: "get PR batch empty", method: http.MethodPost, path: "/pullRequest/getBatch", body: map[string][]string{"pull_request_ids": {}}, err: domain.ErrEmptyBatch, wantStatus: http.StatusBadRequest},
+		{name: "list PRs", method: http.MethodGet, path: "/pullRequest/list?status=OPEN&team_name=backend&name=fix&limit=2", wantStatus: http.StatusOK},
+		{name: "list PRs invalid limit", method: http.MethodGet, path: "/pullRequest/list?limit=abc", wantStatus: http.StatusBadRequest},
+		{name: "list PRs invalid date", method: http.MethodGet, path: "/pullRequest/list?created_from=yesterday", wantStatus: http.StatusBadRequest},
+		{name: "list PRs invalid cursor", method: http.MethodGet, path: "/pullRequest/list?cursor=x", err: domain.ErrInvalidCursor, wantStatus: http.StatusBadRequest},
+		{name: "get review with filters", method: http.MethodGet, path: "/users/getReview?user_id=u2&status=OPEN&limit=10", wantStatus: http.StatusOK},
+		{name: "history", method: http.MethodGet, path: "/pullRequest/history?pull_request_id=pr-1", wantStatus: http.StatusOK},
+		{name: "history missing id", method: http.MethodGet, path: "/pullRequest/history", wantStatus: http.StatusBadRequest},
+		{name: "history not found", method: http.MethodGet, path: "/pullRequest/history?pull_request_id=x", err: domain.ErrPRNotFound, wantStatus: http.StatusNotFound},
+		{name: "statistics", method: http.MethodGet, path: "/statistics", wantStatus: http.StatusOK},
+		{name: "statistics filtered", method: http.MethodGet, path: "/statistics?team=backend&from=2025-01-01T00:00:00Z&to=2026-01-01T00:00:00Z", wantStatus: http.StatusOK},
+		{name: "statistics invalid from", method: http.MethodGet, path: "/statistics?from=last-week", wantStatus: http.StatusBadRequest},
+		{name: "statistics unknown team", method: http.MethodGet, path: "/statistics?team=none", err: domain.ErrTeamNotFound, wantStatus: http.StatusNotFound},
+		{name: "fairness", method: http.MethodGet, path: "/statistics/fairness?team=backend&threshold=0.1", wantStatus: http.StatusOK},
+		{name: "fairness invalid threshold", method: http.MethodGet, path: "/statistics/fairness?team=backend&threshold=-1", wantStatus: http.StatusBadRequest},
+		{name: "fairness NaN threshold", method: http.MethodGet, path: "/statistics/fairness?team=backend&threshold=NaN", wantStatus: http.StatusBadRequest},
+		{name: "fairness infinite threshold", method: http.MethodGet, path: "/statistics/fairness?team=backend&threshold=Inf", wantStatus: http.StatusBadRequest},
+		{name: "fairness missing team", method: http.MethodGet, path: "/statistics/fairness", err: domain.ErrTeamRequired, wantStatus: http.StatusBadRequest},
+		{name: "statistics internal error", method: http.MethodGet, path: "/statistics", err: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+		{name: "policies", method: http.MethodGet, path: "/policies", wantStatus: http.StatusOK},
+		{name: "health", method: http.MethodGet, path: "/health", wantStatus: http.StatusOK},
+		{name: "livez", method: http.MethodGet, path: "/livez", wantStatus: http.StatusOK},
+		{name: "readyz", method: http.MethodGet, path: "/readyz", wantStatus: http.StatusOK},
+		{name: "v2 create team", method: http.MethodPost, path: "/v2/teams", body: team, wantStatus: http.StatusCreated},
+		{name: "v2 create team exists", method: http.MethodPost, path: "/v2/teams", body: team, err: domain.ErrTeamExists, wantStatus: http.StatusConflict},
+		{name: "v2 get team", method: http.MethodGet, path: "/v2/teams/backend", specPath: "/v2/teams/{team_name}", wantStatus: http.StatusOK},
+		{name: "v2 get team not found", method: http.MethodGet, path: "/v2/teams/none", specPath: "/v2/teams/{team_name}", err: domain.ErrTeamNotFound, wantStatus: http.StatusNotFound},
+		{name: "v2 get user", method: http.MethodGet, path: "/v2/users/u1", specPath: "/v2/users/{user_id}", wantStatus: http.StatusOK},
+		{name: "v2 patch user", method: http.MethodPatch, path: "/v2/users/u1", specPath: "/v2/users/{user_id}", body: map[string]bool{"is_active": false}, wantStatus: http.StatusOK},
+		{name: "v2 patch user missing field", method: http.MethodPatch, path: "/v2/users/u1", specPath: "/v2/users/{user_id}", body: map[string]string{}, wantStatus: http.StatusBadRequest},
+		{name: "v2 user reviews", method: http.MethodGet, path: "/v2/users/u2/reviews", specPath: "/v2/users/{user_id}/reviews", wantStatus: http.StatusOK},
+		{name: "v2 create PR", method: http.MethodPost, path: "/v2/pull-requests", body: map[string]string{"pull_request_id": "pr-1", "pull_request_name": "Feature", "author_id": "u1"}, wantStatus: http.StatusCreated},
+		{name: "v2 get PR", method: http.MethodGet, path: "/v2/pull-requests/pr-1", specPath: "/v2/pull-requests/{pull_request_id}", wantStatus: http.StatusOK},
+		{name: "v2 merge PR", method: http.MethodPost, path: "/v2/pull-requests/pr-1:merge", specPath: "/v2/pull-requests/{pull_request_id}:merge", wantStatus: http.StatusOK},
+		{name: "v2 reassign", method: http.MethodPost, path: "/v2/pull-requests/pr-1/reviewers/u2:reassign", specPath: "/v2/pull-requests/{pull_request_id}/reviewers/{user_id}:reassign", wantStatus: http.StatusOK},
+		{name: "v2 add reviewer", method: http.MethodPost, path: "/v2/pull-requests/pr-1/reviewers", specPath: "/v2/pull-requests/{pull_request_id}/reviewers", body: map[string]string{"user_id": "u3"}, wantStatus: http.StatusOK},
+		{name: "v2 remove reviewer", method: http.MethodDelete, path: "/v2/pull-requests/pr-1/reviewers/u2", specPath: "/v2/pull-requests/{pull_request_id}/reviewers/{user_id}", wantStatus: http.StatusOK},
+		{name: "v2 reassign with options", method: http.MethodPost, path: "/v2/pull-requests/pr-1/reviewers/u2:reassign", specPath: "/v2/pull-requests/{pull_request_id}/reviewers/{user_id}:reassign", body: map[string]interface{}{"new_user_id": "u5", "reason": "vacation"}, wantStatus: http.StatusOK},
+		{name: "v2 decline", method: http.MethodPost, path: "/v2/pull-requests/pr-1/reviewers/u2:decline", specPath: "/v2/pull-requests/{pull_request_id}/reviewers/{user_id}:decline", body: map[string]string{"reason": "no expertise"}, wantStatus: http.StatusOK},
+		{name: "v2 decline missing reason", method: http.MethodPost, path: "/v2/pull-requests/pr-1/reviewers/u2:decline", specPath: "/v2/pull-requests/{pull_request_id}/reviewers/{user_id}:decline", body: map[string]string{}, wantStatus: http.StatusBadRequest},
+		{name: "v2 list PRs", method: http.MethodGet, path: "/v2/pull-requests?author_id=u1&merged_from=2025-01-01T00:00:00Z", specPath: "/v2/pull-requests", wantStatus: http.StatusOK},
+		{name: "v2 user reviews paginated", method: http.MethodGet, path: "/v2/users/u2/reviews?limit=1", specPath: "/v2/users/{user_id}/reviews", wantStatus: http.StatusOK},
+		{name: "v2 user reviews invalid limit", method: http.MethodGet, path: "/v2/users/u2/reviews?limit=0", specPath: "/v2/users/{user_id}/reviews", wantStatus: http.StatusBadRequest},
+		{name: "v2 history", method: http.MethodGet, path: "/v2/pull-requests/pr-1/history", specPath: "/v2/pull-requests/{pull_request_id}/history", wantStatus: http.StatusOK},
+		{name: "v2 fairness", method: http.MethodGet, path: "/v2/statistics/fairness?team=backend", specPath: "/v2/statistics/fairness", wantStatus: http.StatusOK},
+		{name: "v2 statistics", method: http.MethodGet, path: "/v2/statistics", wantStatus: http.StatusOK},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			runContractCase(t, spec, tc)
+		})
+	}
+}
+
+func TestContractEveryOperationIsRouted(t *testing.T) {
+	router := newStubRouter(&stubService{})
+	spec := loadOpenAPISpec(t, router)
+
+	for _, path := range spec.paths() {
+		for _, method := range spec.operations(path) {
+			req := httptest.NewRequest(method, path, strings.NewReader("{}"))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code == http.StatusNotFound || w.Code == http.StatusMethodNotAllowed {
+				t.Errorf("%s %s is documented but not routed", method, path)
+			}
+		}
+	}
+}
+
+func TestContractErrorCodesAreDocumented(t *testing.T) {
+	spec := loadOpenAPISpec(t, newStubRouter(&stubService{}))
+
+	enum, _ := spec.lookup("components", "schemas", "ErrorCode", "enum").([]interface{})
+	documented := make(map[string]bool)
+	for _, code := range enum {
+		documented[code.(string)] = true
+	}
+
+	codes := []domain.ErrorCode{
+		domain.ErrCodeTeamExists,
+		domain.ErrCodePRExists,
+		domain.ErrCodeRuleExists,
+		domain.ErrCodePRMerged,
+		domain.ErrCodeNotAssigned,
+		domain.ErrCodeNoCandidate,
+		domain.ErrCodeNotFound,
+		domain.ErrCodeInvalidReviewer,
+		domain.ErrCodeTooManyReviewers,
+		domain.ErrCodePreconditionFailed,
+		"INVALID_REQUEST",
+		"METHOD_NOT_ALLOWED",
+		"INTERNAL_ERROR",
+	}
+
+	for _, code := range codes {
+		if !documented[string(code)] {
+			t.Errorf("Error code %s is not documented in the spec", code)
+		}
+	}
+
+	if len(documented) != len(codes) {
+		t.Errorf("Spec documents %d error codes, expected %d", len(documented), len(codes))
+	}
+}
@@ -0,0 +1,144 @@
+package tests
+
+import (
+	"encoding/json"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/avito-test/pr-reviewer-service/internal/handler"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestIntegrationExclusionRules(t *testing.T) {
+	router := newTestRouter(t)
+
+	createTeam(t, router, domain.Team{
+		TeamName: "backend",
+		Members: []domain.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+			{UserID: "u3", Username: "Carol", IsActive: true},
+			{UserID: "u4", Username: "Dan", IsActive: true},
+		},
+	})
+
+	addRule := func(t *testing.T, body map[string]string) domain.ExclusionRule {
+		t.Helper()
+		w := doJSON(t, router, http.MethodPost, "/exclusions/add", body)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+		}
+		var resp struct {
+			Rule domain.ExclusionRule `json:"rule"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode rule: %v", err)
+		}
+		return resp.Rule
+	}
+
+	expectError := func(t *testing.T, w *http.Response, status int, code domain.ErrorCode, contains ...string) {
+		t.Helper()
+		var errResp handler.ErrorResponse
+		json.NewDecoder(w.Body).Decode(&errResp)
+		if w.StatusCode != status || errResp.Error.Code != string(code) {
+			t.Fatalf("Expected %d %s, got %d %+v", status, code, w.StatusCode, errResp.Error)
+		}
+		for _, s := range contains {
+			if !strings.Contains(errResp.Error.Message, s) {
+				t.Fatalf("Expected %q in the message, got %q", s, errResp.Error.Message)
+			}
+		}
+	}
+
+	manager := addRule(t, map[string]string{"kind": "PAIR", "user_id": "u2", "other_user_id": "u1", "reason": "manager"})
+	if manager.UserID != "u1" || manager.OtherUserID != "u2" {
+		t.Fatalf("Expected the pair to be stored in order, got %+v", manager)
+	}
+	paired := addRule(t, map[string]string{"kind": "AUTHOR", "user_id": "u3", "other_user_id": "u1", "reason": "pair-programmed"})
+
+	t.Run("Invalid rules are rejected", func(t *testing.T) {
+		cases := []struct {
+			name   string
+			body   map[string]string
+			status int
+			code   domain.ErrorCode
+		}{
+			{"duplicate pair", map[string]string{"kind": "PAIR", "user_id": "u1", "other_user_id": "u2"}, http.StatusConflict, domain.ErrCodeRuleExists},
+			{"same user", map[string]string{"kind": "PAIR", "user_id": "u1", "other_user_id": "u1"}, http.StatusBadRequest, domain.ErrCodeInvalidRequest},
+			{"unknown kind", map[string]string{"kind": "TEAM", "user_id": "u1", "other_user_id": "u4"}, http.StatusBadRequest, domain.ErrCodeInvalidRequest},
+			{"unknown user", map[string]string{"kind": "AUTHOR", "user_id": "nobody", "other_user_id": "u1"}, http.StatusNotFound, domain.ErrCodeNotFound},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				w := doJSON(t, router, http.MethodPost, "/exclusions/add", tc.body)
+				expectError(t, w.Result(), tc.status, tc.code)
+			})
+		}
+	})
+
+	t.Run("Automatic picks skip excluded users", func(t *testing.T) {
+		w := doJSON(t, router, http.MethodPost, "/pullRequest/create", map[string]string{
+			"pull_request_id": "pr-1", "pull_request_name": "Feature", "author_id": "u1",
+		})
+		if pr := decodePR(t, w); len(pr.AssignedReviewers) != 1 || pr.AssignedReviewers[0] != "u4" {
+			t.Fatalf("Expected only u4 to be assigned, got %v", pr.AssignedReviewers)
+		}
+	})
+
+	t.Run("Explicit reviewers are checked against the rules", func(t *testing.T) {
+		w := doJSON(t, router, http.MethodPost, "/pullRequest/create", map[string]interface{}{
+			"pull_request_id": "pr-2", "pull_request_name": "Feature", "author_id": "u1", "requested_reviewers": []string{"u2"},
+		})
+		expectError(t, w.Result(), http.StatusBadRequest, domain.ErrCodeInvalidReviewer, "rule 1", "manager")
+
+		w = doJSON(t, router, http.MethodPost, "/pullRequest/addReviewer", map[string]string{"pull_request_id": "pr-1", "user_id": "u3"})
+		expectError(t, w.Result(), http.StatusBadRequest, domain.ErrCodeInvalidReviewer, "rule 2")
+
+		w = doJSON(t, router, http.MethodPost, "/pullRequest/reassign", map[string]string{"pull_request_id": "pr-1", "old_user_id": "u4", "new_user_id": "u2"})
+		expectError(t, w.Result(), http.StatusBadRequest, domain.ErrCodeInvalidReviewer, "rule 1")
+	})
+
+	t.Run("Author rules only apply one way", func(t *testing.T) {
+		w := doJSON(t, router, http.MethodPost, "/pullRequest/create", map[string]interface{}{
+			"pull_request_id": "pr-3", "pull_request_name": "Feature", "author_id": "u3", "requested_reviewers": []string{"u1"},
+		})
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected u1 to review u3, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("No candidate names the eliminating rules", func(t *testing.T) {
+		w := doJSON(t, router, http.MethodPost, "/pullRequest/reassign", map[string]string{"pull_request_id": "pr-1", "old_user_id": "u4"})
+		expectError(t, w.Result(), http.StatusConflict, domain.ErrCodeNoCandidate, "rule 1: u1 and u2 do not review each other (manager)", "rule 2: u3 does not review u1")
+	})
+
+	t.Run("Deleted rules stop applying", func(t *testing.T) {
+		if w := doJSON(t, router, http.MethodPost, "/exclusions/delete", map[string]int64{"rule_id": manager.RuleID}); w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+		w := doJSON(t, router, http.MethodPost, "/exclusions/delete", map[string]int64{"rule_id": manager.RuleID})
+		expectError(t, w.Result(), http.StatusNotFound, domain.ErrCodeNotFound)
+
+		w = doJSON(t, router, http.MethodGet, "/exclusions/list?user_id=u1", nil)
+		var resp struct {
+			Rules []domain.ExclusionRule `json:"rules"`
+		}
+		json.NewDecoder(w.Body).Decode(&resp)
+		if len(resp.Rules) != 1 || resp.Rules[0].RuleID != paired.RuleID {
+			t.Fatalf("Unexpected rules %+v", resp.Rules)
+		}
+
+		w = doJSON(t, router, http.MethodPost, "/pullRequest/reassign", map[string]string{"pull_request_id": "pr-1", "old_user_id": "u4"})
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+		var reassigned struct {
+			ReplacedBy string `json:"replaced_by"`
+		}
+		json.NewDecoder(w.Body).Decode(&reassigned)
+		if reassigned.ReplacedBy != "u2" {
+			t.Fatalf("Expected u2 to replace u4, got %q", reassigned.ReplacedBy)
+		}
+	})
+}
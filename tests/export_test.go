@@ -0,0 +1,226 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/avito-test/pr-reviewer-service/internal/handler"
+	"github.com/avito-test/pr-reviewer-service/internal/service"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportFormatNegotiation(t *testing.T) {
+	router := newStubRouter(&stubService{})
+
+	cases := []struct {
+		name        string
+		path        string
+		accept      string
+		contentType string
+	}{
+		{"default is CSV", "/export/pullRequests", "", "text/csv"},
+		{"Accept selects NDJSON", "/export/pullRequests", "application/x-ndjson", "application/x-ndjson"},
+		{"Accept list picks first supported", "/export/history", "application/xml, text/csv;q=0.9", "text/csv"},
+		{"format overrides Accept", "/export/statistics?format=ndjson", "text/csv", "application/x-ndjson"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			if tc.accept != "" {
+				req.Header.Set("Accept", tc.accept)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+			}
+			if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, tc.contentType) {
+				t.Fatalf("Expected Content-Type %s, got %s", tc.contentType, got)
+			}
+			if !strings.HasPrefix(w.Header().Get("Content-Disposition"), "attachment;") {
+				t.Fatalf("Expected attachment Content-Disposition, got %q", w.Header().Get("Content-Disposition"))
+			}
+		})
+	}
+
+	t.Run("Unknown format is rejected", func(t *testing.T) {
+		w := doJSON(t, router, http.MethodGet, "/export/pullRequests?format=xlsx", nil)
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("Expected status 400, got %d", w.Code)
+		}
+		if code := decodeErrorCode(t, w); code != "INVALID_REQUEST" {
+			t.Fatalf("Expected INVALID_REQUEST, got %s", code)
+		}
+	})
+
+	t.Run("Failure before the first row is a JSON error", func(t *testing.T) {
+		router := newStubRouter(&stubService{err: domain.ErrInvalidStatus})
+		w := doJSON(t, router, http.MethodGet, "/export/history", nil)
+		if w.Code != http.StatusBadRequest || !strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+			t.Fatalf("Expected JSON 400, got %d %s", w.Code, w.Header().Get("Content-Type"))
+		}
+	})
+}
+
+func TestExportCSV(t *testing.T) {
+	router := newStubRouter(&stubService{})
+
+	w := doJSON(t, router, http.MethodGet, "/export/pullRequests?format=csv", nil)
+	records, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse CSV: %v", err)
+	}
+
+	if len(records) != 3 {
+		t.Fatalf("Expected header and 2 rows, got %v", records)
+	}
+	if records[0][0] != "pull_request_id" || records[0][7] != "reviewers" {
+		t.Fatalf("Unexpected header %v", records[0])
+	}
+	if records[1][0] != "pr-1" || records[1][3] != "backend" || records[1][7] != "u2;u3" {
+		t.Fatalf("Unexpected row %v", records[1])
+	}
+
+	w = doJSON(t, router, http.MethodGet, "/export/statistics?group_by=team", nil)
+	records, err = csv.NewReader(w.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse CSV: %v", err)
+	}
+	if records[0][0] != "team_name" {
+		t.Fatalf("Expected per-team header, got %v", records[0])
+	}
+}
+
+func TestExportNDJSON(t *testing.T) {
+	router := newStubRouter(&stubService{})
+
+	w := doJSON(t, router, http.MethodGet, "/export/history?format=ndjson", nil)
+
+	var events []domain.AssignmentEvent
+	scanner := bufio.NewScanner(w.Body)
+	for scanner.Scan() {
+		var event domain.AssignmentEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("Line %q is not JSON: %v", scanner.Text(), err)
+		}
+		events = append(events, event)
+	}
+
+	if len(events) != 2 || events[1].Action != domain.AssignmentReassigned || events[1].PreviousUserID != "u2" {
+		t.Fatalf("Unexpected events %+v", events)
+	}
+}
+
+// slowExportService exports the given number of PRs, pausing after each one.
+type slowExportService struct {
+	*stubService
+	rows  int
+	pause time.Duration
+}
+
+func (s *slowExportService) ExportPRs(ctx context.Context, filter domain.PRFilter, fn func(domain.PullRequestDetails) error) error {
+	for i := 0; i < s.rows; i++ {
+		if err := fn(*stubPRDetails(fmt.Sprintf("pr-%d", i))); err != nil {
+			return err
+		}
+		time.Sleep(s.pause)
+	}
+	return nil
+}
+
+func TestExportOutlivesWriteTimeout(t *testing.T) {
+	stub := &stubService{}
+	slow := &slowExportService{stubService: stub, rows: 300, pause: 2 * time.Millisecond}
+	h := handler.NewHandler(&service.Service{Team: stub, User: stub, PullRequest: slow, Statistics: stub, Exclusion: stub, Policies: stub})
+
+	server := httptest.NewUnstartedServer(h.InitRoutes())
+	server.Config.WriteTimeout = 200 * time.Millisecond
+	server.Start()
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/export/pullRequests?format=csv")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	records, err := csv.NewReader(resp.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("Export was cut short after %d records: %v", len(records), err)
+	}
+	if len(records) != slow.rows+1 {
+		t.Fatalf("Expected header and %d rows, got %d records", slow.rows, len(records))
+	}
+}
+
+func TestIntegrationExport(t *testing.T) {
+	router := newTestRouter(t)
+
+	createTeam(t, router, domain.Team{
+		TeamName: "reports",
+		Members: []domain.TeamMember{
+			{UserID: "r1", Username: "Rob", IsActive: true},
+			{UserID: "r2", Username: "Rae", IsActive: true},
+			{UserID: "r3", Username: "Roy", IsActive: true},
+		},
+	})
+
+	for _, prID := range []string{"pr-report-1", "pr-report-2"} {
+		w := doJSON(t, router, http.MethodPost, "/pullRequest/create", map[string]string{
+			"pull_request_id":   prID,
+			"pull_request_name": "Quarterly " + prID,
+			"author_id":         "r1",
+		})
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d", w.Code)
+		}
+	}
+	doJSON(t, router, http.MethodPost, "/pullRequest/merge", map[string]string{"pull_request_id": "pr-report-2"})
+
+	w := doJSON(t, router, http.MethodGet, "/export/pullRequests?format=ndjson&team_name=reports", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	var prs []domain.PullRequestDetails
+	decoder := json.NewDecoder(w.Body)
+	for decoder.More() {
+		var pr domain.PullRequestDetails
+		if err := decoder.Decode(&pr); err != nil {
+			t.Fatalf("Failed to decode row: %v", err)
+		}
+		prs = append(prs, pr)
+	}
+
+	if len(prs) != 2 {
+		t.Fatalf("Expected 2 PRs, got %+v", prs)
+	}
+	for _, pr := range prs {
+		if len(pr.Reviewers) != 2 || len(pr.AssignedReviewers) != 2 || pr.AuthorTeam != "reports" {
+			t.Fatalf("Expected both reviewers folded into one row, got %+v", pr)
+		}
+	}
+
+	w = doJSON(t, router, http.MethodGet, "/export/history?status=MERGED", nil)
+	records, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse CSV: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected header and 2 assignments of the merged PR, got %v", records)
+	}
+	for _, record := range records[1:] {
+		if record[0] != "pr-report-2" || record[1] != string(domain.AssignmentAssigned) {
+			t.Fatalf("Unexpected history row %v", record)
+		}
+	}
+}
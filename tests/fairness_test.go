@@ -0,0 +1,165 @@
+package tests
+
+import (
+	"context"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/avito-test/pr-reviewer-service/internal/repository"
+	"github.com/avito-test/pr-reviewer-service/internal/service"
+	"math"
+	"testing"
+	"time"
+)
+
+// fairnessRepo feeds fixed member loads to the statistics service.
+type fairnessRepo struct {
+	loads []domain.MemberLoad
+}
+
+func (r *fairnessRepo) CreateTeam(ctx context.Context, teamName string) error {
+	return nil
+}
+
+func (r *fairnessRepo) TeamExists(ctx context.Context, teamName string) (bool, error) {
+	return teamName == "backend", nil
+}
+
+func (r *fairnessRepo) GetTeam(ctx context.Context, teamName string) (*domain.Team, error) {
+	return nil, domain.ErrTeamNotFound
+}
+
+func (r *fairnessRepo) ImportTeams(ctx context.Context, teams []domain.Team, plan func(domain.ImportSnapshot) (*domain.ImportPlan, error)) error {
+	return nil
+}
+
+func (r *fairnessRepo) GetUserStatistics(ctx context.Context, filter domain.StatisticsFilter) ([]domain.UserStatistics, error) {
+	return nil, nil
+}
+
+func (r *fairnessRepo) GetTeamStatistics(ctx context.Context, filter domain.StatisticsFilter) ([]domain.TeamStatistics, error) {
+	return nil, nil
+}
+
+func (r *fairnessRepo) GetMemberLoad(ctx context.Context, teamName string, from, to time.Time) ([]domain.MemberLoad, error) {
+	return r.loads, nil
+}
+
+func fairnessReport(t *testing.T, loads []domain.MemberLoad, filter domain.FairnessFilter) *domain.FairnessReport {
+	t.Helper()
+
+	repo := &fairnessRepo{loads: loads}
+	svc := service.NewStatisticsService(&repository.Repository{Team: repo, Statistics: repo})
+
+	report, err := svc.GetFairnessReport(context.Background(), filter)
+	if err != nil {
+		t.Fatalf("GetFairnessReport failed: %v", err)
+	}
+	return report
+}
+
+func TestFairnessReport(t *testing.T) {
+	to := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	from := to.Add(-10 * 24 * time.Hour)
+	window := to.Sub(from).Seconds()
+	filter := domain.FairnessFilter{TeamName: "backend", From: from, To: to}
+
+	t.Run("Equal load is fair", func(t *testing.T) {
+		report := fairnessReport(t, []domain.MemberLoad{
+			{UserID: "u1", IsActive: true, ActiveSeconds: window, Assignments: 5},
+			{UserID: "u2", IsActive: true, ActiveSeconds: window, Assignments: 5},
+		}, filter)
+
+		if report.Gini != 0 || report.MaxMinRatio == nil || *report.MaxMinRatio != 1 {
+			t.Fatalf("Expected a perfectly fair report, got %+v", report)
+		}
+		for _, member := range report.Members {
+			if member.Flagged {
+				t.Fatalf("Member %s should not be flagged", member.UserID)
+			}
+		}
+	})
+
+	t.Run("Inactive time lowers the expected share", func(t *testing.T) {
+		report := fairnessReport(t, []domain.MemberLoad{
+			{UserID: "u1", IsActive: true, ActiveSeconds: window, Assignments: 8},
+			{UserID: "u2", IsActive: false, ActiveSeconds: window / 4, Assignments: 2},
+			{UserID: "u3", IsActive: false, ActiveSeconds: 0, Assignments: 0},
+		}, filter)
+
+		if len(report.Members) != 2 {
+			t.Fatalf("Members never active in the window must be skipped, got %+v", report.Members)
+		}
+		if report.TotalAssignments != 10 {
+			t.Fatalf("Expected 10 assignments, got %d", report.TotalAssignments)
+		}
+
+		u2 := report.Members[1]
+		if math.Abs(u2.ExpectedShare-0.2) > 1e-9 || math.Abs(u2.ActiveFraction-0.25) > 1e-9 {
+			t.Fatalf("Unexpected expected share %+v", u2)
+		}
+		if math.Abs(report.Gini) > 1e-9 || u2.Flagged {
+			t.Fatalf("Load proportional to active time should be fair, got %+v", report)
+		}
+	})
+
+	t.Run("Skewed load is flagged", func(t *testing.T) {
+		report := fairnessReport(t, []domain.MemberLoad{
+			{UserID: "u1", IsActive: true, ActiveSeconds: window, Assignments: 9},
+			{UserID: "u2", IsActive: true, ActiveSeconds: window, Assignments: 3},
+			{UserID: "u3", IsActive: true, ActiveSeconds: window, Assignments: 0},
+		}, filter)
+
+		if report.MaxMinRatio != nil {
+			t.Fatalf("Expected no max/min ratio when someone got nothing, got %v", *report.MaxMinRatio)
+		}
+		if report.Gini < 0.4 {
+			t.Fatalf("Expected a high Gini coefficient, got %v", report.Gini)
+		}
+		if !report.Members[0].Flagged || !report.Members[2].Flagged || report.Members[1].Flagged {
+			t.Fatalf("Expected u1 and u3 to be flagged, got %+v", report.Members)
+		}
+		if report.Threshold != domain.DefaultFairnessThreshold {
+			t.Fatalf("Expected default threshold, got %v", report.Threshold)
+		}
+	})
+
+	t.Run("An explicit zero threshold flags any deviation", func(t *testing.T) {
+		loads := []domain.MemberLoad{
+			{UserID: "u1", IsActive: true, ActiveSeconds: window, Assignments: 11},
+			{UserID: "u2", IsActive: true, ActiveSeconds: window, Assignments: 9},
+		}
+		if report := fairnessReport(t, loads, filter); report.Members[0].Flagged || report.Members[1].Flagged {
+			t.Fatalf("Expected a small deviation to pass the default threshold, got %+v", report.Members)
+		}
+
+		zero := 0.0
+		strict := filter
+		strict.Threshold = &zero
+		report := fairnessReport(t, loads, strict)
+		if report.Threshold != 0 || !report.Members[0].Flagged || !report.Members[1].Flagged {
+			t.Fatalf("Expected both members flagged at threshold 0, got %v %+v", report.Threshold, report.Members)
+		}
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		negative, notANumber := -1.0, math.NaN()
+		repo := &fairnessRepo{}
+		svc := service.NewStatisticsService(&repository.Repository{Team: repo, Statistics: repo})
+
+		cases := []struct {
+			filter domain.FairnessFilter
+			err    error
+		}{
+			{domain.FairnessFilter{}, domain.ErrTeamRequired},
+			{domain.FairnessFilter{TeamName: "none"}, domain.ErrTeamNotFound},
+			{domain.FairnessFilter{TeamName: "backend", From: to, To: from}, domain.ErrInvalidWindow},
+			{domain.FairnessFilter{TeamName: "backend", Threshold: &negative}, domain.ErrInvalidThreshold},
+			{domain.FairnessFilter{TeamName: "backend", Threshold: &notANumber}, domain.ErrInvalidThreshold},
+		}
+
+		for _, tc := range cases {
+			if _, err := svc.GetFairnessReport(context.Background(), tc.filter); err != tc.err {
+				t.Fatalf("Expected %v for %+v, got %v", tc.err, tc.filter, err)
+			}
+		}
+	})
+}
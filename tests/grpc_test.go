@@ -0,0 +1,225 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	reviewerv1 "github.com/avito-test/pr-reviewer-service/api/proto/reviewer/v1"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/avito-test/pr-reviewer-service/internal/grpchandler"
+	"github.com/avito-test/pr-reviewer-service/internal/service"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"net"
+	"testing"
+)
+
+func setupGRPC(t *testing.T, stub *stubService) *grpc.ClientConn {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpchandler.NewServer(&service.Service{
+		Team:        stub,
+		User:        stub,
+		PullRequest: stub,
+		Statistics:  stub,
+	})
+
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Failed to dial bufconn: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func TestGRPCServices(t *testing.T) {
+	conn := setupGRPC(t, &stubService{})
+	ctx := context.Background()
+
+	t.Run("Create and get team", func(t *testing.T) {
+		client := reviewerv1.NewTeamServiceClient(conn)
+
+		created, err := client.CreateTeam(ctx, &reviewerv1.CreateTeamRequest{
+			Team: &reviewerv1.Team{
+				TeamName: "backend",
+				Members: []*reviewerv1.TeamMember{
+					{UserId: "u1", Username: "Alice", IsActive: true},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatalf("CreateTeam failed: %v", err)
+		}
+		if created.GetTeam().GetTeamName() != "backend" || len(created.GetTeam().GetMembers()) != 1 {
+			t.Fatalf("Unexpected team %v", created.GetTeam())
+		}
+
+		got, err := client.GetTeam(ctx, &reviewerv1.GetTeamRequest{TeamName: "backend"})
+		if err != nil {
+			t.Fatalf("GetTeam failed: %v", err)
+		}
+		if len(got.GetTeam().GetMembers()) != 2 {
+			t.Fatalf("Expected 2 members, got %d", len(got.GetTeam().GetMembers()))
+		}
+	})
+
+	t.Run("Users", func(t *testing.T) {
+		client := reviewerv1.NewUserServiceClient(conn)
+
+		resp, err := client.SetIsActive(ctx, &reviewerv1.SetIsActiveRequest{UserId: "u1", IsActive: false})
+		if err != nil {
+			t.Fatalf("SetIsActive failed: %v", err)
+		}
+		if resp.GetUser().GetIsActive() {
+			t.Fatal("Expected inactive user")
+		}
+
+		review, err := client.GetReview(ctx, &reviewerv1.GetReviewRequest{UserId: "u2"})
+		if err != nil {
+			t.Fatalf("GetReview failed: %v", err)
+		}
+		if len(review.GetPullRequests()) != 1 {
+			t.Fatalf("Expected 1 PR, got %d", len(review.GetPullRequests()))
+		}
+	})
+
+	t.Run("Pull request lifecycle", func(t *testing.T) {
+		client := reviewerv1.NewPullRequestServiceClient(conn)
+
+		created, err := client.CreatePullRequest(ctx, &reviewerv1.CreatePullRequestRequest{
+			PullRequestId:   "pr-1",
+			PullRequestName: "Feature",
+			AuthorId:        "u1",
+		})
+		if err != nil {
+			t.Fatalf("CreatePullRequest failed: %v", err)
+		}
+		if created.GetPr().GetStatus() != reviewerv1.PRStatus_PR_STATUS_OPEN {
+			t.Fatalf("Expected OPEN, got %v", created.GetPr().GetStatus())
+		}
+		if created.GetPr().GetCreatedAt() == nil {
+			t.Fatal("Expected created_at to be set")
+		}
+
+		list, err := client.ListPullRequests(ctx, &reviewerv1.ListPullRequestsRequest{
+			Filter: &reviewerv1.PullRequestFilter{Status: reviewerv1.PRStatus_PR_STATUS_OPEN},
+			Page:   &reviewerv1.PageRequest{Limit: 2},
+		})
+		if err != nil {
+			t.Fatalf("ListPullRequests failed: %v", err)
+		}
+		if len(list.GetPullRequests()) != 2 || list.GetNextCursor() == "" {
+			t.Fatalf("Unexpected page %v", list)
+		}
+
+		merged, err := client.MergePullRequest(ctx, &reviewerv1.MergePullRequestRequest{PullRequestId: "pr-1"})
+		if err != nil {
+			t.Fatalf("MergePullRequest failed: %v", err)
+		}
+		if merged.GetPr().GetStatus() != reviewerv1.PRStatus_PR_STATUS_MERGED || merged.GetPr().GetMergedAt() == nil {
+			t.Fatalf("Expected merged PR, got %v", merged.GetPr())
+		}
+
+		reassigned, err := client.ReassignReviewer(ctx, &reviewerv1.ReassignReviewerRequest{PullRequestId: "pr-1", OldUserId: "u2"})
+		if err != nil {
+			t.Fatalf("ReassignReviewer failed: %v", err)
+		}
+		if reassigned.GetReplacedBy() != "u4" {
+			t.Fatalf("Expected replaced_by u4, got %s", reassigned.GetReplacedBy())
+		}
+
+		declined, err := client.DeclineReview(ctx, &reviewerv1.DeclineReviewRequest{PullRequestId: "pr-1", UserId: "u2", Reason: "conflict of interest"})
+		if err != nil {
+			t.Fatalf("DeclineReview failed: %v", err)
+		}
+		if declined.GetReplacedBy() != "u4" {
+			t.Fatalf("Expected replaced_by u4, got %s", declined.GetReplacedBy())
+		}
+
+		_, err = client.DeclineReview(ctx, &reviewerv1.DeclineReviewRequest{PullRequestId: "pr-1", UserId: "u2"})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("Expected InvalidArgument without reason, got %v", err)
+		}
+	})
+
+	t.Run("Statistics", func(t *testing.T) {
+		client := reviewerv1.NewStatisticsServiceClient(conn)
+
+		stats, err := client.GetStatistics(ctx, &reviewerv1.GetStatisticsRequest{})
+		if err != nil {
+			t.Fatalf("GetStatistics failed: %v", err)
+		}
+		if stats.GetTotalPrs() != 3 || stats.GetAssignmentsByUser()["u2"] != 2 || stats.GetDeclinesByUser()["u5"] != 1 {
+			t.Fatalf("Unexpected statistics %v", stats)
+		}
+	})
+
+	t.Run("Missing arguments", func(t *testing.T) {
+		client := reviewerv1.NewTeamServiceClient(conn)
+
+		_, err := client.GetTeam(ctx, &reviewerv1.GetTeamRequest{})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("Expected InvalidArgument, got %v", err)
+		}
+	})
+}
+
+func TestGRPCErrorMapping(t *testing.T) {
+	cases := []struct {
+		err  error
+		code codes.Code
+	}{
+		{domain.ErrTeamNotFound, codes.NotFound},
+		{domain.ErrTeamExists, codes.AlreadyExists},
+		{domain.ErrPRExists, codes.AlreadyExists},
+		{domain.ErrPRMerged, codes.FailedPrecondition},
+		{domain.ErrNotAssigned, codes.FailedPrecondition},
+		{domain.ErrNoCandidate, codes.FailedPrecondition},
+		{errors.New("connection reset"), codes.Internal},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.code.String(), func(t *testing.T) {
+			conn := setupGRPC(t, &stubService{err: tc.err})
+			client := reviewerv1.NewPullRequestServiceClient(conn)
+
+			_, err := client.ReassignReviewer(context.Background(), &reviewerv1.ReassignReviewerRequest{
+				PullRequestId: "pr-1",
+				OldUserId:     "u2",
+			})
+
+			st := status.Convert(err)
+			if st.Code() != tc.code {
+				t.Fatalf("Expected %v, got %v", tc.code, st.Code())
+			}
+
+			wantReason := "INTERNAL_ERROR"
+			if appErr, ok := domain.IsAppError(tc.err); ok {
+				wantReason = string(appErr.Code)
+			}
+
+			var reason string
+			for _, detail := range st.Details() {
+				if info, ok := detail.(*errdetails.ErrorInfo); ok {
+					reason = info.GetReason()
+				}
+			}
+			if reason != wantReason {
+				t.Fatalf("Expected reason %s, got %q", wantReason, reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/avito-test/pr-reviewer-service/internal/health"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func readiness(t *testing.T, checker *health.Checker) (int, health.Report) {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	checker.ReadinessHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	var report health.Report
+	if err := json.NewDecoder(w.Body).Decode(&report); err != nil {
+		t.Fatalf("Failed to decode readiness report: %v", err)
+	}
+	return w.Code, report
+}
+
+func TestReadiness(t *testing.T) {
+	t.Run("All checks pass", func(t *testing.T) {
+		checker := health.NewChecker(time.Second)
+		checker.Add("database", func(ctx context.Context) error { return nil })
+
+		code, report := readiness(t, checker)
+		if code != http.StatusOK || report.Status != health.StatusOK || report.Checks["database"].Status != health.StatusOK {
+			t.Fatalf("Expected ready, got %d %+v", code, report)
+		}
+	})
+
+	t.Run("Failing check is reported", func(t *testing.T) {
+		checker := health.NewChecker(time.Second)
+		checker.Add("database", func(ctx context.Context) error { return errors.New("connection refused") })
+		checker.Add("migrations", func(ctx context.Context) error { return nil })
+
+		code, report := readiness(t, checker)
+		if code != http.StatusServiceUnavailable || report.Status != health.StatusUnavailable {
+			t.Fatalf("Expected 503 unavailable, got %d %+v", code, report)
+		}
+		if report.Checks["database"].Error != "connection refused" || report.Checks["migrations"].Status != health.StatusOK {
+			t.Fatalf("Unexpected per-check details %+v", report.Checks)
+		}
+	})
+
+	t.Run("Slow check times out", func(t *testing.T) {
+		checker := health.NewChecker(20 * time.Millisecond)
+		checker.Add("database", func(ctx context.Context) error {
+			time.Sleep(time.Second)
+			return nil
+		})
+
+		start := time.Now()
+		code, report := readiness(t, checker)
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Fatalf("Readiness waited %s for a hung check", elapsed)
+		}
+		if code != http.StatusServiceUnavailable || report.Checks["database"].Error != context.DeadlineExceeded.Error() {
+			t.Fatalf("Expected a timeout failure, got %d %+v", code, report)
+		}
+	})
+
+	t.Run("Draining reports unready", func(t *testing.T) {
+		checker := health.NewChecker(time.Second)
+		checker.SetDraining()
+
+		code, report := readiness(t, checker)
+		if code != http.StatusServiceUnavailable || report.Status != health.StatusDraining {
+			t.Fatalf("Expected 503 draining, got %d %+v", code, report)
+		}
+
+		w := httptest.NewRecorder()
+		checker.LivenessHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/livez", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Liveness must not depend on draining, got %d", w.Code)
+		}
+	})
+
+	t.Run("Workers", func(t *testing.T) {
+		checker := health.NewChecker(time.Second)
+		server := checker.AddWorker("grpc_server", 0)
+		poller := checker.AddWorker("poller", 10*time.Millisecond)
+
+		time.Sleep(20 * time.Millisecond)
+		poller.Beat()
+		if code, report := readiness(t, checker); code != http.StatusOK {
+			t.Fatalf("Expected live workers to be ready, got %+v", report)
+		}
+
+		server.Stopped()
+		time.Sleep(20 * time.Millisecond)
+		_, report := readiness(t, checker)
+		if report.Checks["grpc_server"].Status != health.StatusFail || report.Checks["poller"].Status != health.StatusFail {
+			t.Fatalf("Expected exited and silent workers to fail, got %+v", report.Checks)
+		}
+	})
+}
@@ -0,0 +1,124 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/avito-test/pr-reviewer-service/internal/handler"
+	"github.com/avito-test/pr-reviewer-service/internal/repository"
+	"github.com/avito-test/pr-reviewer-service/internal/repository/memory"
+	"github.com/avito-test/pr-reviewer-service/internal/repository/postgres"
+	"github.com/avito-test/pr-reviewer-service/internal/repository/sqlite"
+	"github.com/avito-test/pr-reviewer-service/internal/service"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newTestRepository returns an empty repository: in memory by default, or
+// a freshly migrated database with TEST_STORAGE=postgres or sqlite.
+func newTestRepository(t *testing.T) *repository.Repository {
+	t.Helper()
+
+	switch os.Getenv("TEST_STORAGE") {
+	case "postgres":
+		db := setupTestDB(t)
+		t.Cleanup(func() { db.Close() })
+		return postgres.NewRepository(db)
+	case "sqlite":
+		return newSQLiteRepository(t)
+	default:
+		return memory.NewRepository()
+	}
+}
+
+func newSQLiteRepository(t *testing.T) *repository.Repository {
+	t.Helper()
+
+	db, err := sqlite.NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open SQLite database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := sqlite.RunMigrations(context.Background(), db); err != nil {
+		t.Fatalf("Failed to run SQLite migrations: %v", err)
+	}
+	return sqlite.NewRepository(db)
+}
+
+// requirePostgres skips tests that exercise Postgres itself unless
+// TEST_STORAGE=postgres.
+func requirePostgres(t *testing.T) {
+	t.Helper()
+
+	if os.Getenv("TEST_STORAGE") != "postgres" {
+		t.Skip("set TEST_STORAGE=postgres to run against a database")
+	}
+}
+
+func newTestRouter(t *testing.T) http.Handler {
+	t.Helper()
+
+	svc := service.NewService(newTestRepository(t))
+	return handler.NewHandler(svc).InitRoutes()
+}
+
+func doJSON(t *testing.T, router http.Handler, method, path string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatalf("Failed to encode request body: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest(method, path, &buf)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func createTeam(t *testing.T, router http.Handler, team domain.Team) {
+	t.Helper()
+
+	w := doJSON(t, router, http.MethodPost, "/team/add", team)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Failed to create team %s: %d %s", team.TeamName, w.Code, w.Body.String())
+	}
+}
+
+func decodePR(t *testing.T, w *httptest.ResponseRecorder) domain.PullRequest {
+	t.Helper()
+
+	var response struct {
+		PR domain.PullRequest `json:"pr"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode PR response: %v", err)
+	}
+	return response.PR
+}
+
+func decodeErrorCode(t *testing.T, w *httptest.ResponseRecorder) string {
+	t.Helper()
+
+	var errResp handler.ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("Failed to decode error response: %v", err)
+	}
+	return errResp.Error.Code
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
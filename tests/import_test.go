@@ -0,0 +1,153 @@
+package tests
+
+import (
+	"encoding/json"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIntegrationTeamImport(t *testing.T) {
+	router := newTestRouter(t)
+
+	createTeam(t, router, domain.Team{
+		TeamName: "backend",
+		Members: []domain.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+			{UserID: "u3", Username: "Carol", IsActive: true},
+		},
+	})
+	createTeam(t, router, domain.Team{
+		TeamName: "frontend",
+		Members: []domain.TeamMember{
+			{UserID: "f1", Username: "Fay", IsActive: true},
+		},
+	})
+
+	importCSV := func(t *testing.T, path, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "text/csv; charset=utf-8")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	decodeReport := func(t *testing.T, w *httptest.ResponseRecorder) domain.ImportReport {
+		t.Helper()
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+		var report domain.ImportReport
+		if err := json.NewDecoder(w.Body).Decode(&report); err != nil {
+			t.Fatalf("Failed to decode report: %v", err)
+		}
+		return report
+	}
+
+	// u1 stays, u2 is renamed, u3 moves to the new mobile team, m1 and f2
+	// are new, and f1, missing from frontend, is deactivated.
+	export := `team_name,user_id,username,is_active
+backend,u1,Alice,
+backend,u2,Bobby,true
+mobile,u3,Carol,true
+mobile,m1,Dana,false
+frontend,f2,Finn,
+`
+
+	t.Run("Dry run reports the diff without writing", func(t *testing.T) {
+		report := decodeReport(t, importCSV(t, "/team/import?dry_run=true", export))
+
+		if !report.DryRun || report.Unchanged != 1 {
+			t.Fatalf("Expected a dry run with one unchanged user, got %+v", report)
+		}
+		if !reflect.DeepEqual(report.TeamsCreated, []string{"mobile"}) {
+			t.Fatalf("Expected mobile to be created, got %v", report.TeamsCreated)
+		}
+		if len(report.UsersMoved) != 1 || report.UsersMoved[0] != (domain.UserMove{UserID: "u3", FromTeam: "backend", ToTeam: "mobile"}) {
+			t.Fatalf("Expected u3 to move, got %+v", report.UsersMoved)
+		}
+		if len(report.UsersCreated) != 2 || len(report.UsersUpdated) != 1 || report.UsersUpdated[0].Username != "Bobby" {
+			t.Fatalf("Unexpected created/updated users: %+v %+v", report.UsersCreated, report.UsersUpdated)
+		}
+		if !reflect.DeepEqual(report.UsersDeactivated, []string{"f1"}) {
+			t.Fatalf("Expected f1 to be deactivated, got %v", report.UsersDeactivated)
+		}
+
+		if w := doJSON(t, router, http.MethodGet, "/team/get?team_name=mobile", nil); w.Code != http.StatusNotFound {
+			t.Fatalf("Expected dry run to leave mobile uncreated, got %d", w.Code)
+		}
+	})
+
+	t.Run("Import applies the diff", func(t *testing.T) {
+		report := decodeReport(t, importCSV(t, "/team/import", export))
+		if report.DryRun || len(report.TeamsCreated) != 1 {
+			t.Fatalf("Unexpected report %+v", report)
+		}
+
+		w := doJSON(t, router, http.MethodGet, "/team/get?team_name=mobile", nil)
+		var mobile domain.Team
+		json.NewDecoder(w.Body).Decode(&mobile)
+		if len(mobile.Members) != 2 || mobile.Members[0].UserID != "m1" || mobile.Members[0].IsActive {
+			t.Fatalf("Unexpected mobile members %+v", mobile.Members)
+		}
+
+		w = doJSON(t, router, http.MethodGet, "/team/get?team_name=frontend", nil)
+		var frontend domain.Team
+		json.NewDecoder(w.Body).Decode(&frontend)
+		if len(frontend.Members) != 2 || frontend.Members[0].UserID != "f1" || frontend.Members[0].IsActive {
+			t.Fatalf("Expected f1 kept but inactive, got %+v", frontend.Members)
+		}
+
+		again := decodeReport(t, importCSV(t, "/team/import", export))
+		if again.Unchanged != 5 || len(again.UsersDeactivated) != 0 || len(again.UsersCreated) != 0 {
+			t.Fatalf("Expected a repeated import to change nothing, got %+v", again)
+		}
+	})
+
+	t.Run("A moved user can also be deactivated", func(t *testing.T) {
+		report := decodeReport(t, importCSV(t, "/team/import?dry_run=true", `team_name,user_id,username,is_active
+mobile,u1,Alice,false
+mobile,u3,Carol,true
+mobile,m1,Dana,false
+`))
+		if len(report.UsersMoved) != 1 || report.UsersMoved[0] != (domain.UserMove{UserID: "u1", FromTeam: "backend", ToTeam: "mobile"}) {
+			t.Fatalf("Expected u1 to move, got %+v", report.UsersMoved)
+		}
+		if !reflect.DeepEqual(report.UsersDeactivated, []string{"u1"}) || len(report.UsersUpdated) != 0 {
+			t.Fatalf("Expected u1 to be reported as deactivated too, got %+v", report)
+		}
+	})
+
+	t.Run("Conflicting memberships reject the import", func(t *testing.T) {
+		w := doJSON(t, router, http.MethodPost, "/team/import", map[string]interface{}{
+			"teams": []domain.Team{
+				{TeamName: "backend", Members: []domain.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}}},
+				{TeamName: "platform", Members: []domain.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}, {UserID: "", Username: "Nobody"}}},
+			},
+		})
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("Expected status 400, got %d. Body: %s", w.Code, w.Body.String())
+		}
+		for _, want := range []string{"u1 is listed in both backend and platform", "user_id is required"} {
+			if !strings.Contains(w.Body.String(), want) {
+				t.Fatalf("Expected error to mention %q, got %s", want, w.Body.String())
+			}
+		}
+		if w := doJSON(t, router, http.MethodGet, "/team/get?team_name=platform", nil); w.Code != http.StatusNotFound {
+			t.Fatal("Expected a rejected import to create nothing")
+		}
+	})
+
+	t.Run("Malformed CSV is rejected", func(t *testing.T) {
+		if w := importCSV(t, "/team/import", "team,user\nbackend,u1\n"); w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "team_name") {
+			t.Fatalf("Expected missing column to be reported, got %d %s", w.Code, w.Body.String())
+		}
+		if w := importCSV(t, "/team/import", "team_name,user_id,username,is_active\nbackend,u1,Alice,sometimes\n"); w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "line 2") {
+			t.Fatalf("Expected bad is_active to be reported with its line, got %d %s", w.Code, w.Body.String())
+		}
+	})
+}
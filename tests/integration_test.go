@@ -7,7 +7,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/avito-test/pr-reviewer-service/internal/domain"
-	"github.com/avito-test/pr-reviewer-service/internal/handler"
 	"github.com/avito-test/pr-reviewer-service/internal/repository/postgres"
 	"github.com/avito-test/pr-reviewer-service/internal/service"
 	_ "github.com/lib/pq"
@@ -43,10 +42,18 @@ func setupTestDB(t *testing.T) *sql.DB {
 }
 
 func cleanupDB(db *sql.DB) {
+	db.Exec("DROP TABLE IF EXISTS sync_runs CASCADE")
+	db.Exec("DROP TABLE IF EXISTS exclusion_rules CASCADE")
+	db.Exec("DROP TABLE IF EXISTS pr_required_tags CASCADE")
+	db.Exec("DROP TABLE IF EXISTS user_skills CASCADE")
+	db.Exec("DROP TABLE IF EXISTS pr_reviewer_history CASCADE")
+	db.Exec("DROP TABLE IF EXISTS pr_reviewer_declines CASCADE")
+	db.Exec("DROP TABLE IF EXISTS user_activity_log CASCADE")
 	db.Exec("DROP TABLE IF EXISTS pr_reviewers CASCADE")
 	db.Exec("DROP TABLE IF EXISTS pull_requests CASCADE")
 	db.Exec("DROP TABLE IF EXISTS users CASCADE")
 	db.Exec("DROP TABLE IF EXISTS teams CASCADE")
+	db.Exec("DROP TABLE IF EXISTS schema_migrations")
 }
 
 func getEnvOrDefault(key, defaultValue string) string {
@@ -57,13 +64,7 @@ func getEnvOrDefault(key, defaultValue string) string {
 }
 
 func TestIntegrationFullWorkflow(t *testing.T) {
-	db := setupTestDB(t)
-	defer db.Close()
-
-	repo := postgres.NewRepository(db)
-	svc := service.NewService(repo)
-	handlers := handler.NewHandler(svc)
-	router := handlers.InitRoutes()
+	router := newTestRouter(t)
 
 	t.Run("Create team and verify", func(t *testing.T) {
 		team := domain.Team{
@@ -242,13 +243,7 @@ func TestIntegrationFullWorkflow(t *testing.T) {
 }
 
 func TestIntegrationEdgeCases(t *testing.T) {
-	db := setupTestDB(t)
-	defer db.Close()
-
-	repo := postgres.NewRepository(db)
-	svc := service.NewService(repo)
-	handlers := handler.NewHandler(svc)
-	router := handlers.InitRoutes()
+	router := newTestRouter(t)
 
 	t.Run("Duplicate team creation should fail", func(t *testing.T) {
 		team := domain.Team{
@@ -395,7 +390,7 @@ func TestIntegrationEdgeCases(t *testing.T) {
 
 		body, _ = json.Marshal(mergeReq)
 		req = httptest.NewRequest(http.MethodPost, "/pullRequest/merge", bytes.NewReader(body))
-		w := httptest.NewRecorder()
+		w = httptest.NewRecorder()
 		router.ServeHTTP(w, req)
 
 		if w.Code != http.StatusOK {
@@ -435,6 +430,10 @@ func BenchmarkCreatePR(b *testing.B) {
 
 	for i := 0; i < b.N; i++ {
 		prID := fmt.Sprintf("pr-bench-%d-%d", time.Now().UnixNano(), i)
-		_, _ = svc.PullRequest.CreatePR(ctx, prID, "Benchmark PR", "bench-u1")
+		_, _ = svc.PullRequest.CreatePR(ctx, service.CreatePRInput{
+			PullRequestID:   prID,
+			PullRequestName: "Benchmark PR",
+			AuthorID:        "bench-u1",
+		})
 	}
 }
@@ -0,0 +1,234 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestPRCursorRoundTrip(t *testing.T) {
+	cursor := domain.PRCursor{
+		CreatedAt:     time.Date(2025, 11, 1, 12, 30, 0, 123456000, time.UTC),
+		PullRequestID: "pr|with|pipes",
+	}
+
+	decoded, err := domain.DecodePRCursor(cursor.Encode())
+	if err != nil {
+		t.Fatalf("Failed to decode cursor: %v", err)
+	}
+	if !decoded.CreatedAt.Equal(cursor.CreatedAt) || decoded.PullRequestID != cursor.PullRequestID {
+		t.Fatalf("Expected %+v, got %+v", cursor, decoded)
+	}
+
+	for _, bad := range []string{"%%%", "bm8tc2VwYXJhdG9y", "bm90LWEtdGltZXxwci0x"} {
+		if _, err := domain.DecodePRCursor(bad); err != domain.ErrInvalidCursor {
+			t.Fatalf("Expected ErrInvalidCursor for %q, got %v", bad, err)
+		}
+	}
+}
+
+func TestIntegrationListPRs(t *testing.T) {
+	router := newTestRouter(t)
+
+	createTeam(t, router, domain.Team{
+		TeamName: "search",
+		Members: []domain.TeamMember{
+			{UserID: "s1", Username: "Sam", IsActive: true},
+			{UserID: "s2", Username: "Sue", IsActive: true},
+			{UserID: "s3", Username: "Sid", IsActive: true},
+		},
+	})
+	createTeam(t, router, domain.Team{
+		TeamName: "infra",
+		Members: []domain.TeamMember{
+			{UserID: "i1", Username: "Ian", IsActive: true},
+			{UserID: "i2", Username: "Ivy", IsActive: true},
+		},
+	})
+
+	for i := 1; i <= 5; i++ {
+		w := doJSON(t, router, http.MethodPost, "/pullRequest/create", map[string]string{
+			"pull_request_id":   fmt.Sprintf("pr-search-%d", i),
+			"pull_request_name": fmt.Sprintf("Search feature %d", i),
+			"author_id":         "s1",
+		})
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d", w.Code)
+		}
+	}
+	w := doJSON(t, router, http.MethodPost, "/pullRequest/create", map[string]string{
+		"pull_request_id":   "pr-infra-1",
+		"pull_request_name": "Bump runners",
+		"author_id":         "i1",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d", w.Code)
+	}
+	doJSON(t, router, http.MethodPost, "/pullRequest/merge", map[string]string{"pull_request_id": "pr-search-2"})
+
+	listPage := func(t *testing.T, path string) domain.PRPage {
+		t.Helper()
+
+		w := doJSON(t, router, http.MethodGet, path, nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200 for %s, got %d. Body: %s", path, w.Code, w.Body.String())
+		}
+
+		var page domain.PRPage
+		if err := json.NewDecoder(w.Body).Decode(&page); err != nil {
+			t.Fatalf("Failed to decode page: %v", err)
+		}
+		return page
+	}
+
+	t.Run("Cursor pagination visits every PR once", func(t *testing.T) {
+		seen := make(map[string]bool)
+		path := "/pullRequest/list?team_name=search&limit=2"
+		pages := 0
+		for {
+			page := listPage(t, path)
+			pages++
+			for _, pr := range page.PullRequests {
+				if seen[pr.PullRequestID] {
+					t.Fatalf("PR %s returned twice", pr.PullRequestID)
+				}
+				seen[pr.PullRequestID] = true
+			}
+			if page.NextCursor == "" {
+				break
+			}
+			path = "/pullRequest/list?team_name=search&limit=2&cursor=" + page.NextCursor
+		}
+
+		if len(seen) != 5 || pages != 3 {
+			t.Fatalf("Expected 5 PRs over 3 pages, got %d over %d", len(seen), pages)
+		}
+	})
+
+	t.Run("Filters", func(t *testing.T) {
+		cases := []struct {
+			query string
+			want  int
+		}{
+			{"status=MERGED", 1},
+			{"status=OPEN&author_id=s1", 4},
+			{"team_name=infra", 1},
+			{"name=FEATURE%203", 1},
+			{"created_from=2000-01-01T00:00:00Z&created_to=2001-01-01T00:00:00Z", 0},
+			{"merged_from=2000-01-01T00:00:00Z", 1},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.query, func(t *testing.T) {
+				page := listPage(t, "/pullRequest/list?"+tc.query)
+				if len(page.PullRequests) != tc.want {
+					t.Fatalf("Expected %d PRs, got %+v", tc.want, page.PullRequests)
+				}
+			})
+		}
+	})
+
+	t.Run("Get review is paginated", func(t *testing.T) {
+		w := doJSON(t, router, http.MethodGet, "/users/getReview?user_id=s2&limit=1", nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+
+		var response struct {
+			PullRequests []domain.PullRequestShort `json:"pull_requests"`
+			NextCursor   string                    `json:"next_cursor"`
+		}
+		json.NewDecoder(w.Body).Decode(&response)
+
+		if len(response.PullRequests) != 1 || response.NextCursor == "" {
+			t.Fatalf("Expected one PR and a next cursor, got %+v", response)
+		}
+	})
+
+	t.Run("Invalid status", func(t *testing.T) {
+		w := doJSON(t, router, http.MethodGet, "/pullRequest/list?status=CLOSED", nil)
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("Expected status 400, got %d", w.Code)
+		}
+	})
+}
+
+func TestIntegrationGetPRDetails(t *testing.T) {
+	router := newTestRouter(t)
+
+	createTeam(t, router, domain.Team{
+		TeamName: "billing",
+		Members: []domain.TeamMember{
+			{UserID: "b1", Username: "Bea", IsActive: true},
+			{UserID: "b2", Username: "Ben", IsActive: true},
+			{UserID: "b3", Username: "Bob", IsActive: true},
+		},
+	})
+
+	for _, prID := range []string{"pr-billing-1", "pr-billing-2"} {
+		w := doJSON(t, router, http.MethodPost, "/pullRequest/create", map[string]string{
+			"pull_request_id":   prID,
+			"pull_request_name": "Invoices",
+			"author_id":         "b1",
+		})
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d", w.Code)
+		}
+	}
+
+	t.Run("Single", func(t *testing.T) {
+		w := doJSON(t, router, http.MethodGet, "/pullRequest/get?pull_request_id=pr-billing-1", nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+
+		var response struct {
+			PR domain.PullRequestDetails `json:"pr"`
+		}
+		json.NewDecoder(w.Body).Decode(&response)
+
+		if response.PR.AuthorTeam != "billing" || response.PR.Status != domain.PRStatusOpen {
+			t.Fatalf("Unexpected PR %+v", response.PR)
+		}
+		if len(response.PR.Reviewers) != 2 {
+			t.Fatalf("Expected 2 reviewers, got %+v", response.PR.Reviewers)
+		}
+		for _, reviewer := range response.PR.Reviewers {
+			if reviewer.AssignedAt.IsZero() {
+				t.Fatalf("Expected assigned_at for %s", reviewer.UserID)
+			}
+		}
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		w := doJSON(t, router, http.MethodGet, "/pullRequest/get?pull_request_id=missing", nil)
+		if w.Code != http.StatusNotFound {
+			t.Fatalf("Expected status 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("Batch", func(t *testing.T) {
+		w := doJSON(t, router, http.MethodPost, "/pullRequest/getBatch", map[string][]string{
+			"pull_request_ids": {"pr-billing-2", "missing", "pr-billing-1", "pr-billing-2"},
+		})
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+
+		var response struct {
+			PullRequests []domain.PullRequestDetails `json:"pull_requests"`
+			NotFound     []string                    `json:"not_found"`
+		}
+		json.NewDecoder(w.Body).Decode(&response)
+
+		if len(response.PullRequests) != 2 || response.PullRequests[0].PullRequestID != "pr-billing-2" {
+			t.Fatalf("Expected PRs in request order without duplicates, got %+v", response.PullRequests)
+		}
+		if len(response.NotFound) != 1 || response.NotFound[0] != "missing" {
+			t.Fatalf("Expected missing in not_found, got %v", response.NotFound)
+		}
+	})
+}
@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/avito-test/pr-reviewer-service/internal/handler"
+	"github.com/avito-test/pr-reviewer-service/internal/logging"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func decodeLogLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+
+	var lines []map[string]interface{}
+	for _, raw := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var line map[string]interface{}
+		if err := json.Unmarshal([]byte(raw), &line); err != nil {
+			t.Fatalf("Log line %q is not JSON: %v", raw, err)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestRequestIDPropagation(t *testing.T) {
+	var logs bytes.Buffer
+	router := logging.Middleware(logging.New(&logs, "info"), newStubRouter(&stubService{}))
+
+	t.Run("Incoming ID is echoed and logged", func(t *testing.T) {
+		logs.Reset()
+		req := httptest.NewRequest(http.MethodGet, "/team/get?team_name=backend", nil)
+		req.Header.Set("X-Request-ID", "abc-123")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if got := w.Header().Get("X-Request-ID"); got != "abc-123" {
+			t.Fatalf("Expected X-Request-ID abc-123, got %q", got)
+		}
+
+		lines := decodeLogLines(t, &logs)
+		last := lines[len(lines)-1]
+		if last["request_id"] != "abc-123" || last["method"] != "GET" || last["path"] != "/team/get" || last["status"] != float64(200) {
+			t.Fatalf("Unexpected access log %v", last)
+		}
+		if _, ok := last["latency_ms"]; !ok {
+			t.Fatalf("Access log has no latency: %v", last)
+		}
+	})
+
+	t.Run("Malformed ID is replaced", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		req.Header.Set("X-Request-ID", "bad id\nwith newline")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if got := w.Header().Get("X-Request-ID"); got == "" || strings.Contains(got, " ") {
+			t.Fatalf("Expected a generated request ID, got %q", got)
+		}
+	})
+
+	t.Run("Error responses carry the ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/pullRequest/get", nil)
+		req.Header.Set("X-Request-ID", "err-1")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var errResp handler.ErrorResponse
+		if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+			t.Fatalf("Failed to decode error response: %v", err)
+		}
+		if errResp.Error.RequestID != "err-1" {
+			t.Fatalf("Expected request_id err-1, got %+v", errResp)
+		}
+	})
+}
+
+func TestInternalErrorsAreLogged(t *testing.T) {
+	var logs bytes.Buffer
+	stub := &stubService{err: errors.New("pq: connection refused")}
+	router := logging.Middleware(logging.New(&logs, "info"), newStubRouter(stub))
+
+	req := httptest.NewRequest(http.MethodGet, "/team/get?team_name=backend", nil)
+	req.Header.Set("X-Request-ID", "boom-1")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("Expected status 500, got %d", w.Code)
+	}
+	if strings.Contains(w.Body.String(), "connection refused") {
+		t.Fatal("Internal error detail leaked to the client")
+	}
+
+	for _, line := range decodeLogLines(t, &logs) {
+		if line["level"] == "ERROR" && line["error"] == "pq: connection refused" && line["request_id"] == "boom-1" {
+			return
+		}
+	}
+	t.Fatalf("Internal error was not logged with detail:\n%s", logs.String())
+}
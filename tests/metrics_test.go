@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"github.com/avito-test/pr-reviewer-service/internal/metrics"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMetricsTextFormat(t *testing.T) {
+	registry := metrics.NewRegistry()
+
+	requests := registry.NewCounterVec("requests_total", "Requests.", "path")
+	requests.Inc("/a")
+	requests.Add(2, "/a")
+	requests.Inc(`/"quoted"`)
+
+	latency := registry.NewHistogramVec("latency_seconds", "Latency.", []float64{0.1, 1}, "path")
+	latency.Observe(0.05, "/a")
+	latency.Observe(0.5, "/a")
+	latency.Observe(3, "/a")
+
+	registry.NewGaugeFunc("temperature", "Current temperature.", func() float64 { return 21.5 })
+
+	var out strings.Builder
+	if err := registry.WriteText(&out); err != nil {
+		t.Fatalf("Failed to write metrics: %v", err)
+	}
+
+	for _, line := range []string{
+		"# TYPE requests_total counter",
+		`requests_total{path="/a"} 3`,
+		`requests_total{path="/\"quoted\""} 1`,
+		"# TYPE latency_seconds histogram",
+		`latency_seconds_bucket{path="/a",le="0.1"} 1`,
+		`latency_seconds_bucket{path="/a",le="1"} 2`,
+		`latency_seconds_bucket{path="/a",le="+Inf"} 3`,
+		`latency_seconds_sum{path="/a"} 3.55`,
+		`latency_seconds_count{path="/a"} 3`,
+		"# TYPE temperature gauge",
+		"temperature 21.5",
+	} {
+		if !strings.Contains(out.String(), line+"\n") {
+			t.Errorf("Missing line %q in:\n%s", line, out.String())
+		}
+	}
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	m := metrics.New()
+	router := m.Middleware(newStubRouter(&stubService{}))
+
+	for _, path := range []string{"/team/get?team_name=backend", "/team/get?team_name=other", "/v2/users/u1", "/v2/users/u2", "/v2/nope", "/nope"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	m.PRCreated()
+	m.ReviewersAssigned(2)
+	m.NoCandidate()
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if !strings.HasPrefix(w.Header().Get("Content-Type"), "text/plain; version=0.0.4") {
+		t.Fatalf("Unexpected Content-Type %q", w.Header().Get("Content-Type"))
+	}
+
+	body := w.Body.String()
+	for _, line := range []string{
+		`pr_reviewer_http_requests_total{method="GET",route="/team/get",code="200"} 2`,
+		`pr_reviewer_http_requests_total{method="GET",route="/v2/users/{user_id}",code="200"} 2`,
+		`pr_reviewer_http_requests_total{method="GET",route="/v2/",code="404"} 1`,
+		`pr_reviewer_http_requests_total{method="GET",route="unmatched",code="404"} 1`,
+		`pr_reviewer_http_request_duration_seconds_count{method="GET",route="/team/get",code="200"} 2`,
+		"pr_reviewer_prs_created_total 1",
+		"pr_reviewer_reviewers_assigned_total 2",
+		"pr_reviewer_no_candidate_total 1",
+		"pr_reviewer_prs_merged_total 0",
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("Missing line %q in:\n%s", line, body)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"github.com/avito-test/pr-reviewer-service/internal/repository/postgres"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeMigrations(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestLoadMigrations(t *testing.T) {
+	t.Run("Repository migrations are ordered pairs", func(t *testing.T) {
+		migrations, err := postgres.LoadMigrations("../migrations")
+		if err != nil {
+			t.Fatalf("Failed to load migrations: %v", err)
+		}
+		for i, m := range migrations {
+			if m.Version != i+1 {
+				t.Fatalf("Expected version %d at position %d, got %d", i+1, i, m.Version)
+			}
+			if m.Up == "" || m.Down == "" || len(m.Checksum) != 64 {
+				t.Fatalf("Migration %d_%s is incomplete", m.Version, m.Name)
+			}
+		}
+	})
+
+	t.Run("Ordering is numeric", func(t *testing.T) {
+		dir := writeMigrations(t, map[string]string{
+			"10_later.up.sql":  "SELECT 10;",
+			"2_second.up.sql":  "SELECT 2;",
+			"1_first.up.sql":   "SELECT 1;",
+			"1_first.down.sql": "SELECT -1;",
+			"README.md":        "ignored",
+		})
+
+		migrations, err := postgres.LoadMigrations(dir)
+		if err != nil {
+			t.Fatalf("Failed to load migrations: %v", err)
+		}
+		if len(migrations) != 3 || migrations[0].Version != 1 || migrations[1].Version != 2 || migrations[2].Version != 10 {
+			t.Fatalf("Unexpected order %+v", migrations)
+		}
+		if migrations[0].Down != "SELECT -1;" || migrations[1].Down != "" {
+			t.Fatalf("Down scripts were not paired: %+v", migrations)
+		}
+	})
+
+	t.Run("Checksum follows the up script", func(t *testing.T) {
+		a, _ := postgres.LoadMigrations(writeMigrations(t, map[string]string{"1_init.up.sql": "SELECT 1;"}))
+		b, _ := postgres.LoadMigrations(writeMigrations(t, map[string]string{"1_init.up.sql": "SELECT 2;"}))
+		if a[0].Checksum == b[0].Checksum {
+			t.Fatal("Different scripts produced the same checksum")
+		}
+	})
+
+	invalid := map[string]map[string]string{
+		"Unversioned file": {"init.sql": "SELECT 1;"},
+		"Missing up file":  {"1_init.down.sql": "SELECT 1;"},
+		"Duplicate version": {
+			"1_init.up.sql":  "SELECT 1;",
+			"1_other.up.sql": "SELECT 1;",
+		},
+	}
+	for name, files := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if _, err := postgres.LoadMigrations(writeMigrations(t, files)); err == nil {
+				t.Fatal("Expected an error")
+			}
+		})
+	}
+}
+
+func TestMigrator(t *testing.T) {
+	requirePostgres(t)
+
+	db := setupTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	migrator, err := postgres.NewMigrator(db, "../migrations")
+	if err != nil {
+		t.Fatalf("Failed to load migrations: %v", err)
+	}
+
+	assertVersion := func(expected int) {
+		t.Helper()
+		version, err := postgres.SchemaVersion(ctx, db)
+		if err != nil {
+			t.Fatalf("Failed to read schema version: %v", err)
+		}
+		if version != expected {
+			t.Fatalf("Expected schema version %d, got %d", expected, version)
+		}
+	}
+
+	assertVersion(migrator.Latest())
+
+	// A second boot against a migrated database is a no-op.
+	if err := migrator.Up(ctx); err != nil {
+		t.Fatalf("Repeated up failed: %v", err)
+	}
+
+	if err := migrator.Down(ctx, 2); err != nil {
+		t.Fatalf("Down failed: %v", err)
+	}
+	assertVersion(migrator.Latest() - 2)
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if !statuses[0].Applied || statuses[len(statuses)-1].Applied {
+		t.Fatalf("Unexpected status %+v", statuses)
+	}
+
+	if err := migrator.To(ctx, 0); err != nil {
+		t.Fatalf("Migrating to 0 failed: %v", err)
+	}
+	assertVersion(0)
+
+	if err := migrator.To(ctx, migrator.Latest()); err != nil {
+		t.Fatalf("Migrating back up failed: %v", err)
+	}
+	assertVersion(migrator.Latest())
+
+	if err := migrator.To(ctx, 999); err == nil {
+		t.Fatal("Expected an unknown version to be rejected")
+	}
+
+	// Editing an applied migration must stop the runner.
+	dir := t.TempDir()
+	migrations, _ := postgres.LoadMigrations("../migrations")
+	for _, m := range migrations {
+		up := m.Up
+		if m.Version == 1 {
+			up += "\n-- edited\n"
+		}
+		os.WriteFile(filepath.Join(dir, fmt.Sprintf("%03d_%s.up.sql", m.Version, m.Name)), []byte(up), 0o644)
+	}
+	edited, err := postgres.NewMigrator(db, dir)
+	if err != nil {
+		t.Fatalf("Failed to load edited migrations: %v", err)
+	}
+	if err := edited.Up(ctx); err == nil || !strings.Contains(err.Error(), "modified") {
+		t.Fatalf("Expected a checksum error, got %v", err)
+	}
+}
@@ -0,0 +1,212 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+type openAPISpec struct {
+	doc map[string]interface{}
+}
+
+func loadOpenAPISpec(t *testing.T, router http.Handler) *openAPISpec {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 for /openapi.json, got %d", w.Code)
+	}
+
+	var doc map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&doc); err != nil {
+		t.Fatalf("Failed to decode OpenAPI document: %v", err)
+	}
+
+	if version, _ := doc["openapi"].(string); !strings.HasPrefix(version, "3.") {
+		t.Fatalf("Expected OpenAPI 3 document, got version %q", version)
+	}
+
+	return &openAPISpec{doc: doc}
+}
+
+func (s *openAPISpec) paths() []string {
+	paths, _ := s.doc["paths"].(map[string]interface{})
+	result := make([]string, 0, len(paths))
+	for path := range paths {
+		result = append(result, path)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func (s *openAPISpec) operations(path string) []string {
+	item, _ := s.lookup("paths", path).(map[string]interface{})
+	var methods []string
+	for method := range item {
+		switch method {
+		case "get", "post", "put", "patch", "delete":
+			methods = append(methods, strings.ToUpper(method))
+		}
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+func (s *openAPISpec) lookup(keys ...string) interface{} {
+	var node interface{} = s.doc
+	for _, key := range keys {
+		m, ok := node.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		node = m[key]
+	}
+	return node
+}
+
+func (s *openAPISpec) resolve(node interface{}) interface{} {
+	for {
+		m, ok := node.(map[string]interface{})
+		if !ok {
+			return node
+		}
+		ref, ok := m["$ref"].(string)
+		if !ok {
+			return node
+		}
+		node = s.lookup(strings.Split(strings.TrimPrefix(ref, "#/"), "/")...)
+	}
+}
+
+func (s *openAPISpec) responseSchema(path, method string, status int) (interface{}, error) {
+	operation := s.lookup("paths", path, strings.ToLower(method))
+	if operation == nil {
+		return nil, fmt.Errorf("operation %s %s is not documented", method, path)
+	}
+
+	responses, _ := operation.(map[string]interface{})["responses"].(map[string]interface{})
+	response := s.resolve(responses[fmt.Sprint(status)])
+	if response == nil {
+		return nil, fmt.Errorf("status %d is not documented for %s %s", status, method, path)
+	}
+
+	content, _ := response.(map[string]interface{})["content"].(map[string]interface{})
+	media, _ := content["application/json"].(map[string]interface{})
+	if media == nil {
+		return nil, fmt.Errorf("no application/json content for %d of %s %s", status, method, path)
+	}
+
+	return media["schema"], nil
+}
+
+func (s *openAPISpec) validate(schema, value interface{}, at string) []string {
+	node, _ := s.resolve(schema).(map[string]interface{})
+	if node == nil {
+		return nil
+	}
+
+	if value == nil {
+		if nullable, _ := node["nullable"].(bool); nullable {
+			return nil
+		}
+		if _, typed := node["type"]; typed {
+			return []string{fmt.Sprintf("%s: unexpected null", at)}
+		}
+		return nil
+	}
+
+	if enum, ok := node["enum"].([]interface{}); ok {
+		found := false
+		for _, allowed := range enum {
+			if allowed == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return []string{fmt.Sprintf("%s: %v is not one of %v", at, value, enum)}
+		}
+	}
+
+	var errs []string
+
+	switch node["type"] {
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected object, got %T", at, value)}
+		}
+
+		required, _ := node["required"].([]interface{})
+		for _, name := range required {
+			if _, ok := obj[name.(string)]; !ok {
+				errs = append(errs, fmt.Sprintf("%s: missing required property %q", at, name))
+			}
+		}
+
+		properties, _ := node["properties"].(map[string]interface{})
+		for name, propValue := range obj {
+			if propSchema, ok := properties[name]; ok {
+				errs = append(errs, s.validate(propSchema, propValue, at+"."+name)...)
+				continue
+			}
+
+			switch additional := node["additionalProperties"].(type) {
+			case nil:
+				// Responses must not carry fields the spec does not know about,
+				// unless the schema is a free-form object.
+				if properties != nil {
+					errs = append(errs, fmt.Sprintf("%s: undocumented property %q", at, name))
+				}
+			case bool:
+				if !additional {
+					errs = append(errs, fmt.Sprintf("%s: undocumented property %q", at, name))
+				}
+			default:
+				errs = append(errs, s.validate(additional, propValue, at+"."+name)...)
+			}
+		}
+	case "array":
+		arr, ok := value.([]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected array, got %T", at, value)}
+		}
+		for i, item := range arr {
+			errs = append(errs, s.validate(node["items"], item, fmt.Sprintf("%s[%d]", at, i))...)
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected string, got %T", at, value)}
+		}
+		if node["format"] == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, str); err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %q is not a date-time", at, str))
+			}
+		}
+	case "integer":
+		num, ok := value.(float64)
+		if !ok || num != float64(int64(num)) {
+			return []string{fmt.Sprintf("%s: expected integer, got %v", at, value)}
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			return []string{fmt.Sprintf("%s: expected number, got %T", at, value)}
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return []string{fmt.Sprintf("%s: expected boolean, got %T", at, value)}
+		}
+	}
+
+	return errs
+}
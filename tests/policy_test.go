@@ -0,0 +1,269 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/avito-test/pr-reviewer-service/internal/handler"
+	"github.com/avito-test/pr-reviewer-service/internal/policy"
+	"github.com/avito-test/pr-reviewer-service/internal/service"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestPolicyParse(t *testing.T) {
+	t.Run("Teams inherit unset fields from the default", func(t *testing.T) {
+		snapshot, err := policy.Parse([]byte(`
+default:
+  reviewer_count: 3
+  fallback_teams: [platform]
+  exclude: [lead]
+teams:
+  backend:
+    strategy: least_loaded
+  platform:
+    exclude: []
+`), ".yaml", policy.Default())
+		if err != nil {
+			t.Fatalf("Failed to parse policies: %v", err)
+		}
+
+		backend := snapshot.For("backend")
+		if backend.Strategy != policy.StrategyLeastLoaded || backend.ReviewerCount != 3 {
+			t.Fatalf("Expected least_loaded with 3 reviewers, got %+v", backend)
+		}
+		if len(backend.FallbackTeams) != 1 || !backend.Excludes("lead") {
+			t.Fatalf("Expected inherited fallback and exclusion, got %+v", backend)
+		}
+
+		platform := snapshot.For("platform")
+		if len(platform.FallbackTeams) != 0 || len(platform.Exclude) != 0 {
+			t.Fatalf("Expected platform to drop itself as fallback and clear exclusions, got %+v", platform)
+		}
+
+		if other := snapshot.For("mobile"); other.Strategy != policy.StrategyRandom || other.ReviewerCount != 3 {
+			t.Fatalf("Expected unknown teams to get the default, got %+v", other)
+		}
+	})
+
+	t.Run("TOML is accepted", func(t *testing.T) {
+		snapshot, err := policy.Parse([]byte(`
+[default]
+reviewer_count = 1
+
+[teams.backend]
+fallback_teams = ["platform"]
+`), ".toml", policy.Default())
+		if err != nil {
+			t.Fatalf("Failed to parse policies: %v", err)
+		}
+		if p := snapshot.For("backend"); p.ReviewerCount != 1 || p.FallbackTeams[0] != "platform" {
+			t.Fatalf("Unexpected backend policy: %+v", p)
+		}
+	})
+
+	t.Run("Invalid files are rejected with every problem", func(t *testing.T) {
+		_, err := policy.Parse([]byte(`
+default:
+  strategy: round_robin
+teams:
+  backend:
+    reviewer_count: 11
+    fallback_teams: [backend, platform, platform]
+`), ".yaml", policy.Default())
+		if err == nil {
+			t.Fatal("Expected validation error")
+		}
+		for _, want := range []string{"default.strategy", "teams.backend.reviewer_count", "the team itself", "platform twice"} {
+			if !strings.Contains(err.Error(), want) {
+				t.Fatalf("Expected error to mention %q, got:\n%v", want, err)
+			}
+		}
+
+		if _, err := policy.Parse([]byte("teams:\n  backend:\n    reviewers: 2\n"), ".yaml", policy.Default()); err == nil {
+			t.Fatal("Expected unknown key to be rejected")
+		}
+	})
+}
+
+func TestPolicyStoreReload(t *testing.T) {
+	path := writeConfig(t, "policies.yaml", "teams:\n  backend:\n    reviewer_count: 1\n")
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write policies: %v", err)
+		}
+	}
+
+	store, err := policy.NewStore(path, policy.Policy{ReviewerCount: 2})
+	if err != nil {
+		t.Fatalf("Failed to load policies: %v", err)
+	}
+	if got := store.Current().For("backend").ReviewerCount; got != 1 {
+		t.Fatalf("Expected 1 reviewer for backend, got %d", got)
+	}
+
+	t.Run("Unchanged file is not reloaded", func(t *testing.T) {
+		changed, err := store.Reload()
+		if err != nil || changed || store.Current().Version != 1 {
+			t.Fatalf("Expected no-op reload, got changed=%v err=%v version=%d", changed, err, store.Current().Version)
+		}
+	})
+
+	t.Run("Changed file is swapped in", func(t *testing.T) {
+		write("teams:\n  backend:\n    reviewer_count: 3\n")
+		changed, err := store.Reload()
+		if err != nil || !changed {
+			t.Fatalf("Expected reload, got changed=%v err=%v", changed, err)
+		}
+		if snapshot := store.Current(); snapshot.Version != 2 || snapshot.For("backend").ReviewerCount != 3 {
+			t.Fatalf("Expected version 2 with 3 reviewers, got %+v", snapshot)
+		}
+	})
+
+	t.Run("Invalid file keeps the previous policies", func(t *testing.T) {
+		write("teams:\n  backend:\n    reviewer_count: 0\n    strategy: fastest\n")
+		if _, err := store.Reload(); err == nil {
+			t.Fatal("Expected reload error")
+		}
+		snapshot := store.Current()
+		if snapshot.Version != 2 || snapshot.For("backend").ReviewerCount != 3 {
+			t.Fatalf("Expected previous policies to stay, got %+v", snapshot)
+		}
+		if !strings.Contains(snapshot.ReloadError, "teams.backend.strategy") {
+			t.Fatalf("Expected reload error to be reported, got %q", snapshot.ReloadError)
+		}
+
+		write("teams:\n  backend:\n    reviewer_count: 3\n")
+		if _, err := store.Reload(); err != nil {
+			t.Fatalf("Failed to reload restored file: %v", err)
+		}
+		if snapshot := store.Current(); snapshot.ReloadError != "" || snapshot.Version != 2 {
+			t.Fatalf("Expected restored file to clear the error, got %+v", snapshot)
+		}
+	})
+
+	t.Run("Invalid file fails startup", func(t *testing.T) {
+		bad := writeConfig(t, "bad.yaml", "default:\n  reviewer_count: -1\n")
+		if _, err := policy.NewStore(bad, policy.Default()); err == nil {
+			t.Fatal("Expected NewStore to reject an invalid file")
+		}
+	})
+}
+
+func TestIntegrationAssignmentPolicies(t *testing.T) {
+	path := writeConfig(t, "policies.yaml", `
+teams:
+  backend:
+    reviewer_count: 3
+    fallback_teams: [platform]
+    exclude: [b3]
+  solo:
+    reviewer_count: 1
+    strategy: least_loaded
+`)
+	store, err := policy.NewStore(path, policy.Default())
+	if err != nil {
+		t.Fatalf("Failed to load policies: %v", err)
+	}
+
+	svc := service.NewServiceWithOptions(newTestRepository(t), service.Options{Policies: store})
+	router := handler.NewHandler(svc).InitRoutes()
+
+	createTeam(t, router, domain.Team{
+		TeamName: "backend",
+		Members: []domain.TeamMember{
+			{UserID: "b1", Username: "Bob", IsActive: true},
+			{UserID: "b2", Username: "Bea", IsActive: true},
+			{UserID: "b3", Username: "Ben", IsActive: true},
+		},
+	})
+	createTeam(t, router, domain.Team{
+		TeamName: "platform",
+		Members: []domain.TeamMember{
+			{UserID: "p1", Username: "Pat", IsActive: true},
+		},
+	})
+	createTeam(t, router, domain.Team{
+		TeamName: "solo",
+		Members: []domain.TeamMember{
+			{UserID: "s1", Username: "Sam", IsActive: true},
+			{UserID: "s2", Username: "Sue", IsActive: true},
+			{UserID: "s3", Username: "Sid", IsActive: true},
+		},
+	})
+
+	createPR := func(t *testing.T, prID, authorID string, requested ...string) *httptest.ResponseRecorder {
+		t.Helper()
+		return doJSON(t, router, http.MethodPost, "/pullRequest/create", map[string]interface{}{
+			"pull_request_id":     prID,
+			"pull_request_name":   prID,
+			"author_id":           authorID,
+			"requested_reviewers": requested,
+		})
+	}
+
+	t.Run("Fallback teams fill up and exclusions are skipped", func(t *testing.T) {
+		w := createPR(t, "pr-fallback", "b1")
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+		}
+		pr := decodePR(t, w)
+		if len(pr.AssignedReviewers) != 2 || !containsString(pr.AssignedReviewers, "b2") || !containsString(pr.AssignedReviewers, "p1") {
+			t.Fatalf("Expected b2 and fallback p1 without excluded b3, got %v", pr.AssignedReviewers)
+		}
+	})
+
+	t.Run("Exclusions do not apply to requested reviewers", func(t *testing.T) {
+		w := createPR(t, "pr-requested-excluded", "b1", "b3")
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+		}
+		if pr := decodePR(t, w); len(pr.AssignedReviewers) != 3 {
+			t.Fatalf("Expected b3, b2 and p1, got %v", pr.AssignedReviewers)
+		}
+	})
+
+	t.Run("Fallback team members may be requested", func(t *testing.T) {
+		w := createPR(t, "pr-requested-fallback", "b2", "p1")
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("Least loaded picks the member with fewest assignments", func(t *testing.T) {
+		// s2 reviews two PRs and s3 one, so the next pick must be s3.
+		for i, requested := range []string{"s2", "s3", "s2"} {
+			w := createPR(t, fmt.Sprintf("pr-load-%d", i), "s1", requested)
+			if w.Code != http.StatusCreated {
+				t.Fatalf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+			}
+		}
+
+		w := createPR(t, "pr-least-loaded", "s1")
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+		}
+		if pr := decodePR(t, w); len(pr.AssignedReviewers) != 1 || pr.AssignedReviewers[0] != "s3" {
+			t.Fatalf("Expected the less loaded s3, got %v", pr.AssignedReviewers)
+		}
+	})
+
+	t.Run("Policies endpoint shows the snapshot", func(t *testing.T) {
+		w := doJSON(t, router, http.MethodGet, "/policies", nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+
+		var snapshot policy.Snapshot
+		if err := json.NewDecoder(w.Body).Decode(&snapshot); err != nil {
+			t.Fatalf("Failed to decode policies: %v", err)
+		}
+		if snapshot.Version != 1 || snapshot.Source != path || snapshot.For("solo").Strategy != policy.StrategyLeastLoaded {
+			t.Fatalf("Unexpected snapshot: %+v", snapshot)
+		}
+	})
+}
@@ -0,0 +1,849 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/avito-test/pr-reviewer-service/internal/repository"
+	"github.com/avito-test/pr-reviewer-service/internal/repository/memory"
+	"github.com/avito-test/pr-reviewer-service/internal/repository/postgres"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// The conformance suite pins down the repository semantics the service relies
+// on, so every backend is run through the same cases.
+
+func TestMemoryRepositoryConformance(t *testing.T) {
+	runRepositoryConformance(t, func(t *testing.T) *repository.Repository {
+		return memory.NewRepository()
+	})
+}
+
+func TestSQLiteRepositoryConformance(t *testing.T) {
+	runRepositoryConformance(t, newSQLiteRepository)
+}
+
+func TestPostgresRepositoryConformance(t *testing.T) {
+	requirePostgres(t)
+
+	runRepositoryConformance(t, func(t *testing.T) *repository.Repository {
+		db := setupTestDB(t)
+		t.Cleanup(func() { db.Close() })
+		return postgres.NewRepository(db)
+	})
+}
+
+// seedRepository creates backend (u1-u3 active, u4 inactive) and frontend
+// (f1).
+func seedRepository(t *testing.T, repo *repository.Repository) {
+	t.Helper()
+
+	ctx := context.Background()
+	users := []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserID: "u3", Username: "Carol", TeamName: "backend", IsActive: true},
+		{UserID: "u4", Username: "Dan", TeamName: "backend", IsActive: false},
+		{UserID: "f1", Username: "Fay", TeamName: "frontend", IsActive: true},
+	}
+	for _, team := range []string{"backend", "frontend"} {
+		if err := repo.Team.CreateTeam(ctx, team); err != nil {
+			t.Fatalf("CreateTeam(%s): %v", team, err)
+		}
+	}
+	for i := range users {
+		if err := repo.User.CreateOrUpdateUser(ctx, &users[i]); err != nil {
+			t.Fatalf("CreateOrUpdateUser(%s): %v", users[i].UserID, err)
+		}
+	}
+}
+
+func createTestPR(t *testing.T, repo *repository.Repository, id, author string, reviewers ...string) {
+	t.Helper()
+
+	pr := &domain.PullRequest{
+		PullRequestID:     id,
+		PullRequestName:   "Change " + id,
+		AuthorID:          author,
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: reviewers,
+	}
+	if err := repo.PullRequest.CreatePR(context.Background(), pr); err != nil {
+		t.Fatalf("CreatePR(%s): %v", id, err)
+	}
+}
+
+func userIDs(users []domain.User) []string {
+	var ids []string
+	for _, user := range users {
+		ids = append(ids, user.UserID)
+	}
+	return ids
+}
+
+func historyActions(events []domain.AssignmentEvent) []string {
+	var actions []string
+	for _, event := range events {
+		actions = append(actions, string(event.Action)+":"+event.PreviousUserID+">"+event.UserID)
+	}
+	return actions
+}
+
+func runRepositoryConformance(t *testing.T, newRepo func(t *testing.T) *repository.Repository) {
+	ctx := context.Background()
+
+	t.Run("Teams", func(t *testing.T) {
+		repo := newRepo(t)
+
+		if _, err := repo.Team.GetTeam(ctx, "backend"); !errors.Is(err, domain.ErrTeamNotFound) {
+			t.Fatalf("Expected ErrTeamNotFound, got %v", err)
+		}
+		seedRepository(t, repo)
+
+		if exists, err := repo.Team.TeamExists(ctx, "backend"); err != nil || !exists {
+			t.Fatalf("Expected backend to exist, got %v %v", exists, err)
+		}
+		if exists, _ := repo.Team.TeamExists(ctx, "ghosts"); exists {
+			t.Fatal("Unexpected team ghosts")
+		}
+		if err := repo.Team.CreateTeam(ctx, "backend"); err == nil {
+			t.Fatal("Expected duplicate team to fail")
+		}
+
+		team, err := repo.Team.GetTeam(ctx, "backend")
+		if err != nil {
+			t.Fatalf("GetTeam: %v", err)
+		}
+		if len(team.Members) != 4 || team.Members[0].UserID != "u1" || team.Members[3].IsActive {
+			t.Fatalf("Unexpected members %+v", team.Members)
+		}
+
+		repo.Team.CreateTeam(ctx, "empty")
+		if team, _ := repo.Team.GetTeam(ctx, "empty"); team == nil || team.Members == nil || len(team.Members) != 0 {
+			t.Fatalf("Expected an empty, non-nil member list, got %+v", team)
+		}
+	})
+
+	t.Run("Users", func(t *testing.T) {
+		repo := newRepo(t)
+		seedRepository(t, repo)
+
+		if _, err := repo.User.GetUser(ctx, "nobody"); !errors.Is(err, domain.ErrUserNotFound) {
+			t.Fatalf("Expected ErrUserNotFound, got %v", err)
+		}
+		if err := repo.User.SetIsActive(ctx, "nobody", true); !errors.Is(err, domain.ErrUserNotFound) {
+			t.Fatalf("Expected ErrUserNotFound, got %v", err)
+		}
+
+		moved := domain.User{UserID: "u3", Username: "Caroline", TeamName: "frontend", IsActive: true}
+		if err := repo.User.CreateOrUpdateUser(ctx, &moved); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		moved.Version = 2 // seeded at 1, bumped by the update
+		if user, _ := repo.User.GetUser(ctx, "u3"); !reflect.DeepEqual(*user, moved) {
+			t.Fatalf("Expected %+v, got %+v", moved, user)
+		}
+
+		if err := repo.User.SetIsActive(ctx, "u4", true); err != nil {
+			t.Fatalf("SetIsActive: %v", err)
+		}
+
+		backend, _ := repo.User.GetUsersByTeam(ctx, "backend")
+		if got := userIDs(backend); !reflect.DeepEqual(got, []string{"u1", "u2", "u4"}) {
+			t.Fatalf("Unexpected backend users %v", got)
+		}
+		active, _ := repo.User.GetActiveTeamMembers(ctx, "backend", "u1")
+		if got := userIDs(active); !reflect.DeepEqual(got, []string{"u2", "u4"}) {
+			t.Fatalf("Unexpected active members %v", got)
+		}
+		if none, _ := repo.User.GetUsersByTeam(ctx, "ghosts"); len(none) != 0 {
+			t.Fatalf("Expected no users, got %v", none)
+		}
+	})
+
+	t.Run("Import", func(t *testing.T) {
+		repo := newRepo(t)
+		seedRepository(t, repo)
+
+		apply := func(plan domain.ImportPlan) func(domain.ImportSnapshot) (*domain.ImportPlan, error) {
+			return func(domain.ImportSnapshot) (*domain.ImportPlan, error) { return &plan, nil }
+		}
+
+		err := repo.Team.ImportTeams(ctx, []domain.Team{{TeamName: "ghosts"}}, apply(domain.ImportPlan{
+			Teams: []string{"ghosts"},
+			Users: []domain.User{
+				{UserID: "g1", Username: "Gus", TeamName: "ghosts", IsActive: true},
+				{UserID: "x1", Username: "Xena", TeamName: "nowhere", IsActive: true},
+			},
+		}))
+		if err == nil {
+			t.Fatal("Expected a user of an unknown team to fail the import")
+		}
+		if exists, _ := repo.Team.TeamExists(ctx, "ghosts"); exists {
+			t.Fatal("Expected a failed import to create nothing")
+		}
+		if _, err := repo.User.GetUser(ctx, "g1"); !errors.Is(err, domain.ErrUserNotFound) {
+			t.Fatalf("Expected a failed import to create no users, got %v", err)
+		}
+
+		teams := []domain.Team{
+			{TeamName: "mobile", Members: []domain.TeamMember{{UserID: "m1"}, {UserID: "u3"}}},
+			{TeamName: "frontend"},
+		}
+		var snapshot domain.ImportSnapshot
+		err = repo.Team.ImportTeams(ctx, teams, func(current domain.ImportSnapshot) (*domain.ImportPlan, error) {
+			snapshot = current
+			return nil, nil
+		})
+		if err != nil {
+			t.Fatalf("ImportTeams without a plan: %v", err)
+		}
+		if !reflect.DeepEqual(snapshot.Teams, map[string]bool{"frontend": true}) {
+			t.Fatalf("Expected only frontend to exist, got %v", snapshot.Teams)
+		}
+		if len(snapshot.Users) != 2 || snapshot.Users["u3"].TeamName != "backend" || snapshot.Users["f1"].Username != "Fay" {
+			t.Fatalf("Expected the listed user and the frontend member, got %+v", snapshot.Users)
+		}
+
+		failed := errors.New("rejected")
+		err = repo.Team.ImportTeams(ctx, teams, func(domain.ImportSnapshot) (*domain.ImportPlan, error) {
+			return nil, failed
+		})
+		if !errors.Is(err, failed) {
+			t.Fatalf("Expected the planning error, got %v", err)
+		}
+
+		err = repo.Team.ImportTeams(ctx, teams, apply(domain.ImportPlan{
+			Teams: []string{"mobile", "frontend"},
+			Users: []domain.User{
+				{UserID: "m1", Username: "Max", TeamName: "mobile", IsActive: true},
+				{UserID: "u3", Username: "Carol", TeamName: "mobile", IsActive: true},
+				{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: false},
+			},
+		}))
+		if err != nil {
+			t.Fatalf("ImportTeams: %v", err)
+		}
+
+		mobile, _ := repo.User.GetUsersByTeam(ctx, "mobile")
+		if got := userIDs(mobile); !reflect.DeepEqual(got, []string{"m1", "u3"}) {
+			t.Fatalf("Unexpected mobile users %v", got)
+		}
+		if user, _ := repo.User.GetUser(ctx, "u2"); user.IsActive {
+			t.Fatal("Expected u2 to be deactivated")
+		}
+		if frontend, _ := repo.User.GetUsersByTeam(ctx, "frontend"); len(frontend) != 1 {
+			t.Fatalf("Expected existing teams in the plan to be kept, got %v", frontend)
+		}
+	})
+
+	t.Run("Exclusion rules", func(t *testing.T) {
+		repo := newRepo(t)
+		seedRepository(t, repo)
+
+		pair := domain.ExclusionRule{Kind: domain.ExclusionPair, UserID: "u1", OtherUserID: "u2", Reason: "manager"}
+		if err := repo.Exclusion.CreateRule(ctx, &pair); err != nil {
+			t.Fatalf("CreateRule: %v", err)
+		}
+		if pair.RuleID == 0 || pair.CreatedAt.IsZero() {
+			t.Fatalf("Expected the rule ID and creation time to be filled in, got %+v", pair)
+		}
+		author := domain.ExclusionRule{Kind: domain.ExclusionAuthor, UserID: "u3", OtherUserID: "u1"}
+		if err := repo.Exclusion.CreateRule(ctx, &author); err != nil {
+			t.Fatalf("CreateRule: %v", err)
+		}
+
+		duplicate := domain.ExclusionRule{Kind: domain.ExclusionPair, UserID: "u1", OtherUserID: "u2"}
+		if err := repo.Exclusion.CreateRule(ctx, &duplicate); !errors.Is(err, domain.ErrRuleExists) {
+			t.Fatalf("Expected ErrRuleExists, got %v", err)
+		}
+
+		rules, _ := repo.Exclusion.ListRules(ctx, "u1")
+		if len(rules) != 2 || rules[0].RuleID != pair.RuleID || rules[0].Reason != "manager" || rules[1].Kind != domain.ExclusionAuthor {
+			t.Fatalf("Unexpected rules for u1 %+v", rules)
+		}
+		if rules, _ := repo.Exclusion.ListRules(ctx, "u3"); len(rules) != 1 || rules[0].RuleID != author.RuleID {
+			t.Fatalf("Unexpected rules for u3 %+v", rules)
+		}
+		if rules, _ := repo.Exclusion.ListRules(ctx, "f1"); rules == nil || len(rules) != 0 {
+			t.Fatalf("Expected an empty list for f1, got %#v", rules)
+		}
+
+		if err := repo.Exclusion.DeleteRule(ctx, pair.RuleID); err != nil {
+			t.Fatalf("DeleteRule: %v", err)
+		}
+		if err := repo.Exclusion.DeleteRule(ctx, pair.RuleID); !errors.Is(err, domain.ErrRuleNotFound) {
+			t.Fatalf("Expected ErrRuleNotFound, got %v", err)
+		}
+		if rules, _ := repo.Exclusion.ListRules(ctx, ""); len(rules) != 1 || rules[0].RuleID != author.RuleID {
+			t.Fatalf("Unexpected rules after delete %+v", rules)
+		}
+	})
+
+	t.Run("Skills and required tags", func(t *testing.T) {
+		repo := newRepo(t)
+		seedRepository(t, repo)
+
+		if err := repo.User.SetSkills(ctx, "nobody", []string{"db"}); !errors.Is(err, domain.ErrUserNotFound) {
+			t.Fatalf("Expected ErrUserNotFound, got %v", err)
+		}
+		if err := repo.User.SetSkills(ctx, "u2", []string{"db", "go"}); err != nil {
+			t.Fatalf("SetSkills: %v", err)
+		}
+		if err := repo.User.SetSkills(ctx, "u2", []string{"infra", "db"}); err != nil {
+			t.Fatalf("SetSkills: %v", err)
+		}
+		if user, _ := repo.User.GetUser(ctx, "u2"); !reflect.DeepEqual(user.Skills, []string{"db", "infra"}) {
+			t.Fatalf("Expected the skills to be replaced, got %v", user.Skills)
+		}
+
+		// Team upserts must not touch skills.
+		if err := repo.User.CreateOrUpdateUser(ctx, &domain.User{UserID: "u2", Username: "Robert", TeamName: "backend", IsActive: true}); err != nil {
+			t.Fatalf("CreateOrUpdateUser: %v", err)
+		}
+		members, _ := repo.User.GetActiveTeamMembers(ctx, "backend", "u1")
+		if len(members) != 2 || members[0].UserID != "u2" || !reflect.DeepEqual(members[0].Skills, []string{"db", "infra"}) || members[1].Skills != nil {
+			t.Fatalf("Unexpected members %+v", members)
+		}
+
+		pr := &domain.PullRequest{
+			PullRequestID:     "pr-tags",
+			PullRequestName:   "Tagged",
+			AuthorID:          "u1",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"u2", "u3"},
+			RequiredTags:      []string{"db", "infra", "ui"},
+			ReviewerTags:      map[string][]string{"u2": {"db", "infra"}},
+		}
+		if err := repo.PullRequest.CreatePR(ctx, pr); err != nil {
+			t.Fatalf("CreatePR: %v", err)
+		}
+		got, _ := repo.PullRequest.GetPR(ctx, "pr-tags")
+		if !reflect.DeepEqual(got.RequiredTags, pr.RequiredTags) || !reflect.DeepEqual(got.ReviewerTags, pr.ReviewerTags) {
+			t.Fatalf("Unexpected tags %v %v", got.RequiredTags, got.ReviewerTags)
+		}
+
+		var exported []domain.PullRequestDetails
+		err := repo.PullRequest.StreamPRs(ctx, domain.PRFilter{}, func(pr domain.PullRequestDetails) error {
+			exported = append(exported, pr)
+			return nil
+		})
+		if err != nil || len(exported) != 1 || !reflect.DeepEqual(exported[0].RequiredTags, pr.RequiredTags) || !reflect.DeepEqual(exported[0].ReviewerTags, pr.ReviewerTags) {
+			t.Fatalf("Expected the export to carry the tags, got %+v %v", exported, err)
+		}
+
+		if err := repo.PullRequest.ReplaceReviewer(ctx, "pr-tags", "u2", "u4", ""); err != nil {
+			t.Fatalf("ReplaceReviewer: %v", err)
+		}
+		details, _ := repo.PullRequest.GetPRDetails(ctx, []string{"pr-tags"})
+		if len(details) != 1 || len(details[0].RequiredTags) != 3 || details[0].ReviewerTags != nil {
+			t.Fatalf("Expected the replaced reviewer's tags to be dropped, got %+v", details)
+		}
+
+		if err := repo.User.SetSkills(ctx, "u2", nil); err != nil {
+			t.Fatalf("SetSkills: %v", err)
+		}
+		if user, _ := repo.User.GetUser(ctx, "u2"); user.Skills != nil {
+			t.Fatalf("Expected no skills, got %#v", user.Skills)
+		}
+	})
+
+	t.Run("Pull requests", func(t *testing.T) {
+		repo := newRepo(t)
+		seedRepository(t, repo)
+
+		if _, err := repo.PullRequest.GetPR(ctx, "pr-1"); !errors.Is(err, domain.ErrPRNotFound) {
+			t.Fatalf("Expected ErrPRNotFound, got %v", err)
+		}
+		createTestPR(t, repo, "pr-1", "u1", "u3", "u2")
+
+		if exists, _ := repo.PullRequest.PRExists(ctx, "pr-1"); !exists {
+			t.Fatal("Expected pr-1 to exist")
+		}
+		if err := repo.PullRequest.CreatePR(ctx, &domain.PullRequest{PullRequestID: "pr-1", PullRequestName: "dup", AuthorID: "u1", Status: domain.PRStatusOpen}); err == nil {
+			t.Fatal("Expected duplicate PR to fail")
+		}
+
+		pr, err := repo.PullRequest.GetPR(ctx, "pr-1")
+		if err != nil {
+			t.Fatalf("GetPR: %v", err)
+		}
+		if pr.Status != domain.PRStatusOpen || pr.CreatedAt == nil || pr.MergedAt != nil {
+			t.Fatalf("Unexpected PR %+v", pr)
+		}
+		if !reflect.DeepEqual(pr.AssignedReviewers, []string{"u2", "u3"}) {
+			t.Fatalf("Expected reviewers sorted by ID, got %v", pr.AssignedReviewers)
+		}
+
+		if err := repo.PullRequest.MergePR(ctx, "pr-1"); err != nil {
+			t.Fatalf("MergePR: %v", err)
+		}
+		merged, _ := repo.PullRequest.GetPR(ctx, "pr-1")
+		if merged.Status != domain.PRStatusMerged || merged.MergedAt == nil {
+			t.Fatalf("Expected merged PR, got %+v", merged)
+		}
+		time.Sleep(2 * time.Millisecond)
+		repo.PullRequest.MergePR(ctx, "pr-1")
+		if again, _ := repo.PullRequest.GetPR(ctx, "pr-1"); !again.MergedAt.Equal(*merged.MergedAt) {
+			t.Fatal("Merging twice must keep the original merged_at")
+		}
+
+		createTestPR(t, repo, "pr-2", "f1")
+		if pr, _ := repo.PullRequest.GetPR(ctx, "pr-2"); pr.AssignedReviewers == nil || len(pr.AssignedReviewers) != 0 {
+			t.Fatalf("Expected an empty, non-nil reviewer list, got %#v", pr.AssignedReviewers)
+		}
+
+		details, err := repo.PullRequest.GetPRDetails(ctx, []string{"pr-1", "pr-missing"})
+		if err != nil {
+			t.Fatalf("GetPRDetails: %v", err)
+		}
+		if len(details) != 1 || details[0].AuthorTeam != "backend" || len(details[0].Reviewers) != 2 || details[0].Reviewers[0].UserID != "u2" {
+			t.Fatalf("Unexpected details %+v", details)
+		}
+	})
+
+	t.Run("Reviewer changes", func(t *testing.T) {
+		repo := newRepo(t)
+		seedRepository(t, repo)
+		createTestPR(t, repo, "pr-1", "u1", "u2")
+
+		if err := repo.PullRequest.AssignReviewer(ctx, "pr-1", "u3", "extra", 2); err != nil {
+			t.Fatalf("AssignReviewer: %v", err)
+		}
+		if err := repo.PullRequest.AssignReviewer(ctx, "pr-1", "u3", "again", 2); err != nil {
+			t.Fatalf("Repeated AssignReviewer must be a no-op, got %v", err)
+		}
+		if assigned, _ := repo.PullRequest.IsReviewerAssigned(ctx, "pr-1", "u3"); !assigned {
+			t.Fatal("Expected u3 to be assigned")
+		}
+		if err := repo.PullRequest.AssignReviewer(ctx, "pr-1", "f1", "", 2); !errors.Is(err, domain.ErrTooManyReviewers) {
+			t.Fatalf("Expected ErrTooManyReviewers, got %v", err)
+		}
+
+		if err := repo.PullRequest.ReplaceReviewer(ctx, "pr-1", "u4", "f1", ""); !errors.Is(err, domain.ErrNotAssigned) {
+			t.Fatalf("Expected ErrNotAssigned, got %v", err)
+		}
+		if err := repo.PullRequest.ReplaceReviewer(ctx, "pr-1", "u2", "u3", ""); !errors.Is(err, domain.ErrAlreadyAssigned) {
+			t.Fatalf("Expected ErrAlreadyAssigned, got %v", err)
+		}
+		if assigned, _ := repo.PullRequest.IsReviewerAssigned(ctx, "pr-1", "u2"); !assigned {
+			t.Fatal("A failed replacement must leave the old reviewer assigned")
+		}
+		if err := repo.PullRequest.ReplaceReviewer(ctx, "pr-1", "u2", "u4", "swap"); err != nil {
+			t.Fatalf("ReplaceReviewer: %v", err)
+		}
+
+		if err := repo.PullRequest.DeclineReviewer(ctx, "pr-1", "u2", "", "busy"); !errors.Is(err, domain.ErrNotAssigned) {
+			t.Fatalf("Expected ErrNotAssigned, got %v", err)
+		}
+		if err := repo.PullRequest.DeclineReviewer(ctx, "pr-1", "u3", "u4", "busy"); !errors.Is(err, domain.ErrAlreadyAssigned) {
+			t.Fatalf("Expected ErrAlreadyAssigned, got %v", err)
+		}
+		if declined, _ := repo.PullRequest.GetDeclinedReviewers(ctx, "pr-1"); len(declined) != 0 {
+			t.Fatalf("A failed decline must not be recorded, got %v", declined)
+		}
+		if err := repo.PullRequest.DeclineReviewer(ctx, "pr-1", "u3", "u2", "busy"); err != nil {
+			t.Fatalf("DeclineReviewer: %v", err)
+		}
+		if err := repo.PullRequest.DeclineReviewer(ctx, "pr-1", "u4", "", "away"); err != nil {
+			t.Fatalf("DeclineReviewer without replacement: %v", err)
+		}
+		if declined, _ := repo.PullRequest.GetDeclinedReviewers(ctx, "pr-1"); !reflect.DeepEqual(declined, []string{"u3", "u4"}) {
+			t.Fatalf("Unexpected declined reviewers %v", declined)
+		}
+
+		if err := repo.PullRequest.UnassignReviewer(ctx, "pr-1", "u2", "done"); err != nil {
+			t.Fatalf("UnassignReviewer: %v", err)
+		}
+		if err := repo.PullRequest.UnassignReviewer(ctx, "pr-1", "u2", "done"); err != nil {
+			t.Fatalf("Repeated UnassignReviewer must be a no-op, got %v", err)
+		}
+
+		history, err := repo.PullRequest.GetAssignmentHistory(ctx, "pr-1")
+		if err != nil {
+			t.Fatalf("GetAssignmentHistory: %v", err)
+		}
+		expected := []string{
+			"ASSIGNED:>u2",
+			"ASSIGNED:>u3",
+			"REASSIGNED:u2>u4",
+			"DECLINED:>u3",
+			"REASSIGNED:u3>u2",
+			"DECLINED:>u4",
+			"UNASSIGNED:>u2",
+		}
+		if got := historyActions(history); !reflect.DeepEqual(got, expected) {
+			t.Fatalf("Expected history %v, got %v", expected, got)
+		}
+		if history[2].Reason != "swap" || history[0].CreatedAt == nil {
+			t.Fatalf("Unexpected history details %+v", history[2])
+		}
+		if empty, _ := repo.PullRequest.GetAssignmentHistory(ctx, "pr-missing"); empty == nil || len(empty) != 0 {
+			t.Fatalf("Expected an empty, non-nil history, got %#v", empty)
+		}
+	})
+
+	t.Run("Concurrent reassignment", func(t *testing.T) {
+		repo := newRepo(t)
+		seedRepository(t, repo)
+		createTestPR(t, repo, "pr-1", "u1", "u2")
+
+		// Every request replaces the same reviewer; exactly one may win.
+		candidates := []string{"u3", "u4", "f1"}
+		errs := make(chan error, len(candidates))
+		var wg sync.WaitGroup
+		for _, candidate := range candidates {
+			wg.Add(1)
+			go func(candidate string) {
+				defer wg.Done()
+				errs <- repo.PullRequest.ReplaceReviewer(ctx, "pr-1", "u2", candidate, "race")
+			}(candidate)
+		}
+		wg.Wait()
+		close(errs)
+
+		succeeded := 0
+		for err := range errs {
+			switch {
+			case err == nil:
+				succeeded++
+			case !errors.Is(err, domain.ErrNotAssigned):
+				t.Fatalf("Expected ErrNotAssigned for the losers, got %v", err)
+			}
+		}
+		if succeeded != 1 {
+			t.Fatalf("Expected exactly one replacement to succeed, got %d", succeeded)
+		}
+
+		pr, _ := repo.PullRequest.GetPR(ctx, "pr-1")
+		if len(pr.AssignedReviewers) != 1 || pr.AssignedReviewers[0] == "u2" {
+			t.Fatalf("Expected a single new reviewer, got %v", pr.AssignedReviewers)
+		}
+		history, _ := repo.PullRequest.GetAssignmentHistory(ctx, "pr-1")
+		if len(history) != 2 {
+			t.Fatalf("Expected one assignment and one reassignment, got %v", historyActions(history))
+		}
+	})
+
+	t.Run("Concurrent additions", func(t *testing.T) {
+		repo := newRepo(t)
+		seedRepository(t, repo)
+		createTestPR(t, repo, "pr-1", "u1", "u2")
+
+		// One slot is left under the ceiling of two; exactly one may take it.
+		candidates := []string{"u3", "u4", "f1"}
+		errs := make(chan error, len(candidates))
+		var wg sync.WaitGroup
+		for _, candidate := range candidates {
+			wg.Add(1)
+			go func(candidate string) {
+				defer wg.Done()
+				errs <- repo.PullRequest.AssignReviewer(ctx, "pr-1", candidate, "race", 2)
+			}(candidate)
+		}
+		wg.Wait()
+		close(errs)
+
+		succeeded := 0
+		for err := range errs {
+			switch {
+			case err == nil:
+				succeeded++
+			case !errors.Is(err, domain.ErrTooManyReviewers):
+				t.Fatalf("Expected ErrTooManyReviewers for the losers, got %v", err)
+			}
+		}
+		if pr, _ := repo.PullRequest.GetPR(ctx, "pr-1"); succeeded != 1 || len(pr.AssignedReviewers) != 2 {
+			t.Fatalf("Expected exactly one addition, got %d and %v", succeeded, pr.AssignedReviewers)
+		}
+	})
+
+	t.Run("Versions", func(t *testing.T) {
+		repo := newRepo(t)
+		seedRepository(t, repo)
+		createTestPR(t, repo, "pr-1", "u1", "u2")
+
+		user, _ := repo.User.GetUser(ctx, "u3")
+		guarded := domain.WithExpectedVersion(ctx, user.Version)
+		if err := repo.User.SetIsActive(guarded, "u3", false); err != nil {
+			t.Fatalf("SetIsActive at the current version: %v", err)
+		}
+		if err := repo.User.SetIsActive(guarded, "u3", true); !errors.Is(err, domain.ErrPreconditionFailed) {
+			t.Fatalf("Expected ErrPreconditionFailed, got %v", err)
+		}
+		if err := repo.User.SetSkills(guarded, "u3", []string{"db"}); !errors.Is(err, domain.ErrPreconditionFailed) {
+			t.Fatalf("Expected ErrPreconditionFailed, got %v", err)
+		}
+		if user, _ := repo.User.GetUser(ctx, "u3"); user.IsActive || user.Skills != nil {
+			t.Fatalf("A failed precondition must change nothing, got %+v", user)
+		}
+
+		pr, _ := repo.PullRequest.GetPR(ctx, "pr-1")
+		guarded = domain.WithExpectedVersion(ctx, pr.Version)
+		if err := repo.PullRequest.AssignReviewer(guarded, "pr-1", "u3", "", 2); err != nil {
+			t.Fatalf("AssignReviewer at the current version: %v", err)
+		}
+		if err := repo.PullRequest.UnassignReviewer(guarded, "pr-1", "u3", ""); !errors.Is(err, domain.ErrPreconditionFailed) {
+			t.Fatalf("Expected ErrPreconditionFailed, got %v", err)
+		}
+		if err := repo.PullRequest.ReplaceReviewer(guarded, "pr-1", "u2", "f1", ""); !errors.Is(err, domain.ErrPreconditionFailed) {
+			t.Fatalf("Expected ErrPreconditionFailed, got %v", err)
+		}
+		if err := repo.PullRequest.MergePR(guarded, "pr-1"); !errors.Is(err, domain.ErrPreconditionFailed) {
+			t.Fatalf("Expected ErrPreconditionFailed, got %v", err)
+		}
+		updated, _ := repo.PullRequest.GetPR(ctx, "pr-1")
+		if updated.Version <= pr.Version || updated.Status != domain.PRStatusOpen || !reflect.DeepEqual(updated.AssignedReviewers, []string{"u2", "u3"}) {
+			t.Fatalf("A failed precondition must change nothing, got %+v", updated)
+		}
+		if err := repo.PullRequest.MergePR(ctx, "pr-1"); err != nil {
+			t.Fatalf("Unguarded MergePR: %v", err)
+		}
+	})
+
+	t.Run("Sync runs", func(t *testing.T) {
+		repo := newRepo(t)
+
+		if runs, err := repo.SyncRun.ListRuns(ctx, 10); err != nil || runs == nil || len(runs) != 0 {
+			t.Fatalf("Expected an empty list, got %#v %v", runs, err)
+		}
+
+		startedAt := time.Now().UTC().Truncate(time.Millisecond)
+		failed := &domain.SyncRun{Source: "org.csv", Trigger: "schedule", StartedAt: startedAt, FinishedAt: startedAt.Add(time.Second),
+			Status: domain.SyncStatusFailed, Error: "failed to fetch directory"}
+		applied := &domain.SyncRun{Source: "org.csv", Trigger: "api", StartedAt: startedAt, FinishedAt: startedAt.Add(time.Second),
+			Status: domain.SyncStatusApplied, Report: &domain.ImportReport{TeamsCreated: []string{"mobile"}, UsersDeactivated: []string{"u4"}, Unchanged: 3}}
+		for _, run := range []*domain.SyncRun{failed, applied} {
+			if err := repo.SyncRun.CreateRun(ctx, run); err != nil {
+				t.Fatalf("CreateRun: %v", err)
+			}
+		}
+		if applied.ID <= failed.ID {
+			t.Fatalf("Expected increasing IDs, got %d and %d", failed.ID, applied.ID)
+		}
+
+		runs, err := repo.SyncRun.ListRuns(ctx, 1)
+		if err != nil || len(runs) != 1 {
+			t.Fatalf("Expected one run, got %+v %v", runs, err)
+		}
+		if got := runs[0]; got.ID != applied.ID || got.Trigger != "api" || !got.StartedAt.Equal(startedAt) || !got.FinishedAt.Equal(applied.FinishedAt) ||
+			!reflect.DeepEqual(got.Report, applied.Report) {
+			t.Fatalf("Unexpected run %+v, want %+v", got, applied)
+		}
+		if runs, _ := repo.SyncRun.ListRuns(ctx, 10); len(runs) != 2 || runs[1].Report != nil || runs[1].Error != failed.Error {
+			t.Fatalf("Unexpected runs %+v", runs)
+		}
+
+		acquired, err := repo.SyncRun.WithLock(ctx, func() error {
+			nested, err := repo.SyncRun.WithLock(ctx, func() error {
+				t.Error("The lock must not be taken twice")
+				return nil
+			})
+			if nested || err != nil {
+				t.Errorf("Expected the held lock to be refused, got %v %v", nested, err)
+			}
+			return nil
+		})
+		if !acquired || err != nil {
+			t.Fatalf("Expected the lock to be taken, got %v %v", acquired, err)
+		}
+		if acquired, _ := repo.SyncRun.WithLock(ctx, func() error { return nil }); !acquired {
+			t.Fatal("Expected the lock to be released")
+		}
+	})
+
+	t.Run("Listing", func(t *testing.T) {
+		repo := newRepo(t)
+		seedRepository(t, repo)
+		for _, pr := range []struct{ id, author, reviewer string }{
+			{"pr-a", "u1", "u2"},
+			{"pr-b", "u2", "u1"},
+			{"pr-c", "f1", ""},
+			{"pr-d", "u1", "u3"},
+		} {
+			if pr.reviewer == "" {
+				createTestPR(t, repo, pr.id, pr.author)
+			} else {
+				createTestPR(t, repo, pr.id, pr.author, pr.reviewer)
+			}
+			time.Sleep(2 * time.Millisecond)
+		}
+		repo.PullRequest.MergePR(ctx, "pr-b")
+
+		list := func(filter domain.PRFilter, after *domain.PRCursor, limit int) ([]string, *domain.PRCursor) {
+			t.Helper()
+			prs, next, err := repo.PullRequest.ListPRs(ctx, filter, after, limit)
+			if err != nil {
+				t.Fatalf("ListPRs: %v", err)
+			}
+			ids := []string{}
+			for _, pr := range prs {
+				ids = append(ids, pr.PullRequestID)
+			}
+			return ids, next
+		}
+
+		page, next := list(domain.PRFilter{}, nil, 3)
+		if !reflect.DeepEqual(page, []string{"pr-d", "pr-c", "pr-b"}) || next == nil {
+			t.Fatalf("Unexpected first page %v %v", page, next)
+		}
+		page, next = list(domain.PRFilter{}, next, 3)
+		if !reflect.DeepEqual(page, []string{"pr-a"}) || next != nil {
+			t.Fatalf("Unexpected last page %v %v", page, next)
+		}
+
+		filters := map[string]struct {
+			filter   domain.PRFilter
+			expected []string
+		}{
+			"status":   {domain.PRFilter{Status: domain.PRStatusMerged}, []string{"pr-b"}},
+			"author":   {domain.PRFilter{AuthorID: "u1"}, []string{"pr-d", "pr-a"}},
+			"reviewer": {domain.PRFilter{ReviewerID: "u1"}, []string{"pr-b"}},
+			"team":     {domain.PRFilter{TeamName: "frontend"}, []string{"pr-c"}},
+			"name":     {domain.PRFilter{NameContains: "CHANGE PR-A"}, []string{"pr-a"}},
+		}
+		for name, tc := range filters {
+			if got, _ := list(tc.filter, nil, 10); !reflect.DeepEqual(got, tc.expected) {
+				t.Fatalf("Filter %s: expected %v, got %v", name, tc.expected, got)
+			}
+		}
+
+		var streamed []string
+		err := repo.PullRequest.StreamPRs(ctx, domain.PRFilter{AuthorID: "u1"}, func(pr domain.PullRequestDetails) error {
+			streamed = append(streamed, pr.PullRequestID+"/"+pr.AuthorTeam+"/"+pr.AssignedReviewers[0])
+			return nil
+		})
+		if err != nil || !reflect.DeepEqual(streamed, []string{"pr-d/backend/u3", "pr-a/backend/u2"}) {
+			t.Fatalf("Unexpected stream %v %v", streamed, err)
+		}
+
+		var events []string
+		err = repo.PullRequest.StreamAssignmentHistory(ctx, domain.PRFilter{TeamName: "backend"}, func(event domain.AssignmentEvent) error {
+			events = append(events, event.PullRequestID)
+			return nil
+		})
+		if err != nil || !reflect.DeepEqual(events, []string{"pr-a", "pr-b", "pr-d"}) {
+			t.Fatalf("Unexpected history stream %v %v", events, err)
+		}
+
+		stop := errors.New("stop")
+		calls := 0
+		err = repo.PullRequest.StreamPRs(ctx, domain.PRFilter{}, func(domain.PullRequestDetails) error {
+			calls++
+			return stop
+		})
+		if !errors.Is(err, stop) || calls != 1 {
+			t.Fatalf("Expected streaming to stop at the first error, got %v after %d calls", err, calls)
+		}
+
+		// A consumer that is still writing the export out must not hold up
+		// other requests.
+		readDuring := func() error {
+			readCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+			defer cancel()
+			_, err := repo.User.GetUser(readCtx, "u1")
+			return err
+		}
+		err = repo.PullRequest.StreamPRs(ctx, domain.PRFilter{}, func(domain.PullRequestDetails) error {
+			return readDuring()
+		})
+		if err != nil {
+			t.Fatalf("Expected reads to proceed during a PR export, got %v", err)
+		}
+		err = repo.PullRequest.StreamAssignmentHistory(ctx, domain.PRFilter{}, func(domain.AssignmentEvent) error {
+			return readDuring()
+		})
+		if err != nil {
+			t.Fatalf("Expected reads to proceed during a history export, got %v", err)
+		}
+	})
+
+	t.Run("Statistics", func(t *testing.T) {
+		repo := newRepo(t)
+		seedRepository(t, repo)
+		createTestPR(t, repo, "pr-1", "u1", "u2", "u3")
+		createTestPR(t, repo, "pr-2", "u1")
+		createTestPR(t, repo, "pr-3", "f1")
+		repo.PullRequest.DeclineReviewer(ctx, "pr-1", "u3", "u4", "busy")
+		repo.PullRequest.MergePR(ctx, "pr-1")
+		repo.PullRequest.MergePR(ctx, "pr-3")
+
+		users, err := repo.Statistics.GetUserStatistics(ctx, domain.StatisticsFilter{TeamName: "backend"})
+		if err != nil {
+			t.Fatalf("GetUserStatistics: %v", err)
+		}
+		byUser := make(map[string]domain.ReviewStatistics)
+		var order []string
+		for _, user := range users {
+			byUser[user.UserID] = user.ReviewStatistics
+			order = append(order, user.UserID)
+		}
+		if !reflect.DeepEqual(order, []string{"u1", "u2", "u3", "u4"}) {
+			t.Fatalf("Expected backend users ordered by ID, got %v", order)
+		}
+		if u1 := byUser["u1"]; u1.OpenPRs != 1 || u1.MergedPRs != 1 || u1.Assignments != 0 {
+			t.Fatalf("Unexpected u1 statistics %+v", u1)
+		}
+		if u2 := byUser["u2"]; u2.Assignments != 1 || u2.AvgTimeToMergeSeconds == nil {
+			t.Fatalf("Unexpected u2 statistics %+v", u2)
+		}
+		if u3 := byUser["u3"]; u3.Declines != 1 || u3.ReassignmentsAway != 0 || u3.Assignments != 0 {
+			t.Fatalf("Unexpected u3 statistics %+v", u3)
+		}
+
+		teams, err := repo.Statistics.GetTeamStatistics(ctx, domain.StatisticsFilter{})
+		if err != nil {
+			t.Fatalf("GetTeamStatistics: %v", err)
+		}
+		if len(teams) != 2 || teams[0].TeamName != "backend" || teams[1].MergedWithoutReviewers != 1 || teams[0].Assignments != 2 {
+			t.Fatalf("Unexpected team statistics %+v", teams)
+		}
+
+		future := time.Now().Add(time.Hour)
+		windowed, _ := repo.Statistics.GetTeamStatistics(ctx, domain.StatisticsFilter{From: &future})
+		if len(windowed) != 2 || windowed[0].MergedPRs != 0 || windowed[0].Assignments != 0 {
+			t.Fatalf("Expected nothing inside a future window, got %+v", windowed)
+		}
+
+		loads, err := repo.Statistics.GetMemberLoad(ctx, "backend", time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+		if err != nil {
+			t.Fatalf("GetMemberLoad: %v", err)
+		}
+		if len(loads) != 4 || loads[1].UserID != "u2" || loads[1].Assignments != 1 || loads[1].ActiveSeconds <= 0 {
+			t.Fatalf("Unexpected member load %+v", loads)
+		}
+		if loads[3].IsActive || loads[3].ActiveSeconds != 0 || loads[3].Assignments != 1 {
+			t.Fatalf("Inactive u4 must have no active time, got %+v", loads[3])
+		}
+	})
+
+	t.Run("Reassignments away exclude declines", func(t *testing.T) {
+		repo := newRepo(t)
+		seedRepository(t, repo)
+		createTestPR(t, repo, "pr-1", "u1", "u2", "u3")
+		if err := repo.PullRequest.DeclineReviewer(ctx, "pr-1", "u3", "u4", "busy"); err != nil {
+			t.Fatalf("DeclineReviewer: %v", err)
+		}
+		if err := repo.PullRequest.ReplaceReviewer(ctx, "pr-1", "u2", "u3", "rebalance"); err != nil {
+			t.Fatalf("ReplaceReviewer: %v", err)
+		}
+
+		users, err := repo.Statistics.GetUserStatistics(ctx, domain.StatisticsFilter{TeamName: "backend"})
+		if err != nil {
+			t.Fatalf("GetUserStatistics: %v", err)
+		}
+		byUser := make(map[string]domain.ReviewStatistics)
+		for _, user := range users {
+			byUser[user.UserID] = user.ReviewStatistics
+		}
+		if u3 := byUser["u3"]; u3.Declines != 1 || u3.ReassignmentsAway != 0 {
+			t.Fatalf("Expected the decline to be counted once, got %+v", u3)
+		}
+		if u2 := byUser["u2"]; u2.ReassignmentsAway != 1 {
+			t.Fatalf("Expected a reassignment away for u2, got %+v", u2)
+		}
+	})
+}
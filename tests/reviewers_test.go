@@ -0,0 +1,370 @@
+package tests
+
+import (
+	"encoding/json"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/avito-test/pr-reviewer-service/internal/service"
+	"net/http"
+	"testing"
+)
+
+func TestIntegrationManualReviewers(t *testing.T) {
+	router := newTestRouter(t)
+
+	createTeam(t, router, domain.Team{
+		TeamName: "frontend",
+		Members: []domain.TeamMember{
+			{UserID: "f1", Username: "Fiona", IsActive: true},
+			{UserID: "f2", Username: "Fred", IsActive: true},
+			{UserID: "f3", Username: "Frank", IsActive: true},
+			{UserID: "f4", Username: "Faye", IsActive: false},
+		},
+	})
+	createTeam(t, router, domain.Team{
+		TeamName: "mobile",
+		Members: []domain.TeamMember{
+			{UserID: "m1", Username: "Mia", IsActive: true},
+		},
+	})
+
+	t.Run("Requested reviewers are honored first", func(t *testing.T) {
+		w := doJSON(t, router, http.MethodPost, "/pullRequest/create", map[string]interface{}{
+			"pull_request_id":     "pr-requested",
+			"pull_request_name":   "Requested",
+			"author_id":           "f1",
+			"requested_reviewers": []string{"f3"},
+		})
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+		}
+
+		pr := decodePR(t, w)
+		if len(pr.AssignedReviewers) != 2 {
+			t.Fatalf("Expected 2 reviewers, got %v", pr.AssignedReviewers)
+		}
+		if !containsString(pr.AssignedReviewers, "f3") || !containsString(pr.AssignedReviewers, "f2") {
+			t.Fatalf("Expected f3 requested and f2 auto-selected, got %v", pr.AssignedReviewers)
+		}
+	})
+
+	t.Run("Invalid requested reviewers are rejected", func(t *testing.T) {
+		cases := []struct {
+			name      string
+			requested []string
+			status    int
+			code      domain.ErrorCode
+		}{
+			{"self", []string{"f1"}, http.StatusBadRequest, domain.ErrCodeInvalidReviewer},
+			{"other team", []string{"m1"}, http.StatusBadRequest, domain.ErrCodeInvalidReviewer},
+			{"inactive", []string{"f4"}, http.StatusBadRequest, domain.ErrCodeInvalidReviewer},
+			{"duplicate", []string{"f2", "f2"}, http.StatusBadRequest, domain.ErrCodeInvalidReviewer},
+			{"unknown", []string{"nobody"}, http.StatusNotFound, domain.ErrCodeNotFound},
+			{"too many", []string{"f2", "f3", "f4"}, http.StatusConflict, domain.ErrCodeTooManyReviewers},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				w := doJSON(t, router, http.MethodPost, "/pullRequest/create", map[string]interface{}{
+					"pull_request_id":     "pr-invalid-" + tc.name,
+					"pull_request_name":   "Invalid",
+					"author_id":           "f1",
+					"requested_reviewers": tc.requested,
+				})
+				if w.Code != tc.status {
+					t.Fatalf("Expected status %d, got %d. Body: %s", tc.status, w.Code, w.Body.String())
+				}
+				if code := decodeErrorCode(t, w); code != string(tc.code) {
+					t.Fatalf("Expected code %s, got %s", tc.code, code)
+				}
+			})
+		}
+	})
+
+	t.Run("Remove and add reviewer", func(t *testing.T) {
+		w := doJSON(t, router, http.MethodPost, "/pullRequest/removeReviewer", map[string]string{
+			"pull_request_id": "pr-requested",
+			"user_id":         "f2",
+		})
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+		if pr := decodePR(t, w); containsString(pr.AssignedReviewers, "f2") || len(pr.AssignedReviewers) != 1 {
+			t.Fatalf("Expected only f3 to remain, got %v", pr.AssignedReviewers)
+		}
+
+		w = doJSON(t, router, http.MethodPost, "/pullRequest/removeReviewer", map[string]string{
+			"pull_request_id": "pr-requested",
+			"user_id":         "f2",
+		})
+		if w.Code != http.StatusConflict {
+			t.Fatalf("Expected status 409 for unassigned reviewer, got %d", w.Code)
+		}
+
+		w = doJSON(t, router, http.MethodPost, "/pullRequest/addReviewer", map[string]string{
+			"pull_request_id": "pr-requested",
+			"user_id":         "f2",
+		})
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+		if pr := decodePR(t, w); !containsString(pr.AssignedReviewers, "f2") {
+			t.Fatalf("Expected f2 to be assigned, got %v", pr.AssignedReviewers)
+		}
+
+		w = doJSON(t, router, http.MethodPost, "/pullRequest/addReviewer", map[string]string{
+			"pull_request_id": "pr-requested",
+			"user_id":         "f2",
+		})
+		if w.Code != http.StatusOK {
+			t.Fatalf("Adding an assigned reviewer should be idempotent, got %d", w.Code)
+		}
+	})
+
+	t.Run("Add reviewer respects the limit and merged state", func(t *testing.T) {
+		w := doJSON(t, router, http.MethodPost, "/pullRequest/addReviewer", map[string]string{
+			"pull_request_id": "pr-requested",
+			"user_id":         "f1",
+		})
+		if w.Code != http.StatusConflict {
+			t.Fatalf("Expected status 409 for full PR, got %d", w.Code)
+		}
+
+		doJSON(t, router, http.MethodPost, "/pullRequest/merge", map[string]string{"pull_request_id": "pr-requested"})
+
+		w = doJSON(t, router, http.MethodPost, "/pullRequest/removeReviewer", map[string]string{
+			"pull_request_id": "pr-requested",
+			"user_id":         "f2",
+		})
+		if w.Code != http.StatusConflict {
+			t.Fatalf("Expected status 409 for merged PR, got %d", w.Code)
+		}
+		if code := decodeErrorCode(t, w); code != string(domain.ErrCodePRMerged) {
+			t.Fatalf("Expected PR_MERGED, got %s", code)
+		}
+	})
+}
+
+func TestIntegrationReassignOptions(t *testing.T) {
+	router := newTestRouter(t)
+
+	createTeam(t, router, domain.Team{
+		TeamName: "platform",
+		Members: []domain.TeamMember{
+			{UserID: "p1", Username: "Paul", IsActive: true},
+			{UserID: "p2", Username: "Pam", IsActive: true},
+			{UserID: "p3", Username: "Pete", IsActive: true},
+			{UserID: "p4", Username: "Pia", IsActive: true},
+			{UserID: "p5", Username: "Pat", IsActive: false},
+		},
+	})
+
+	w := doJSON(t, router, http.MethodPost, "/pullRequest/create", map[string]interface{}{
+		"pull_request_id":     "pr-options",
+		"pull_request_name":   "Options",
+		"author_id":           "p1",
+		"requested_reviewers": []string{"p2", "p3"},
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	t.Run("Invalid explicit replacement", func(t *testing.T) {
+		cases := []struct {
+			name    string
+			newUser string
+			exclude []string
+		}{
+			{"author", "p1", nil},
+			{"already assigned", "p3", nil},
+			{"inactive", "p5", nil},
+			{"excluded", "p4", []string{"p4"}},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				w := doJSON(t, router, http.MethodPost, "/pullRequest/reassign", map[string]interface{}{
+					"pull_request_id":  "pr-options",
+					"old_user_id":      "p2",
+					"new_user_id":      tc.newUser,
+					"exclude_user_ids": tc.exclude,
+				})
+				if w.Code != http.StatusBadRequest {
+					t.Fatalf("Expected status 400, got %d. Body: %s", w.Code, w.Body.String())
+				}
+			})
+		}
+	})
+
+	t.Run("Exclusions leave no candidate", func(t *testing.T) {
+		w := doJSON(t, router, http.MethodPost, "/pullRequest/reassign", map[string]interface{}{
+			"pull_request_id":  "pr-options",
+			"old_user_id":      "p2",
+			"exclude_user_ids": []string{"p4"},
+		})
+		if w.Code != http.StatusConflict {
+			t.Fatalf("Expected status 409, got %d. Body: %s", w.Code, w.Body.String())
+		}
+		if code := decodeErrorCode(t, w); code != string(domain.ErrCodeNoCandidate) {
+			t.Fatalf("Expected NO_CANDIDATE, got %s", code)
+		}
+	})
+
+	t.Run("Explicit replacement with reason is recorded", func(t *testing.T) {
+		w := doJSON(t, router, http.MethodPost, "/pullRequest/reassign", map[string]interface{}{
+			"pull_request_id": "pr-options",
+			"old_user_id":     "p2",
+			"new_user_id":     "p4",
+			"reason":          "on vacation",
+		})
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+
+		w = doJSON(t, router, http.MethodGet, "/pullRequest/history?pull_request_id=pr-options", nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+
+		var response struct {
+			History []domain.AssignmentEvent `json:"history"`
+		}
+		json.NewDecoder(w.Body).Decode(&response)
+
+		if len(response.History) != 3 {
+			t.Fatalf("Expected 2 assignments and 1 reassignment, got %+v", response.History)
+		}
+
+		last := response.History[2]
+		if last.Action != domain.AssignmentReassigned || last.UserID != "p4" || last.PreviousUserID != "p2" || last.Reason != "on vacation" {
+			t.Fatalf("Unexpected history entry %+v", last)
+		}
+	})
+}
+
+func TestIntegrationDeclineReview(t *testing.T) {
+	router := newTestRouter(t)
+
+	createTeam(t, router, domain.Team{
+		TeamName: "mobile",
+		Members: []domain.TeamMember{
+			{UserID: "m1", Username: "Mia", IsActive: true},
+			{UserID: "m2", Username: "Max", IsActive: true},
+			{UserID: "m3", Username: "Mel", IsActive: true},
+			{UserID: "m4", Username: "Moe", IsActive: true},
+		},
+	})
+
+	w := doJSON(t, router, http.MethodPost, "/pullRequest/create", map[string]interface{}{
+		"pull_request_id":     "pr-decline",
+		"pull_request_name":   "Decline",
+		"author_id":           "m1",
+		"requested_reviewers": []string{"m2", "m3"},
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	t.Run("Reason is required", func(t *testing.T) {
+		w := doJSON(t, router, http.MethodPost, "/pullRequest/decline", map[string]string{
+			"pull_request_id": "pr-decline",
+			"user_id":         "m2",
+		})
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("Expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("Decline picks a replacement", func(t *testing.T) {
+		w := doJSON(t, router, http.MethodPost, "/pullRequest/decline", map[string]string{
+			"pull_request_id": "pr-decline",
+			"user_id":         "m2",
+			"reason":          "conflict of interest",
+		})
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+
+		var response struct {
+			PR         domain.PullRequest `json:"pr"`
+			ReplacedBy string             `json:"replaced_by"`
+		}
+		json.NewDecoder(w.Body).Decode(&response)
+
+		if response.ReplacedBy != "m4" {
+			t.Fatalf("Expected m4 as the only candidate, got %q", response.ReplacedBy)
+		}
+		if containsString(response.PR.AssignedReviewers, "m2") {
+			t.Fatal("Declined reviewer is still assigned")
+		}
+	})
+
+	t.Run("Declined reviewer cannot be added back", func(t *testing.T) {
+		w := doJSON(t, router, http.MethodPost, "/pullRequest/removeReviewer", map[string]string{
+			"pull_request_id": "pr-decline",
+			"user_id":         "m3",
+		})
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+
+		w = doJSON(t, router, http.MethodPost, "/pullRequest/addReviewer", map[string]string{
+			"pull_request_id": "pr-decline",
+			"user_id":         "m2",
+		})
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("Expected status 400, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("Decline without candidates releases the reviewer", func(t *testing.T) {
+		w := doJSON(t, router, http.MethodPost, "/pullRequest/decline", map[string]string{
+			"pull_request_id": "pr-decline",
+			"user_id":         "m4",
+			"reason":          "no expertise",
+		})
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+
+		var response struct {
+			PR         domain.PullRequest `json:"pr"`
+			ReplacedBy string             `json:"replaced_by"`
+		}
+		json.NewDecoder(w.Body).Decode(&response)
+
+		if response.ReplacedBy != "m3" {
+			t.Fatalf("Expected m3 as the only candidate, got %q", response.ReplacedBy)
+		}
+
+		w = doJSON(t, router, http.MethodPost, "/pullRequest/decline", map[string]string{
+			"pull_request_id": "pr-decline",
+			"user_id":         "m3",
+			"reason":          "busy",
+		})
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+
+		response.ReplacedBy = "unset"
+		json.NewDecoder(w.Body).Decode(&response)
+		if response.ReplacedBy != "" || len(response.PR.AssignedReviewers) != 0 {
+			t.Fatalf("Expected no replacement and no reviewers, got %q %v", response.ReplacedBy, response.PR.AssignedReviewers)
+		}
+	})
+
+	t.Run("Declines are counted in statistics", func(t *testing.T) {
+		w := doJSON(t, router, http.MethodGet, "/statistics", nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+
+		var stats service.Statistics
+		json.NewDecoder(w.Body).Decode(&stats)
+
+		for _, userID := range []string{"m2", "m3", "m4"} {
+			if stats.DeclinesByUser[userID] != 1 {
+				t.Fatalf("Expected 1 decline for %s, got %v", userID, stats.DeclinesByUser)
+			}
+		}
+	})
+}
@@ -0,0 +1,173 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestIntegrationSkillTags(t *testing.T) {
+	router := newTestRouter(t)
+
+	createTeam(t, router, domain.Team{
+		TeamName: "backend",
+		Members: []domain.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+			{UserID: "u3", Username: "Carol", IsActive: true},
+			{UserID: "u4", Username: "Dan", IsActive: true},
+			{UserID: "u5", Username: "Eve", IsActive: true},
+		},
+	})
+
+	setSkills := func(t *testing.T, userID string, skills ...string) domain.User {
+		t.Helper()
+		w := doJSON(t, router, http.MethodPost, "/users/setSkills", map[string]interface{}{"user_id": userID, "skills": skills})
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+		var resp struct {
+			User domain.User `json:"user"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode user: %v", err)
+		}
+		return resp.User
+	}
+
+	createPR := func(t *testing.T, id string, tags []string, requested ...string) domain.PullRequest {
+		t.Helper()
+		w := doJSON(t, router, http.MethodPost, "/pullRequest/create", map[string]interface{}{
+			"pull_request_id": id, "pull_request_name": "Feature", "author_id": "u1",
+			"required_tags": tags, "requested_reviewers": requested,
+		})
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+		}
+		return decodePR(t, w)
+	}
+
+	if user := setSkills(t, "u2", " DB ", "db"); !reflect.DeepEqual(user.Skills, []string{"db"}) {
+		t.Fatalf("Expected normalized skills, got %v", user.Skills)
+	}
+	setSkills(t, "u3", "infra")
+
+	t.Run("Invalid tags are rejected", func(t *testing.T) {
+		w := doJSON(t, router, http.MethodPost, "/users/setSkills", map[string]interface{}{"user_id": "u2", "skills": []string{"two words"}})
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("Expected status 400, got %d", w.Code)
+		}
+		w = doJSON(t, router, http.MethodPost, "/pullRequest/create", map[string]interface{}{
+			"pull_request_id": "pr-bad", "pull_request_name": "Feature", "author_id": "u1", "required_tags": []string{""},
+		})
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("Expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("Each tag gets a matching reviewer", func(t *testing.T) {
+		for i := 0; i < 5; i++ {
+			pr := createPR(t, fmt.Sprintf("pr-match-%d", i), []string{"infra", "DB"})
+			want := map[string][]string{"u2": {"db"}, "u3": {"infra"}}
+			if !reflect.DeepEqual(pr.AssignedReviewers, []string{"u2", "u3"}) || !reflect.DeepEqual(pr.ReviewerTags, want) {
+				t.Fatalf("Expected u2 and u3 picked for their tags, got %v %v", pr.AssignedReviewers, pr.ReviewerTags)
+			}
+			if !reflect.DeepEqual(pr.RequiredTags, []string{"db", "infra"}) {
+				t.Fatalf("Unexpected required tags %v", pr.RequiredTags)
+			}
+		}
+	})
+
+	t.Run("Requested reviewers cover their own tags", func(t *testing.T) {
+		pr := createPR(t, "pr-requested", []string{"db", "infra"}, "u3")
+		want := map[string][]string{"u2": {"db"}, "u3": {"infra"}}
+		if !reflect.DeepEqual(pr.ReviewerTags, want) {
+			t.Fatalf("Unexpected reviewer tags %v", pr.ReviewerTags)
+		}
+	})
+
+	t.Run("Unmatched tags fall back to random selection", func(t *testing.T) {
+		pr := createPR(t, "pr-unmatched", []string{"rust"})
+		if len(pr.AssignedReviewers) != 2 || pr.ReviewerTags != nil || !reflect.DeepEqual(pr.RequiredTags, []string{"rust"}) {
+			t.Fatalf("Expected two random reviewers and no matches, got %+v", pr)
+		}
+	})
+
+	t.Run("Candidates covering more tags are preferred", func(t *testing.T) {
+		setSkills(t, "u4", "db", "infra")
+		for i := 0; i < 5; i++ {
+			pr := createPR(t, fmt.Sprintf("pr-broad-%d", i), []string{"db", "infra"})
+			if pr.AssignedReviewers[0] != "u4" && pr.AssignedReviewers[1] != "u4" {
+				t.Fatalf("Expected u4 to be assigned, got %v", pr.AssignedReviewers)
+			}
+			if want := map[string][]string{"u4": {"db", "infra"}}; !reflect.DeepEqual(pr.ReviewerTags, want) {
+				t.Fatalf("Unexpected reviewer tags %v", pr.ReviewerTags)
+			}
+		}
+		setSkills(t, "u4")
+	})
+
+	t.Run("Exclusion rules still apply", func(t *testing.T) {
+		w := doJSON(t, router, http.MethodPost, "/exclusions/add", map[string]string{"kind": "AUTHOR", "user_id": "u2", "other_user_id": "u1"})
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+		}
+
+		pr := createPR(t, "pr-excluded", []string{"db"})
+		for _, reviewerID := range pr.AssignedReviewers {
+			if reviewerID == "u2" {
+				t.Fatalf("Expected u2 to be skipped, got %v", pr.AssignedReviewers)
+			}
+		}
+		if pr.ReviewerTags != nil {
+			t.Fatalf("Expected db to stay uncovered, got %v", pr.ReviewerTags)
+		}
+	})
+
+	getTags := func(t *testing.T, prID string) map[string][]string {
+		t.Helper()
+		w := doJSON(t, router, http.MethodGet, "/pullRequest/get?pull_request_id="+prID, nil)
+		var resp struct {
+			PR domain.PullRequestDetails `json:"pr"`
+		}
+		json.NewDecoder(w.Body).Decode(&resp)
+		if len(resp.PR.RequiredTags) != 2 {
+			t.Fatalf("Unexpected required tags %v", resp.PR.RequiredTags)
+		}
+		return resp.PR.ReviewerTags
+	}
+
+	t.Run("Reassigned reviewers hand their tags over", func(t *testing.T) {
+		setSkills(t, "u4", "infra")
+		w := doJSON(t, router, http.MethodPost, "/pullRequest/reassign", map[string]string{"pull_request_id": "pr-requested", "old_user_id": "u3"})
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+		if want := map[string][]string{"u2": {"db"}, "u4": {"infra"}}; !reflect.DeepEqual(getTags(t, "pr-requested"), want) {
+			t.Fatalf("Expected u4 to take over infra, got %v", getTags(t, "pr-requested"))
+		}
+	})
+
+	t.Run("Declined reviewers hand their tags over", func(t *testing.T) {
+		w := doJSON(t, router, http.MethodPost, "/pullRequest/decline", map[string]string{"pull_request_id": "pr-requested", "user_id": "u4", "reason": "busy"})
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+		if want := map[string][]string{"u2": {"db"}, "u3": {"infra"}}; !reflect.DeepEqual(getTags(t, "pr-requested"), want) {
+			t.Fatalf("Expected u3 to take over infra, got %v", getTags(t, "pr-requested"))
+		}
+	})
+
+	t.Run("Tags the replacement lacks stay uncovered", func(t *testing.T) {
+		w := doJSON(t, router, http.MethodPost, "/pullRequest/decline", map[string]string{"pull_request_id": "pr-requested", "user_id": "u2", "reason": "busy"})
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+		if want := map[string][]string{"u3": {"infra"}}; !reflect.DeepEqual(getTags(t, "pr-requested"), want) {
+			t.Fatalf("Expected db to stay uncovered, got %v", getTags(t, "pr-requested"))
+		}
+	})
+}
@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"encoding/json"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/avito-test/pr-reviewer-service/internal/service"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestIntegrationRichStatistics(t *testing.T) {
+	router := newTestRouter(t)
+
+	createTeam(t, router, domain.Team{
+		TeamName: "payments",
+		Members: []domain.TeamMember{
+			{UserID: "pay1", Username: "Pia", IsActive: true},
+			{UserID: "pay2", Username: "Pol", IsActive: true},
+			{UserID: "pay3", Username: "Pru", IsActive: true},
+		},
+	})
+	createTeam(t, router, domain.Team{
+		TeamName: "solo",
+		Members: []domain.TeamMember{
+			{UserID: "solo1", Username: "Sol", IsActive: true},
+		},
+	})
+
+	prs := []struct{ id, author string }{
+		{"pr-pay-1", "pay1"},
+		{"pr-pay-2", "pay1"},
+		{"pr-solo-1", "solo1"},
+	}
+	for _, pr := range prs {
+		w := doJSON(t, router, http.MethodPost, "/pullRequest/create", map[string]string{
+			"pull_request_id":   pr.id,
+			"pull_request_name": pr.id,
+			"author_id":         pr.author,
+		})
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d", w.Code)
+		}
+	}
+
+	doJSON(t, router, http.MethodPost, "/pullRequest/merge", map[string]string{"pull_request_id": "pr-pay-1"})
+	doJSON(t, router, http.MethodPost, "/pullRequest/merge", map[string]string{"pull_request_id": "pr-solo-1"})
+
+	getStats := func(t *testing.T, path string) service.Statistics {
+		t.Helper()
+
+		w := doJSON(t, router, http.MethodGet, path, nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+
+		var stats service.Statistics
+		if err := json.NewDecoder(w.Body).Decode(&stats); err != nil {
+			t.Fatalf("Failed to decode statistics: %v", err)
+		}
+		return stats
+	}
+
+	t.Run("Per team", func(t *testing.T) {
+		stats := getStats(t, "/statistics")
+
+		teams := make(map[string]domain.TeamStatistics)
+		for _, team := range stats.Teams {
+			teams[team.TeamName] = team
+		}
+
+		payments := teams["payments"]
+		if payments.OpenPRs != 1 || payments.MergedPRs != 1 || payments.Assignments != 4 {
+			t.Fatalf("Unexpected payments statistics %+v", payments)
+		}
+		if payments.AvgTimeToMergeSeconds == nil || payments.P90TimeToMergeSeconds == nil {
+			t.Fatalf("Expected merge latency for payments, got %+v", payments)
+		}
+
+		solo := teams["solo"]
+		if solo.MergedWithoutReviewers != 1 || solo.AvgTimeToMergeSeconds != nil {
+			t.Fatalf("Unexpected solo statistics %+v", solo)
+		}
+	})
+
+	t.Run("Per user with team filter", func(t *testing.T) {
+		stats := getStats(t, "/statistics?team=payments")
+
+		if len(stats.Teams) != 1 || len(stats.Users) != 3 || stats.TotalPRs != 2 {
+			t.Fatalf("Expected only payments, got %+v", stats)
+		}
+		for _, user := range stats.Users {
+			if user.TeamName != "payments" {
+				t.Fatalf("Unexpected user %+v", user)
+			}
+			if user.UserID == "pay1" && (user.OpenPRs != 1 || user.MergedPRs != 1 || user.Assignments != 0) {
+				t.Fatalf("Unexpected author statistics %+v", user)
+			}
+		}
+	})
+
+	t.Run("Window", func(t *testing.T) {
+		future := time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)
+		stats := getStats(t, "/statistics?from="+future)
+
+		if stats.TotalPRs != 0 || len(stats.AssignmentsByUser) != 0 {
+			t.Fatalf("Expected nothing after %s, got %+v", future, stats)
+		}
+	})
+
+	t.Run("Invalid window", func(t *testing.T) {
+		w := doJSON(t, router, http.MethodGet, "/statistics?from=2025-02-01T00:00:00Z&to=2025-01-01T00:00:00Z", nil)
+
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("Expected status 400, got %d", w.Code)
+		}
+	})
+}
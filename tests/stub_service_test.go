@@ -0,0 +1,369 @@
+package tests
+
+import (
+	"context"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/avito-test/pr-reviewer-service/internal/handler"
+	"github.com/avito-test/pr-reviewer-service/internal/policy"
+	"github.com/avito-test/pr-reviewer-service/internal/service"
+	"net/http"
+	"time"
+)
+
+// stubService implements every service interface with canned data so that
+// handler responses can be exercised without a database. When err is set,
+// every method returns it.
+type stubService struct {
+	err error
+}
+
+func newStubRouter(stub *stubService) http.Handler {
+	svc := &service.Service{
+		Team:        stub,
+		User:        stub,
+		PullRequest: stub,
+		Statistics:  stub,
+		Exclusion:   stub,
+		Policies:    stub,
+	}
+	h := handler.NewHandler(svc)
+	h.Sync = handler.NewSyncHandler(stub)
+	return h.InitRoutes()
+}
+
+func stubPR(prID string, status domain.PRStatus) *domain.PullRequest {
+	createdAt := time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC)
+	pr := &domain.PullRequest{
+		PullRequestID:     prID,
+		PullRequestName:   "Stub PR",
+		AuthorID:          "u1",
+		Status:            status,
+		AssignedReviewers: []string{"u2", "u3"},
+		CreatedAt:         &createdAt,
+	}
+	if status == domain.PRStatusMerged {
+		mergedAt := createdAt.Add(time.Hour)
+		pr.MergedAt = &mergedAt
+	}
+	return pr
+}
+
+func stubPRDetails(prID string) *domain.PullRequestDetails {
+	pr := stubPR(prID, domain.PRStatusOpen)
+	details := &domain.PullRequestDetails{PullRequest: *pr, AuthorTeam: "backend"}
+	for _, userID := range pr.AssignedReviewers {
+		details.Reviewers = append(details.Reviewers, domain.ReviewerAssignment{UserID: userID, AssignedAt: *pr.CreatedAt})
+	}
+	return details
+}
+
+func (s *stubService) CreateTeam(ctx context.Context, team *domain.Team) (*domain.Team, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return team, nil
+}
+
+func (s *stubService) AddMember(ctx context.Context, teamName string, member domain.TeamMember) (*domain.Team, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	team, _ := s.GetTeam(ctx, teamName)
+	team.Members = append(team.Members, member)
+	return team, nil
+}
+
+func (s *stubService) ImportTeams(ctx context.Context, teams []domain.Team, dryRun bool) (*domain.ImportReport, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &domain.ImportReport{
+		DryRun:           dryRun,
+		TeamsCreated:     []string{"mobile"},
+		UsersCreated:     []domain.User{{UserID: "u4", Username: "Dana", TeamName: "mobile", IsActive: true}},
+		UsersMoved:       []domain.UserMove{{UserID: "u3", FromTeam: "backend", ToTeam: "mobile"}},
+		UsersUpdated:     []domain.User{},
+		UsersDeactivated: []string{"u2"},
+		Unchanged:        1,
+	}, nil
+}
+
+func (s *stubService) ImportTeamsChecked(ctx context.Context, teams []domain.Team, dryRun bool, check service.ImportCheck) (*domain.ImportReport, error) {
+	return s.ImportTeams(ctx, teams, dryRun)
+}
+
+func (s *stubService) Run(ctx context.Context, trigger string) domain.SyncRun {
+	report, _ := s.ImportTeams(ctx, nil, false)
+	startedAt := time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC)
+	return domain.SyncRun{
+		ID:         1,
+		Source:     "https://directory.example.com/teams.json",
+		Trigger:    trigger,
+		StartedAt:  startedAt,
+		FinishedAt: startedAt.Add(time.Second),
+		Status:     domain.SyncStatusApplied,
+		Report:     report,
+	}
+}
+
+func (s *stubService) Runs(ctx context.Context) ([]domain.SyncRun, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return []domain.SyncRun{s.Run(ctx, "schedule")}, nil
+}
+
+func (s *stubService) GetTeam(ctx context.Context, teamName string) (*domain.Team, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &domain.Team{
+		TeamName: teamName,
+		Members: []domain.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: false},
+		},
+	}, nil
+}
+
+func (s *stubService) GetUser(ctx context.Context, userID string) (*domain.User, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &domain.User{UserID: userID, Username: "Alice", TeamName: "backend", IsActive: true}, nil
+}
+
+func (s *stubService) SetIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &domain.User{UserID: userID, Username: "Alice", TeamName: "backend", IsActive: isActive}, nil
+}
+
+func (s *stubService) SetSkills(ctx context.Context, userID string, skills []string) (*domain.User, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &domain.User{UserID: userID, Username: "Alice", TeamName: "backend", IsActive: true, Skills: skills}, nil
+}
+
+func (s *stubService) GetReviewPRs(ctx context.Context, userID string, input service.ListPRsInput) (*domain.PRPage, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &domain.PRPage{
+		PullRequests: []domain.PullRequestShort{
+			{PullRequestID: "pr-1", PullRequestName: "Stub PR", AuthorID: "u1", Status: domain.PRStatusOpen},
+		},
+	}, nil
+}
+
+func (s *stubService) GetPRDetails(ctx context.Context, prID string) (*domain.PullRequestDetails, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return stubPRDetails(prID), nil
+}
+
+func (s *stubService) GetPRDetailsBatch(ctx context.Context, prIDs []string) ([]domain.PullRequestDetails, []string, error) {
+	if s.err != nil {
+		return nil, nil, s.err
+	}
+	if len(prIDs) == 0 {
+		return nil, nil, domain.ErrEmptyBatch
+	}
+	return []domain.PullRequestDetails{*stubPRDetails(prIDs[0])}, prIDs[1:], nil
+}
+
+func (s *stubService) ListPRs(ctx context.Context, input service.ListPRsInput) (*domain.PRPage, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	createdAt := time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC)
+	return &domain.PRPage{
+		PullRequests: []domain.PullRequestShort{
+			{PullRequestID: "pr-2", PullRequestName: "Stub PR", AuthorID: "u1", Status: domain.PRStatusMerged},
+			{PullRequestID: "pr-1", PullRequestName: "Stub PR", AuthorID: "u1", Status: domain.PRStatusOpen},
+		},
+		NextCursor: domain.PRCursor{CreatedAt: createdAt, PullRequestID: "pr-1"}.Encode(),
+	}, nil
+}
+
+func (s *stubService) CreatePR(ctx context.Context, input service.CreatePRInput) (*domain.PullRequest, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	pr := stubPR(input.PullRequestID, domain.PRStatusOpen)
+	if len(input.RequiredTags) > 0 {
+		pr.RequiredTags = input.RequiredTags
+		pr.ReviewerTags = map[string][]string{pr.AssignedReviewers[0]: input.RequiredTags[:1]}
+	}
+	return pr, nil
+}
+
+func (s *stubService) GetPR(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return stubPR(prID, domain.PRStatusOpen), nil
+}
+
+func (s *stubService) MergePR(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return stubPR(prID, domain.PRStatusMerged), nil
+}
+
+func (s *stubService) ReassignReviewer(ctx context.Context, input service.ReassignInput) (*domain.PullRequest, string, error) {
+	if s.err != nil {
+		return nil, "", s.err
+	}
+	replacedBy := input.NewUserID
+	if replacedBy == "" {
+		replacedBy = "u4"
+	}
+	return stubPR(input.PullRequestID, domain.PRStatusOpen), replacedBy, nil
+}
+
+func (s *stubService) DeclineReview(ctx context.Context, prID, userID, reason string) (*domain.PullRequest, string, error) {
+	if s.err != nil {
+		return nil, "", s.err
+	}
+	return stubPR(prID, domain.PRStatusOpen), "u4", nil
+}
+
+func (s *stubService) AddReviewer(ctx context.Context, prID, userID string) (*domain.PullRequest, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	pr := stubPR(prID, domain.PRStatusOpen)
+	pr.AssignedReviewers = []string{"u2", userID}
+	return pr, nil
+}
+
+func (s *stubService) RemoveReviewer(ctx context.Context, prID, userID string) (*domain.PullRequest, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	pr := stubPR(prID, domain.PRStatusOpen)
+	pr.AssignedReviewers = []string{"u3"}
+	return pr, nil
+}
+
+func (s *stubService) GetAssignmentHistory(ctx context.Context, prID string) ([]domain.AssignmentEvent, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	at := time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC)
+	return []domain.AssignmentEvent{
+		{PullRequestID: prID, Action: domain.AssignmentAssigned, UserID: "u2", CreatedAt: &at},
+		{PullRequestID: prID, Action: domain.AssignmentReassigned, UserID: "u4", PreviousUserID: "u2", Reason: "on vacation", CreatedAt: &at},
+	}, nil
+}
+
+func (s *stubService) ExportPRs(ctx context.Context, filter domain.PRFilter, fn func(domain.PullRequestDetails) error) error {
+	if s.err != nil {
+		return s.err
+	}
+	for _, prID := range []string{"pr-1", "pr-2"} {
+		if err := fn(*stubPRDetails(prID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *stubService) ExportAssignmentHistory(ctx context.Context, filter domain.PRFilter, fn func(domain.AssignmentEvent) error) error {
+	events, err := s.GetAssignmentHistory(ctx, "pr-1")
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *stubService) GetStatistics(ctx context.Context, filter domain.StatisticsFilter) (*service.Statistics, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	avg, p90 := 5400.0, 7200.0
+	return &service.Statistics{
+		TotalPRs:          3,
+		AssignmentsByUser: map[string]int{"u2": 2, "u3": 1},
+		DeclinesByUser:    map[string]int{"u5": 1},
+		Users: []domain.UserStatistics{
+			{UserID: "u2", TeamName: "backend", ReviewStatistics: domain.ReviewStatistics{Assignments: 2, AvgTimeToMergeSeconds: &avg, P90TimeToMergeSeconds: &p90}},
+			{UserID: "u5", TeamName: "backend", ReviewStatistics: domain.ReviewStatistics{OpenPRs: 2, MergedPRs: 1, Declines: 1, ReassignmentsAway: 1}},
+		},
+		Teams: []domain.TeamStatistics{
+			{TeamName: "backend", ReviewStatistics: domain.ReviewStatistics{OpenPRs: 2, MergedPRs: 1, Assignments: 3, AvgTimeToMergeSeconds: &avg, P90TimeToMergeSeconds: &p90}},
+		},
+	}, nil
+}
+
+func (s *stubService) GetFairnessReport(ctx context.Context, filter domain.FairnessFilter) (*domain.FairnessReport, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	from := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	ratio := 2.0
+	return &domain.FairnessReport{
+		TeamName:         filter.TeamName,
+		From:             from,
+		To:               from.AddDate(0, 1, 0),
+		Threshold:        domain.DefaultFairnessThreshold,
+		TotalAssignments: 3,
+		Gini:             0.17,
+		MaxMinRatio:      &ratio,
+		Members: []domain.FairnessMember{
+			{UserID: "u2", IsActive: true, ActiveFraction: 1, Assignments: 2, ActualShare: 0.67, ExpectedShare: 0.5, Deviation: 0.33, Flagged: true},
+			{UserID: "u3", IsActive: true, ActiveFraction: 1, Assignments: 1, ActualShare: 0.33, ExpectedShare: 0.5, Deviation: -0.33, Flagged: true},
+		},
+	}, nil
+}
+
+func stubRule() *domain.ExclusionRule {
+	return &domain.ExclusionRule{
+		RuleID:      1,
+		Kind:        domain.ExclusionPair,
+		UserID:      "u1",
+		OtherUserID: "u2",
+		Reason:      "manager",
+		CreatedAt:   time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func (s *stubService) CreateRule(ctx context.Context, rule domain.ExclusionRule) (*domain.ExclusionRule, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return stubRule(), nil
+}
+
+func (s *stubService) ListRules(ctx context.Context, userID string) ([]domain.ExclusionRule, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return []domain.ExclusionRule{*stubRule()}, nil
+}
+
+func (s *stubService) DeleteRule(ctx context.Context, ruleID int64) error {
+	return s.err
+}
+
+func (s *stubService) Current() *policy.Snapshot {
+	backend := policy.Default()
+	backend.Strategy = policy.StrategyLeastLoaded
+	backend.FallbackTeams = []string{"platform"}
+	return &policy.Snapshot{
+		Version:  1,
+		LoadedAt: time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC),
+		Default:  policy.Default(),
+		Teams:    map[string]policy.Policy{"backend": backend},
+	}
+}
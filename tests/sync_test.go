@@ -0,0 +1,226 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/avito-test/pr-reviewer-service/internal/domain"
+	"github.com/avito-test/pr-reviewer-service/internal/handler"
+	"github.com/avito-test/pr-reviewer-service/internal/orgsync"
+	"github.com/avito-test/pr-reviewer-service/internal/service"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDirectorySources(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("File sources read CSV and JSON", func(t *testing.T) {
+		csvPath := writeConfig(t, "org.csv", "team_name,user_id,username\nbackend,u1,Alice\nbackend,u2,Bob\n")
+		jsonPath := writeConfig(t, "org.json", `{"teams": [{"team_name": "backend", "members": [{"user_id": "u1", "username": "Alice", "is_active": true}]}]}`)
+
+		for path, members := range map[string]int{csvPath: 2, jsonPath: 1} {
+			source, err := orgsync.NewSource(path, "", time.Second)
+			if err != nil {
+				t.Fatalf("NewSource(%s): %v", path, err)
+			}
+			teams, err := source.Fetch(ctx)
+			if err != nil || len(teams) != 1 || len(teams[0].Members) != members {
+				t.Fatalf("Unexpected teams from %s: %v %+v", path, err, teams)
+			}
+		}
+
+		if _, err := orgsync.NewSource("org.txt", "", time.Second); err == nil {
+			t.Fatal("Expected an unknown extension to be rejected")
+		}
+	})
+
+	t.Run("HTTP source sends the token and follows the content type", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", "text/csv")
+			w.Write([]byte("team_name,user_id,username,is_active\nmobile,m1,Max,false\n"))
+		}))
+		defer server.Close()
+
+		source, _ := orgsync.NewSource(server.URL, "secret", time.Second)
+		teams, err := source.Fetch(ctx)
+		if err != nil || len(teams) != 1 || teams[0].Members[0].IsActive {
+			t.Fatalf("Unexpected teams: %v %+v", err, teams)
+		}
+
+		source, _ = orgsync.NewSource(server.URL, "wrong", time.Second)
+		if _, err := source.Fetch(ctx); err == nil || !strings.Contains(err.Error(), "401") {
+			t.Fatalf("Expected the status to be reported, got %v", err)
+		}
+	})
+}
+
+func TestIntegrationDirectorySync(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+	svc := service.NewService(repo)
+	router := handler.NewHandler(svc).InitRoutes()
+
+	createTeam(t, router, domain.Team{
+		TeamName: "backend",
+		Members: []domain.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+			{UserID: "u3", Username: "Carol", IsActive: true},
+			{UserID: "u4", Username: "Dan", IsActive: true},
+			{UserID: "u5", Username: "Eve", IsActive: true},
+		},
+	})
+
+	path := writeConfig(t, "directory.csv", "")
+	writeDirectory := func(t *testing.T, rows ...string) {
+		t.Helper()
+		content := "team_name,user_id,username\n" + strings.Join(rows, "\n") + "\n"
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write directory: %v", err)
+		}
+	}
+	source := &orgsync.FileSource{Path: path}
+	syncer := orgsync.New(source, svc.Team, repo.SyncRun, orgsync.Options{MaxDeactivatePercent: 20})
+
+	isActive := func(t *testing.T, userID string) bool {
+		t.Helper()
+		user, err := svc.User.GetUser(ctx, userID)
+		if err != nil {
+			t.Fatalf("GetUser(%s): %v", userID, err)
+		}
+		return user.IsActive
+	}
+
+	t.Run("Matching directory changes nothing", func(t *testing.T) {
+		writeDirectory(t, "backend,u1,Alice", "backend,u2,Bob", "backend,u3,Carol", "backend,u4,Dan", "backend,u5,Eve")
+		if run := syncer.Run(ctx, "test"); run.Status != domain.SyncStatusUnchanged || run.Report.Unchanged != 5 {
+			t.Fatalf("Expected an unchanged run, got %+v", run)
+		}
+	})
+
+	t.Run("Mass deactivation is blocked", func(t *testing.T) {
+		writeDirectory(t, "backend,u1,Alice", "backend,u2,Bob", "backend,u3,Carol")
+		run := syncer.Run(ctx, "test")
+		if run.Status != domain.SyncStatusBlocked || !strings.Contains(run.Error, "deactivate 2 of 5") {
+			t.Fatalf("Expected a blocked run, got %+v", run)
+		}
+		if len(run.Report.UsersDeactivated) != 2 || !isActive(t, "u4") || !isActive(t, "u5") {
+			t.Fatalf("Expected the diff to be recorded but not applied, got %+v", run.Report)
+		}
+	})
+
+	t.Run("Dry run records without applying", func(t *testing.T) {
+		writeDirectory(t, "backend,u1,Alice", "backend,u2,Bob", "backend,u3,Carol", "backend,u4,Dan", "mobile,u5,Eve")
+		dryRun := orgsync.New(source, svc.Team, repo.SyncRun, orgsync.Options{MaxDeactivatePercent: 20, DryRun: true})
+		if run := dryRun.Run(ctx, "test"); run.Status != domain.SyncStatusDryRun || len(run.Report.UsersMoved) != 1 {
+			t.Fatalf("Expected a dry run with one move, got %+v", run)
+		}
+		if user, _ := svc.User.GetUser(ctx, "u5"); user.TeamName != "backend" {
+			t.Fatalf("Expected dry run to leave u5 in backend, got %s", user.TeamName)
+		}
+	})
+
+	t.Run("Changes within the limit are applied", func(t *testing.T) {
+		writeDirectory(t, "backend,u1,Alice", "backend,u2,Bob", "backend,u3,Carol", "mobile,u5,Eve", "mobile,m1,Max")
+		run := syncer.Run(ctx, "test")
+		if run.Status != domain.SyncStatusApplied || run.Error != "" {
+			t.Fatalf("Expected an applied run, got %+v", run)
+		}
+		if isActive(t, "u4") {
+			t.Fatal("Expected u4 to be deactivated")
+		}
+		if user, _ := svc.User.GetUser(ctx, "u5"); user.TeamName != "mobile" || !isActive(t, "m1") {
+			t.Fatalf("Expected u5 moved and m1 created, got %+v", user)
+		}
+	})
+
+	t.Run("Fetch failures are recorded", func(t *testing.T) {
+		missing := orgsync.New(&orgsync.FileSource{Path: filepath.Join(t.TempDir(), "gone.csv")}, svc.Team, repo.SyncRun, orgsync.Options{})
+		if run := missing.Run(ctx, "test"); run.Status != domain.SyncStatusFailed || run.Report != nil {
+			t.Fatalf("Expected a failed run, got %+v", run)
+		}
+	})
+
+	t.Run("Runs are listed newest first", func(t *testing.T) {
+		h := handler.NewHandler(svc)
+		h.Sync = handler.NewSyncHandler(syncer)
+		router := h.InitRoutes()
+
+		if w := doJSON(t, router, http.MethodPost, "/sync/run", nil); w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+		if w := doJSON(t, router, http.MethodGet, "/sync/run", nil); w.Code != http.StatusMethodNotAllowed || w.Header().Get("Allow") != http.MethodPost {
+			t.Fatalf("Expected GET to be refused with 405, got %d", w.Code)
+		}
+
+		w := doJSON(t, router, http.MethodGet, "/sync/runs", nil)
+		var resp struct {
+			Runs []domain.SyncRun `json:"runs"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode runs: %v", err)
+		}
+		if len(resp.Runs) != 6 || resp.Runs[0].ID != 6 || resp.Runs[0].Trigger != "api" || resp.Runs[0].Status != domain.SyncStatusUnchanged {
+			t.Fatalf("Unexpected runs %+v", resp.Runs)
+		}
+		if resp.Runs[1].Status != domain.SyncStatusFailed || resp.Runs[3].Report == nil || len(resp.Runs[3].Report.UsersMoved) != 1 {
+			t.Fatalf("Expected the runs of every syncer with their reports, got %+v", resp.Runs)
+		}
+	})
+
+	t.Run("Runs outlive the syncer", func(t *testing.T) {
+		restarted := orgsync.New(source, svc.Team, repo.SyncRun, orgsync.Options{})
+		runs, err := restarted.Runs(ctx)
+		if err != nil || len(runs) != 6 || runs[0].ID != 6 {
+			t.Fatalf("Expected the recorded runs after a restart, got %+v %v", runs, err)
+		}
+	})
+
+	t.Run("Runs are skipped while another replica syncs", func(t *testing.T) {
+		var run domain.SyncRun
+		acquired, err := repo.SyncRun.WithLock(ctx, func() error {
+			run = syncer.Run(ctx, "test")
+			return nil
+		})
+		if !acquired || err != nil || run.Status != domain.SyncStatusSkipped || run.Report != nil {
+			t.Fatalf("Expected a skipped run, got %+v %v", run, err)
+		}
+		if runs, _ := syncer.Runs(ctx); len(runs) != 6 {
+			t.Fatalf("Expected skipped runs not to be recorded, got %d runs", len(runs))
+		}
+	})
+
+	t.Run("Checks see the diff that is applied", func(t *testing.T) {
+		teams := []domain.Team{{TeamName: "backend", Members: []domain.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}}}}
+		stop := errors.New("stop")
+		_, err := svc.Team.ImportTeamsChecked(ctx, teams, false, func(plan *domain.ImportReport, current domain.ImportSnapshot) error {
+			if !reflect.DeepEqual(plan.UsersDeactivated, []string{"u2", "u3"}) || !current.Users["u2"].IsActive {
+				t.Errorf("Unexpected diff %+v", plan)
+			}
+			return stop
+		})
+		if !errors.Is(err, stop) || !isActive(t, "u2") {
+			t.Fatalf("Expected a rejected check to write nothing, got %v", err)
+		}
+
+		var checked *domain.ImportReport
+		report, err := svc.Team.ImportTeamsChecked(ctx, teams, false, func(plan *domain.ImportReport, current domain.ImportSnapshot) error {
+			checked = plan
+			return nil
+		})
+		if err != nil || report != checked || isActive(t, "u2") || isActive(t, "u3") {
+			t.Fatalf("Expected the checked diff to be applied, got %+v %v", report, err)
+		}
+	})
+}
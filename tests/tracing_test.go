@@ -0,0 +1,129 @@
+package tests
+
+import (
+	"context"
+	"github.com/avito-test/pr-reviewer-service/internal/handler"
+	"github.com/avito-test/pr-reviewer-service/internal/repository"
+	"github.com/avito-test/pr-reviewer-service/internal/service"
+	"github.com/avito-test/pr-reviewer-service/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// useInMemoryTracer routes spans from the global provider into an in-memory
+// exporter for the duration of the test.
+func useInMemoryTracer(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		provider.Shutdown(context.Background())
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+
+	return exporter
+}
+
+func findSpan(t *testing.T, spans tracetest.SpanStubs, name string) tracetest.SpanStub {
+	t.Helper()
+
+	for _, span := range spans {
+		if span.Name == name {
+			return span
+		}
+	}
+
+	var names []string
+	for _, span := range spans {
+		names = append(names, span.Name)
+	}
+	t.Fatalf("No span %q among %v", name, names)
+	return tracetest.SpanStub{}
+}
+
+func spanAttribute(span tracetest.SpanStub, key string) (attribute.Value, bool) {
+	for _, attr := range span.Attributes {
+		if string(attr.Key) == key {
+			return attr.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestTracingPropagatesTraceparent(t *testing.T) {
+	exporter := useInMemoryTracer(t)
+
+	stub := &stubService{}
+	svc := service.WithTracing(&service.Service{Team: stub, User: stub, PullRequest: stub, Statistics: stub})
+	router := tracing.Middleware(handler.NewHandler(svc).InitRoutes())
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	const parentID = "00f067aa0ba902b7"
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", strings.NewReader(`{"pull_request_id":"pr-7","pull_request_name":"Trace me","author_id":"u1"}`))
+	req.Header.Set("traceparent", "00-"+traceID+"-"+parentID+"-01")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := exporter.GetSpans()
+	server := findSpan(t, spans, "POST /pullRequest/create")
+	if server.SpanContext.TraceID().String() != traceID || server.Parent.SpanID().String() != parentID {
+		t.Fatalf("Server span did not continue the incoming trace: %s parent %s", server.SpanContext.TraceID(), server.Parent.SpanID())
+	}
+	if status, _ := spanAttribute(server, "http.response.status_code"); status.AsInt64() != http.StatusCreated {
+		t.Fatalf("Expected status_code 201, got %v", status.AsInt64())
+	}
+
+	create := findSpan(t, spans, "PullRequestService.CreatePR")
+	if create.Parent.SpanID() != server.SpanContext.SpanID() {
+		t.Fatal("Service span is not a child of the server span")
+	}
+	if prID, _ := spanAttribute(create, "pr.id"); prID.AsString() != "pr-7" {
+		t.Fatalf("Expected pr.id pr-7, got %q", prID.AsString())
+	}
+	if author, _ := spanAttribute(create, "user.id"); author.AsString() != "u1" {
+		t.Fatalf("Expected user.id u1, got %q", author.AsString())
+	}
+}
+
+func TestTracingRepositorySpans(t *testing.T) {
+	exporter := useInMemoryTracer(t)
+
+	fake := &fairnessRepo{}
+	repo := repository.WithTracing(&repository.Repository{Team: fake, Statistics: fake}, "memory")
+
+	ctx := context.Background()
+	repo.Statistics.GetMemberLoad(ctx, "backend", time.Now().Add(-time.Hour), time.Now())
+	repo.Team.GetTeam(ctx, "ghosts")
+
+	spans := exporter.GetSpans()
+
+	load := findSpan(t, spans, "memory.StatisticsRepo.GetMemberLoad")
+	if team, _ := spanAttribute(load, "team.name"); team.AsString() != "backend" {
+		t.Fatalf("Expected team.name backend, got %q", team.AsString())
+	}
+	if system, _ := spanAttribute(load, "db.system"); system.AsString() != "memory" {
+		t.Fatalf("Expected db.system memory, got %q", system.AsString())
+	}
+
+	notFound := findSpan(t, spans, "memory.TeamRepo.GetTeam")
+	if code, _ := spanAttribute(notFound, "error.code"); code.AsString() != "NOT_FOUND" {
+		t.Fatalf("Expected error.code NOT_FOUND, got %q", code.AsString())
+	}
+	if notFound.Status.Code == codes.Error {
+		t.Fatal("Domain errors must not mark the span as failed")
+	}
+}
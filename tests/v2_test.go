@@ -0,0 +1,137 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestV2CreateSetsLocationAndETag(t *testing.T) {
+	router := newStubRouter(&stubService{})
+
+	body, _ := json.Marshal(map[string]string{
+		"pull_request_id":   "pr/42",
+		"pull_request_name": "Slash in id",
+		"author_id":         "u1",
+	})
+	req := httptest.NewRequest(http.MethodPost, "/v2/pull-requests", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	if location := w.Header().Get("Location"); location != "/v2/pull-requests/pr%2F42" {
+		t.Fatalf("Unexpected Location header %q", location)
+	}
+
+	if w.Header().Get("ETag") == "" {
+		t.Fatal("Expected ETag header")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, w.Header().Get("Location"), nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 for Location, got %d. Body: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestV2ConditionalRequests(t *testing.T) {
+	router := newStubRouter(&stubService{})
+
+	req := httptest.NewRequest(http.MethodGet, "/v2/users/u1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	tag := w.Header().Get("ETag")
+	if w.Code != http.StatusOK || tag == "" {
+		t.Fatalf("Expected 200 with ETag, got %d and %q", w.Code, tag)
+	}
+
+	t.Run("If-None-Match returns 304", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v2/users/u1", nil)
+		req.Header.Set("If-None-Match", tag)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusNotModified {
+			t.Fatalf("Expected status 304, got %d", w.Code)
+		}
+		if w.Body.Len() != 0 {
+			t.Fatal("304 response must not have a body")
+		}
+	})
+
+	t.Run("Stale If-Match is rejected", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPatch, "/v2/users/u1", bytes.NewReader([]byte(`{"is_active": false}`)))
+		req.Header.Set("If-Match", `"stale"`)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusPreconditionFailed {
+			t.Fatalf("Expected status 412, got %d", w.Code)
+		}
+
+		var errResp map[string]map[string]string
+		json.NewDecoder(w.Body).Decode(&errResp)
+		if errResp["error"]["code"] != "PRECONDITION_FAILED" {
+			t.Fatalf("Expected PRECONDITION_FAILED, got %v", errResp)
+		}
+	})
+
+	t.Run("Weak If-Match is rejected", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPatch, "/v2/users/u1", bytes.NewReader([]byte(`{"is_active": false}`)))
+		req.Header.Set("If-Match", "W/"+tag)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusPreconditionFailed {
+			t.Fatalf("Expected status 412, got %d", w.Code)
+		}
+	})
+
+	t.Run("Current If-Match is accepted", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPatch, "/v2/users/u1", bytes.NewReader([]byte(`{"is_active": false}`)))
+		req.Header.Set("If-Match", tag)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+		if w.Header().Get("ETag") == tag {
+			t.Fatal("Expected a new ETag after the update")
+		}
+	})
+
+	t.Run("Stale If-Match blocks reassign", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v2/pull-requests/pr-1/reviewers/u2:reassign", nil)
+		req.Header.Set("If-Match", `"stale"`)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusPreconditionFailed {
+			t.Fatalf("Expected status 412, got %d", w.Code)
+		}
+	})
+}
+
+func TestV2MethodNotAllowed(t *testing.T) {
+	router := newStubRouter(&stubService{})
+
+	req := httptest.NewRequest(http.MethodDelete, "/v2/users/u1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("Expected status 405, got %d", w.Code)
+	}
+	if allow := w.Header().Get("Allow"); allow != "GET, PATCH" {
+		t.Fatalf("Unexpected Allow header %q", allow)
+	}
+}